@@ -1,40 +1,67 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"ndugu-backend/internal/config"
+
 	hydra "github.com/ory/hydra-client-go/v2"
 	// "github.com/ory/keto-client-go" // Temporairement commenté
 	kratos "github.com/ory/kratos-client-go"
 )
 
+// KratosSessionCookie nom du cookie de session posé par Kratos pour les navigateurs
+const KratosSessionCookie = "ory_kratos_session"
+
+var (
+	// ErrInvalidSession est renvoyée lorsque Kratos refuse la session (401/403, 400 à la déconnexion)
+	ErrInvalidSession = errors.New("session invalide")
+	// ErrIdentityNotFound est renvoyée lorsque l'identité n'existe pas dans Kratos (404)
+	ErrIdentityNotFound = errors.New("identité introuvable")
+	// ErrIdentityConflict est renvoyée lorsque Kratos refuse des traits déjà utilisés par une autre identité (409)
+	ErrIdentityConflict = errors.New("identité en conflit")
+)
+
 // OryClient encapsule les clients pour les services Ory
 type OryClient struct {
 	Kratos *kratos.APIClient
 	Hydra  *hydra.APIClient
 	// Keto   *keto.APIClient // Temporairement commenté
+
+	kratosPublicURL string
+	httpClient      *http.Client
 }
 
-// NewOryClient crée une nouvelle instance du client Ory
-func NewOryClient() *OryClient {
+// NewOryClientWithConfig crée une nouvelle instance du client Ory.
+// Si httpClient est nil, http.DefaultClient est utilisé.
+func NewOryClientWithConfig(cfg config.OryConfig, httpClient *http.Client) *OryClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
 	// Configuration Kratos
 	kratosConfig := kratos.NewConfiguration()
+	kratosConfig.HTTPClient = httpClient
 	kratosConfig.Servers = []kratos.ServerConfiguration{
 		{
-			URL: "http://kratos:4434", // Admin API
+			URL: cfg.Kratos.AdminURL, // Admin API
 		},
 	}
 	kratosClient := kratos.NewAPIClient(kratosConfig)
 
 	// Configuration Hydra
 	hydraConfig := hydra.NewConfiguration()
+	hydraConfig.HTTPClient = httpClient
 	hydraConfig.Servers = []hydra.ServerConfiguration{
 		{
-			URL: "http://hydra:4445", // Admin API
+			URL: cfg.Hydra.AdminURL, // Admin API
 		},
 	}
 	hydraClient := hydra.NewAPIClient(hydraConfig)
@@ -52,6 +79,8 @@ func NewOryClient() *OryClient {
 		Kratos: kratosClient,
 		Hydra:  hydraClient,
 		// Keto:   ketoClient, // Temporairement commenté
+		kratosPublicURL: strings.TrimSuffix(cfg.Kratos.PublicURL, "/"),
+		httpClient:      httpClient,
 	}
 }
 
@@ -79,53 +108,107 @@ func (c *OryClient) CreateUser(ctx context.Context, email, firstName, lastName s
 		},
 	}
 
-	identity, _, err := c.Kratos.IdentityApi.CreateIdentity(ctx).CreateIdentityBody(createIdentityBody).Execute()
+	identity, resp, err := c.Kratos.IdentityApi.CreateIdentity(ctx).CreateIdentityBody(createIdentityBody).Execute()
+	if err != nil {
+		return nil, identityError(resp, fmt.Errorf("erreur lors de la création de l'identité: %w", err))
+	}
+
+	return toUser(identity), nil
+}
+
+// GetUser récupère un utilisateur par son ID
+func (c *OryClient) GetUser(ctx context.Context, userID string) (*User, error) {
+	identity, resp, err := c.Kratos.IdentityApi.GetIdentity(ctx, userID).Execute()
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de l'identité: %w", err)
+		return nil, identityError(resp, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err))
 	}
 
-	// Convertir en User
-	traits := identity.Traits.(map[string]interface{})
-	user := &User{
-		ID:     identity.Id,
-		Email:  traits["email"].(string),
-		Name:   traits["name"].(map[string]interface{}),
-		Traits: traits,
+	return toUser(identity), nil
+}
+
+// UpdateUser remplace l'email et le nom d'une identité Kratos.
+// Les autres traits, le schéma, l'état et les métadonnées de l'identité sont conservés:
+// l'API admin remplace l'identité entière.
+func (c *OryClient) UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*User, error) {
+	identity, resp, err := c.Kratos.IdentityApi.GetIdentity(ctx, userID).Execute()
+	if err != nil {
+		return nil, identityError(resp, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err))
 	}
 
-	if identity.CreatedAt != nil {
-		user.CreatedAt = *identity.CreatedAt
+	traits := map[string]interface{}{}
+	if current, ok := identity.Traits.(map[string]interface{}); ok {
+		for key, value := range current {
+			traits[key] = value
+		}
 	}
-	if identity.UpdatedAt != nil {
-		user.UpdatedAt = *identity.UpdatedAt
+	name := map[string]interface{}{}
+	if current, ok := traits["name"].(map[string]interface{}); ok {
+		for key, value := range current {
+			name[key] = value
+		}
+	}
+	name["first"] = firstName
+	name["last"] = lastName
+	traits["email"] = email
+	traits["name"] = name
+
+	state := identity.GetState()
+	if state == "" {
+		state = kratos.IDENTITYSTATE_ACTIVE
+	}
+	updateIdentityBody := kratos.UpdateIdentityBody{
+		SchemaId:       identity.SchemaId,
+		State:          state,
+		Traits:         traits,
+		MetadataPublic: identity.MetadataPublic,
+		MetadataAdmin:  identity.MetadataAdmin,
+	}
+
+	updated, resp, err := c.Kratos.IdentityApi.UpdateIdentity(ctx, userID).UpdateIdentityBody(updateIdentityBody).Execute()
+	if err != nil {
+		return nil, identityError(resp, fmt.Errorf("erreur lors de la mise à jour de l'identité: %w", err))
 	}
 
-	return user, nil
+	return toUser(updated), nil
 }
 
-// GetUser récupère un utilisateur par son ID
-func (c *OryClient) GetUser(ctx context.Context, userID string) (*User, error) {
-	identity, _, err := c.Kratos.IdentityApi.GetIdentity(ctx, userID).Execute()
+// DeleteUser supprime définitivement une identité Kratos et ses sessions
+func (c *OryClient) DeleteUser(ctx context.Context, userID string) error {
+	resp, err := c.Kratos.IdentityApi.DeleteIdentity(ctx, userID).Execute()
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
+		return identityError(resp, fmt.Errorf("erreur lors de la suppression de l'identité: %w", err))
 	}
+	return nil
+}
 
-	traits := identity.Traits.(map[string]interface{})
-	user := &User{
-		ID:     identity.Id,
-		Email:  traits["email"].(string),
-		Name:   traits["name"].(map[string]interface{}),
-		Traits: traits,
+// toUser convertit une identité Kratos en User
+func toUser(identity *kratos.Identity) *User {
+	traits, _ := identity.Traits.(map[string]interface{})
+	email, _ := traits["email"].(string)
+	name, _ := traits["name"].(map[string]interface{})
+
+	return &User{
+		ID:        identity.Id,
+		Email:     email,
+		Name:      name,
+		Traits:    traits,
+		CreatedAt: identity.GetCreatedAt(),
+		UpdatedAt: identity.GetUpdatedAt(),
 	}
+}
 
-	if identity.CreatedAt != nil {
-		user.CreatedAt = *identity.CreatedAt
+// identityError distingue une identité absente (404) ou en conflit (409) des autres erreurs Kratos
+func identityError(resp *http.Response, err error) error {
+	if resp == nil {
+		return err
 	}
-	if identity.UpdatedAt != nil {
-		user.UpdatedAt = *identity.UpdatedAt
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %v", ErrIdentityNotFound, err)
+	case http.StatusConflict:
+		return fmt.Errorf("%w: %v", ErrIdentityConflict, err)
 	}
-
-	return user, nil
+	return err
 }
 
 // CreateOAuth2Client crée un client OAuth2 via Hydra - Temporairement commenté
@@ -182,25 +265,70 @@ func (c *OryClient) GetUser(ctx context.Context, userID string) (*User, error) {
 // 	return len(response.RelationTuples) > 0, nil
 // }
 
-// ValidateSession valide une session Kratos
+// ValidateSession valide un token de session Kratos (clients natifs, en-tête X-Session-Token)
 func (c *OryClient) ValidateSession(ctx context.Context, sessionToken string) (*kratos.Session, error) {
-	// Créer une requête avec le cookie de session
-	req, err := http.NewRequestWithContext(ctx, "GET", "http://kratos:4433/sessions/whoami", nil)
+	return c.whoami(ctx, func(req *http.Request) {
+		req.Header.Set("X-Session-Token", sessionToken)
+	})
+}
+
+// ValidateSessionCookie valide le cookie de session Kratos d'un navigateur
+func (c *OryClient) ValidateSessionCookie(ctx context.Context, cookie string) (*kratos.Session, error) {
+	return c.whoami(ctx, func(req *http.Request) {
+		req.AddCookie(&http.Cookie{Name: KratosSessionCookie, Value: cookie})
+	})
+}
+
+// RevokeSession révoque un token de session Kratos (déconnexion d'un client natif)
+func (c *OryClient) RevokeSession(ctx context.Context, sessionToken string) error {
+	body, err := json.Marshal(kratos.NewPerformNativeLogoutBody(sessionToken))
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de la requête: %w", err)
+		return fmt.Errorf("erreur lors de l'encodage de la requête: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.kratosPublicURL+"/self-service/logout/api", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("erreur lors de la création de la requête: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la requête: %w", err)
 	}
+	defer resp.Body.Close()
 
-	req.Header.Set("Cookie", fmt.Sprintf("ory_kratos_session=%s", sessionToken))
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: status %d", ErrInvalidSession, resp.StatusCode)
+	default:
+		return fmt.Errorf("réponse inattendue de Kratos: status %d", resp.StatusCode)
+	}
+}
+
+// whoami interroge l'API publique de Kratos avec les identifiants de session fournis
+func (c *OryClient) whoami(ctx context.Context, authenticate func(req *http.Request)) (*kratos.Session, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.kratosPublicURL+"/sessions/whoami", nil)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la requête: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	authenticate(req)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la requête: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("session invalide: status %d", resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("%w: status %d", ErrInvalidSession, resp.StatusCode)
+	default:
+		return nil, fmt.Errorf("réponse inattendue de Kratos: status %d", resp.StatusCode)
 	}
 
 	var session kratos.Session
@@ -0,0 +1,33 @@
+package auth
+
+import "context"
+
+// Méthodes d'authentification d'une identité
+const (
+	MethodSession     = "session"      // Session Kratos (X-Session-Token)
+	MethodAccessToken = "access_token" // Jeton d'accès OAuth2 Hydra (Authorization: Bearer)
+)
+
+// Identity représente l'appelant authentifié d'une requête
+type Identity struct {
+	Subject               string
+	Email                 string
+	Method                string
+	ClientID              string
+	Scopes                []string
+	AAL                   string
+	AuthenticationMethods []string
+}
+
+type identityKey struct{}
+
+// WithIdentity attache l'identité authentifiée au contexte
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext retourne l'identité authentifiée attachée au contexte
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
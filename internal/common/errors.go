@@ -26,6 +26,18 @@ const (
 	// Erreurs spécifiques aux clients
 	ErrCodeCustomerNotFound ErrorCode = "CUSTOMER_NOT_FOUND"
 	ErrCodeCustomerExists   ErrorCode = "CUSTOMER_EXISTS"
+	ErrCodeCustomerInactive ErrorCode = "CUSTOMER_INACTIVE"
+
+	// Erreurs d'authentification
+	ErrCodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
+
+	// Erreurs spécifiques aux clients OAuth2
+	ErrCodeOAuth2ClientNotFound ErrorCode = "OAUTH2_CLIENT_NOT_FOUND"
+	ErrCodeOAuth2ClientExists   ErrorCode = "OAUTH2_CLIENT_EXISTS"
+
+	// Erreurs d'idempotence
+	ErrCodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyKeyInUse  ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
 
 	// Erreurs Ory
 	ErrCodeKratosError ErrorCode = "KRATOS_ERROR"
@@ -35,10 +47,23 @@ const (
 
 // AppError représente une erreur de l'application
 type AppError struct {
-	Code       ErrorCode `json:"code"`
-	Message    string    `json:"message"`
-	Details    string    `json:"details,omitempty"`
-	HTTPStatus int       `json:"-"`
+	Code       ErrorCode        `json:"code"`
+	Message    string           `json:"message"`
+	Details    string           `json:"details,omitempty"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+	HTTPStatus int              `json:"-"`
+
+	// messageKey clé du catalogue utilisée par Localize pour traduire Message
+	messageKey    string
+	messageParams map[string]string
+}
+
+// FieldViolation décrit un champ invalide d'une requête
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Rule        string `json:"rule,omitempty"`  // règle non respectée, ex: "required", "max"
+	Param       string `json:"param,omitempty"` // paramètre de la règle, ex: "50" pour max=50
+	Description string `json:"description"`
 }
 
 // Error implémente l'interface error
@@ -63,19 +88,56 @@ func NewAppError(code ErrorCode, message string, details ...string) *AppError {
 	return appErr
 }
 
+// newCatalogError crée une erreur dont le message provient du catalogue (langue par défaut)
+func newCatalogError(code ErrorCode, key string, params map[string]string) *AppError {
+	appErr := NewAppError(code, Translate(DefaultLanguage, key, params))
+	appErr.messageKey = key
+	appErr.messageParams = params
+	return appErr
+}
+
+// codeError crée une erreur portant le message générique de son code
+func codeError(code ErrorCode) *AppError {
+	return newCatalogError(code, string(code), nil)
+}
+
+// NewValidationError crée une erreur INVALID_INPUT décrivant les champs invalides
+func NewValidationError(violations ...FieldViolation) *AppError {
+	if len(violations) == 1 {
+		appErr := NewAppError(ErrCodeInvalidInput, violations[0].Description)
+		appErr.Violations = violations
+		return appErr
+	}
+	appErr := codeError(ErrCodeInvalidInput)
+	appErr.Violations = violations
+	return appErr
+}
+
+// NewFieldError crée une erreur INVALID_INPUT pour un seul champ ne respectant pas
+// une règle du catalogue (ex: NewFieldError("clientId", "required"))
+func NewFieldError(field, rule string, param ...string) *AppError {
+	var p string
+	if len(param) > 0 {
+		p = param[0]
+	}
+	return NewValidationError(NewViolation(field, rule, p))
+}
+
 // getHTTPStatus retourne le code HTTP correspondant au code d'erreur
 func getHTTPStatus(code ErrorCode) int {
 	switch code {
 	case ErrCodeInvalidInput:
 		return http.StatusBadRequest
-	case ErrCodeNotFound, ErrCodeUserNotFound, ErrCodeCustomerNotFound:
+	case ErrCodeNotFound, ErrCodeUserNotFound, ErrCodeCustomerNotFound, ErrCodeOAuth2ClientNotFound:
 		return http.StatusNotFound
-	case ErrCodeUnauthorized, ErrCodeInvalidSession, ErrCodeSessionExpired:
+	case ErrCodeUnauthorized, ErrCodeInvalidSession, ErrCodeSessionExpired, ErrCodeInvalidCredentials:
 		return http.StatusUnauthorized
-	case ErrCodeForbidden:
+	case ErrCodeForbidden, ErrCodeCustomerInactive:
 		return http.StatusForbidden
-	case ErrCodeConflict, ErrCodeUserExists, ErrCodeCustomerExists:
+	case ErrCodeConflict, ErrCodeUserExists, ErrCodeCustomerExists, ErrCodeOAuth2ClientExists, ErrCodeIdempotencyKeyInUse:
 		return http.StatusConflict
+	case ErrCodeIdempotencyKeyReused:
+		return http.StatusUnprocessableEntity
 	default:
 		return http.StatusInternalServerError
 	}
@@ -83,25 +145,37 @@ func getHTTPStatus(code ErrorCode) int {
 
 // Erreurs prédéfinies
 var (
-	ErrInternalServer = NewAppError(ErrCodeInternal, "Erreur interne du serveur")
-	ErrInvalidInput   = NewAppError(ErrCodeInvalidInput, "Données d'entrée invalides")
-	ErrNotFound       = NewAppError(ErrCodeNotFound, "Ressource non trouvée")
-	ErrUnauthorized   = NewAppError(ErrCodeUnauthorized, "Non autorisé")
-	ErrForbidden      = NewAppError(ErrCodeForbidden, "Accès interdit")
-	ErrConflict       = NewAppError(ErrCodeConflict, "Conflit de ressources")
+	ErrInternalServer = codeError(ErrCodeInternal)
+	ErrInvalidInput   = codeError(ErrCodeInvalidInput)
+	ErrNotFound       = codeError(ErrCodeNotFound)
+	ErrUnauthorized   = codeError(ErrCodeUnauthorized)
+	ErrForbidden      = codeError(ErrCodeForbidden)
+	ErrConflict       = codeError(ErrCodeConflict)
 
 	// Erreurs utilisateurs
-	ErrUserNotFound   = NewAppError(ErrCodeUserNotFound, "Utilisateur non trouvé")
-	ErrUserExists     = NewAppError(ErrCodeUserExists, "Utilisateur déjà existant")
-	ErrInvalidSession = NewAppError(ErrCodeInvalidSession, "Session invalide")
-	ErrSessionExpired = NewAppError(ErrCodeSessionExpired, "Session expirée")
+	ErrUserNotFound   = codeError(ErrCodeUserNotFound)
+	ErrUserExists     = codeError(ErrCodeUserExists)
+	ErrInvalidSession = codeError(ErrCodeInvalidSession)
+	ErrSessionExpired = codeError(ErrCodeSessionExpired)
 
 	// Erreurs clients
-	ErrCustomerNotFound = NewAppError(ErrCodeCustomerNotFound, "Client non trouvé")
-	ErrCustomerExists   = NewAppError(ErrCodeCustomerExists, "Client déjà existant")
+	ErrCustomerNotFound = codeError(ErrCodeCustomerNotFound)
+	ErrCustomerExists   = codeError(ErrCodeCustomerExists)
+	ErrCustomerInactive = codeError(ErrCodeCustomerInactive)
+
+	// Erreurs d'authentification
+	ErrInvalidCredentials = codeError(ErrCodeInvalidCredentials)
+
+	// Erreurs clients OAuth2
+	ErrOAuth2ClientNotFound = codeError(ErrCodeOAuth2ClientNotFound)
+	ErrOAuth2ClientExists   = codeError(ErrCodeOAuth2ClientExists)
+
+	// Erreurs d'idempotence
+	ErrIdempotencyKeyReused = codeError(ErrCodeIdempotencyKeyReused)
+	ErrIdempotencyKeyInUse  = codeError(ErrCodeIdempotencyKeyInUse)
 
 	// Erreurs Ory
-	ErrKratosError = NewAppError(ErrCodeKratosError, "Erreur Kratos")
-	ErrHydraError  = NewAppError(ErrCodeHydraError, "Erreur Hydra")
-	ErrKetoError   = NewAppError(ErrCodeKetoError, "Erreur Keto")
+	ErrKratosError = codeError(ErrCodeKratosError)
+	ErrHydraError  = codeError(ErrCodeHydraError)
+	ErrKetoError   = codeError(ErrCodeKetoError)
 )
@@ -0,0 +1,209 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Langues supportées pour les messages destinés aux utilisateurs
+const (
+	LangFR = "fr"
+	LangEN = "en"
+
+	// DefaultLanguage langue des messages lorsque le client n'en demande aucune supportée
+	DefaultLanguage = LangFR
+)
+
+// catalogs messages par langue, indexés par code d'erreur (ex: "USER_NOT_FOUND")
+// ou par règle de validation (ex: "validation.max"). Les paramètres {nom} sont interpolés.
+var catalogs = map[string]map[string]string{
+	LangFR: {
+		string(ErrCodeInternal):     "Erreur interne du serveur",
+		string(ErrCodeInvalidInput): "Données d'entrée invalides",
+		string(ErrCodeNotFound):     "Ressource non trouvée",
+		string(ErrCodeUnauthorized): "Non autorisé",
+		string(ErrCodeForbidden):    "Accès interdit",
+		string(ErrCodeConflict):     "Conflit de ressources",
+
+		string(ErrCodeUserNotFound):   "Utilisateur non trouvé",
+		string(ErrCodeUserExists):     "Utilisateur déjà existant",
+		string(ErrCodeInvalidSession): "Session invalide",
+		string(ErrCodeSessionExpired): "Session expirée",
+
+		string(ErrCodeCustomerNotFound): "Client non trouvé",
+		string(ErrCodeCustomerExists):   "Client déjà existant",
+		string(ErrCodeCustomerInactive): "Compte client désactivé",
+
+		string(ErrCodeInvalidCredentials): "Identifiants invalides",
+
+		string(ErrCodeOAuth2ClientNotFound): "Client OAuth2 non trouvé",
+		string(ErrCodeOAuth2ClientExists):   "Client OAuth2 déjà existant",
+
+		string(ErrCodeIdempotencyKeyReused): "Clé d'idempotence déjà utilisée pour une requête différente",
+		string(ErrCodeIdempotencyKeyInUse):  "Une requête avec cette clé d'idempotence est en cours de traitement",
+
+		string(ErrCodeKratosError): "Erreur Kratos",
+		string(ErrCodeHydraError):  "Erreur Hydra",
+		string(ErrCodeKetoError):   "Erreur Keto",
+
+		"request.canceled":          "Requête annulée",
+		"request.deadline_exceeded": "Délai de la requête dépassé",
+
+		"validation.required":       "Champ requis",
+		"validation.required_named": "{field} est requis",
+		"validation.min":            "Doit contenir au moins {param} caractères",
+		"validation.max":            "Ne peut pas dépasser {param} caractères",
+		"validation.maxItems":       "Ne peut pas contenir plus de {param} éléments",
+		"validation.gte":            "Doit être supérieur ou égal à {param}",
+		"validation.oneof":          "Doit être l'une des valeurs: {param}",
+		"validation.email":          "Format d'email invalide",
+		"validation.url":            "URI absolue invalide",
+		"validation.e164":           "Numéro de téléphone invalide, format international attendu (ex: +243812345678)",
+		"validation.phoneCode":      "Indicatif téléphonique invalide (ex: +243)",
+		"validation.ketoNamespace":  "Namespace inconnu",
+	},
+	LangEN: {
+		string(ErrCodeInternal):     "Internal server error",
+		string(ErrCodeInvalidInput): "Invalid input data",
+		string(ErrCodeNotFound):     "Resource not found",
+		string(ErrCodeUnauthorized): "Unauthorized",
+		string(ErrCodeForbidden):    "Access denied",
+		string(ErrCodeConflict):     "Resource conflict",
+
+		string(ErrCodeUserNotFound):   "User not found",
+		string(ErrCodeUserExists):     "User already exists",
+		string(ErrCodeInvalidSession): "Invalid session",
+		string(ErrCodeSessionExpired): "Session expired",
+
+		string(ErrCodeCustomerNotFound): "Customer not found",
+		string(ErrCodeCustomerExists):   "Customer already exists",
+		string(ErrCodeCustomerInactive): "Customer account deactivated",
+
+		string(ErrCodeInvalidCredentials): "Invalid credentials",
+
+		string(ErrCodeOAuth2ClientNotFound): "OAuth2 client not found",
+		string(ErrCodeOAuth2ClientExists):   "OAuth2 client already exists",
+
+		string(ErrCodeIdempotencyKeyReused): "Idempotency key already used for a different request",
+		string(ErrCodeIdempotencyKeyInUse):  "A request with this idempotency key is being processed",
+
+		string(ErrCodeKratosError): "Identity service error",
+		string(ErrCodeHydraError):  "OAuth2 service error",
+		string(ErrCodeKetoError):   "Permission service error",
+
+		"request.canceled":          "Request canceled",
+		"request.deadline_exceeded": "Request deadline exceeded",
+
+		"validation.required":       "Field is required",
+		"validation.required_named": "{field} is required",
+		"validation.min":            "Must contain at least {param} characters",
+		"validation.max":            "Must not exceed {param} characters",
+		"validation.maxItems":       "Must not contain more than {param} items",
+		"validation.gte":            "Must be greater than or equal to {param}",
+		"validation.oneof":          "Must be one of: {param}",
+		"validation.email":          "Invalid email format",
+		"validation.url":            "Invalid absolute URI",
+		"validation.e164":           "Invalid phone number, international format expected (e.g. +243812345678)",
+		"validation.phoneCode":      "Invalid country calling code (e.g. +243)",
+		"validation.ketoNamespace":  "Unknown namespace",
+	},
+}
+
+// Translate retourne le message de la clé dans la langue demandée, à défaut dans la
+// langue par défaut, à défaut la clé elle-même. params remplace les {nom} du message.
+func Translate(lang, key string, params map[string]string) string {
+	message, ok := catalogs[lang][key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+	return message
+}
+
+// ParseAcceptLanguage retourne la langue supportée préférée d'un en-tête Accept-Language
+// (ex: "en-US,en;q=0.9,fr;q=0.8" donne "en"), ou DefaultLanguage.
+func ParseAcceptLanguage(header string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := catalogs[primary]; ok && quality > 0 {
+			candidates = append(candidates, candidate{lang: primary, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLanguage
+	}
+	// Tri stable: à qualité égale, l'ordre de l'en-tête est conservé
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang
+}
+
+// languageKey clé de contexte de la langue de la requête
+type languageKey struct{}
+
+// WithLanguage associe la langue de la requête au contexte
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// LanguageFromContext retourne la langue de la requête, ou DefaultLanguage
+func LanguageFromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// RequestLanguage retourne la langue demandée par l'en-tête Accept-Language d'une requête HTTP
+func RequestLanguage(r *http.Request) string {
+	return ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// Localize retourne une copie de l'erreur dont les messages sont traduits.
+// Les violations portant une règle sont traduites individuellement; un message
+// spécifique sans traduction est conservé dans la langue par défaut et remplacé
+// par le message générique du code dans les autres langues.
+func (e *AppError) Localize(lang string) *AppError {
+	localized := *e
+
+	if len(e.Violations) > 0 {
+		localized.Violations = make([]FieldViolation, len(e.Violations))
+		for i, v := range e.Violations {
+			if v.Rule != "" {
+				v.Description = Translate(lang, "validation."+v.Rule, map[string]string{"param": v.Param})
+			}
+			localized.Violations[i] = v
+		}
+	}
+
+	switch {
+	case e.messageKey != "":
+		localized.Message = Translate(lang, e.messageKey, e.messageParams)
+	case len(localized.Violations) == 1:
+		localized.Message = localized.Violations[0].Description
+	case lang != DefaultLanguage || e.Message == Translate(DefaultLanguage, string(e.Code), nil):
+		localized.Message = Translate(lang, string(e.Code), nil)
+	}
+	return &localized
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: LangFR},
+		{header: "en", want: LangEN},
+		{header: "en-US,en;q=0.9,fr;q=0.8", want: LangEN},
+		{header: "fr-CD, en;q=0.5", want: LangFR},
+		{header: "de-DE,en;q=0.7,fr;q=0.9", want: LangFR},
+		{header: "sw, ln", want: LangFR},
+		{header: "EN-gb", want: LangEN},
+		{header: "fr;q=0, en;q=0.1", want: LangEN},
+		{header: "en;q=abc", want: LangFR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppError_Localize(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         *AppError
+		lang        string
+		wantMessage string
+	}{
+		{name: "predefined error in english", err: ErrUserNotFound, lang: LangEN, wantMessage: "User not found"},
+		{name: "predefined error in french", err: ErrUserNotFound, lang: LangFR, wantMessage: "Utilisateur non trouvé"},
+		{name: "specific message kept in french", err: NewAppError(ErrCodeHydraError, "Erreur lors de la création du client OAuth2"), lang: LangFR, wantMessage: "Erreur lors de la création du client OAuth2"},
+		{name: "specific message falls back to the code in english", err: NewAppError(ErrCodeHydraError, "Erreur lors de la création du client OAuth2"), lang: LangEN, wantMessage: "OAuth2 service error"},
+		{name: "named required field", err: ValidateRequired("", "Login challenge").(*AppError), lang: LangEN, wantMessage: "Login challenge is required"},
+		{name: "single violation with parameter", err: NewValidationError(NewViolation("firstName", "max", "50")), lang: LangEN, wantMessage: "Must not exceed 50 characters"},
+		{name: "several violations", err: NewValidationError(NewViolation("email", "email", ""), NewViolation("lastName", "required", "")), lang: LangEN, wantMessage: "Invalid input data"},
+		{name: "unsupported language uses french", err: ErrCustomerInactive, lang: "de", wantMessage: "Compte client désactivé"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := tt.err.Localize(tt.lang)
+
+			// Assert
+			if got.Message != tt.wantMessage {
+				t.Errorf("Localize(%q).Message = %q, want %q", tt.lang, got.Message, tt.wantMessage)
+			}
+			if got.Code != tt.err.Code || got.HTTPStatus != tt.err.HTTPStatus {
+				t.Errorf("Localize() changed code or status: %+v", got)
+			}
+		})
+	}
+}
+
+func TestAppError_LocalizeViolations(t *testing.T) {
+	err := NewValidationError(NewViolation("email", "email", ""), NewViolation("password", "min", "8"))
+
+	got := err.Localize(LangEN)
+
+	if got.Violations[1].Description != "Must contain at least 8 characters" {
+		t.Errorf("violation description = %q, want english with parameter", got.Violations[1].Description)
+	}
+	if err.Violations[1].Description != "Doit contenir au moins 8 caractères" {
+		t.Errorf("Localize() modified the original error: %q", err.Violations[1].Description)
+	}
+}
+
+func TestCatalogs_SameKeys(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range catalogs[DefaultLanguage] {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("catalog %q is missing key %q", lang, key)
+			}
+		}
+		for key := range catalog {
+			if _, ok := catalogs[DefaultLanguage][key]; !ok {
+				t.Errorf("catalog %q has key %q missing from the default catalog", lang, key)
+			}
+		}
+	}
+}
@@ -1,111 +1,263 @@
 package common
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
 )
 
-// Logger interface pour le logging
+// Logger interface pour le logging structuré.
+// Le message est fixe; les données variables sont passées en paires clé/valeur:
+//
+//	logger.Info("Client créé", "customerId", customer.ID)
 type Logger interface {
-	Info(msg string, args ...interface{})
-	Error(msg string, args ...interface{})
-	Debug(msg string, args ...interface{})
-	Warn(msg string, args ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+	Debug(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
 	WithField(key string, value interface{}) Logger
 	WithFields(fields map[string]interface{}) Logger
+	// WithContext ajoute les champs de corrélation du contexte (request_id, user_id, method, trace_id)
+	WithContext(ctx context.Context) Logger
 	SetLevel(level string)
+	// SetFormat choisit le format de sortie: "text" ou "json"
+	SetFormat(format string)
+	// SetRedactor choisit le masquage des données personnelles appliqué à chaque entrée
+	SetRedactor(redactor *Redactor)
+}
+
+// Clés des champs de corrélation portés par le contexte
+const (
+	LogKeyRequestID = "request_id"
+	LogKeyUserID    = "user_id"
+	LogKeyMethod    = "method"
+	LogKeyTraceID   = "trace_id"
+)
+
+// RequestIDHeader métadonnée gRPC et en-tête HTTP portant l'identifiant de corrélation d'une requête
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength longueur maximale d'un identifiant de requête fourni par un client
+const maxRequestIDLength = 128
+
+// badKey clé des valeurs passées sans clé textuelle
+const badKey = "!BADKEY"
+
+// logFieldsKey clé de contexte des champs de log
+type logFieldsKey struct{}
+
+// WithLogFields retourne un contexte enrichi de champs de log (paires clé/valeur),
+// repris par Logger.WithContext
+func WithLogFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	fields := make(map[string]interface{})
+	for key, value := range LogFieldsFromContext(ctx) {
+		fields[key] = value
+	}
+	for key, value := range fieldsFromPairs(keysAndValues) {
+		fields[key] = value
+	}
+	return context.WithValue(ctx, logFieldsKey{}, fields)
+}
+
+// LogFieldsFromContext retourne les champs de log du contexte (à ne pas modifier)
+func LogFieldsFromContext(ctx context.Context) map[string]interface{} {
+	fields, _ := ctx.Value(logFieldsKey{}).(map[string]interface{})
+	return fields
+}
+
+// RequestIDFromContext retourne l'identifiant de la requête en cours, ou ""
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := LogFieldsFromContext(ctx)[LogKeyRequestID].(string)
+	return requestID
+}
+
+// RequestIDOrNew reprend l'identifiant de requête fourni par un client (ex: APISIX) s'il
+// peut être journalisé tel quel (longueur bornée, ASCII imprimable sans espace), sinon en génère un
+func RequestIDOrNew(id string) string {
+	if id == "" || len(id) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+	for _, r := range id {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return uuid.NewString()
+		}
+	}
+	return id
+}
+
+// fieldsFromPairs convertit des paires clé/valeur en champs.
+// Une valeur sans clé textuelle est conservée sous la clé "!BADKEY".
+func fieldsFromPairs(keysAndValues []interface{}) map[string]interface{} {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(keysAndValues)/2+1)
+	for i := 0; i < len(keysAndValues); i++ {
+		key, ok := keysAndValues[i].(string)
+		if !ok || i+1 == len(keysAndValues) {
+			fields[badKey] = keysAndValues[i]
+			continue
+		}
+		fields[key] = keysAndValues[i+1]
+		i++
+	}
+	return fields
 }
 
-// SimpleLogger implémentation simple du logger
+// Niveaux de log, du plus verbeux au plus restrictif
+const (
+	levelDebug = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+// parseLevel convertit un niveau textuel; un niveau inconnu vaut "info"
+func parseLevel(level string) int {
+	switch strings.ToLower(level) {
+	case "debug":
+		return levelDebug
+	case "warn":
+		return levelWarn
+	case "error":
+		return levelError
+	default:
+		return levelInfo
+	}
+}
+
+// SimpleLogger implémentation simple du logger (sortie texte "message clé=valeur"), utilisée dans les tests
 type SimpleLogger struct {
 	infoLogger  *log.Logger
 	errorLogger *log.Logger
 	debugLogger *log.Logger
 	warnLogger  *log.Logger
+	level       int
+	fields      map[string]interface{}
+	redactor    *Redactor
 }
 
 // NewSimpleLogger crée un nouveau logger simple
 func NewSimpleLogger() *SimpleLogger {
 	return &SimpleLogger{
-		infoLogger:  log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile),
-		errorLogger: log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile),
-		debugLogger: log.New(os.Stdout, "DEBUG: ", log.Ldate|log.Ltime|log.Lshortfile),
-		warnLogger:  log.New(os.Stdout, "WARN: ", log.Ldate|log.Ltime|log.Lshortfile),
+		infoLogger:  log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime),
+		errorLogger: log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime),
+		debugLogger: log.New(os.Stdout, "DEBUG: ", log.Ldate|log.Ltime),
+		warnLogger:  log.New(os.Stdout, "WARN: ", log.Ldate|log.Ltime),
+		level:       levelInfo,
+		redactor:    defaultRedactor,
 	}
 }
 
 // Info log un message d'information
-func (l *SimpleLogger) Info(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.infoLogger.Printf(msg, args...)
-	} else {
-		l.infoLogger.Println(msg)
-	}
+func (l *SimpleLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.print(levelInfo, l.infoLogger, msg, keysAndValues)
 }
 
 // Error log un message d'erreur
-func (l *SimpleLogger) Error(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.errorLogger.Printf(msg, args...)
-	} else {
-		l.errorLogger.Println(msg)
-	}
+func (l *SimpleLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.print(levelError, l.errorLogger, msg, keysAndValues)
 }
 
 // Debug log un message de debug
-func (l *SimpleLogger) Debug(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.debugLogger.Printf(msg, args...)
-	} else {
-		l.debugLogger.Println(msg)
-	}
+func (l *SimpleLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.print(levelDebug, l.debugLogger, msg, keysAndValues)
 }
 
 // Warn log un message d'avertissement
-func (l *SimpleLogger) Warn(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.warnLogger.Printf(msg, args...)
-	} else {
-		l.warnLogger.Println(msg)
+func (l *SimpleLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.print(levelWarn, l.warnLogger, msg, keysAndValues)
+}
+
+// print écrit le message suivi des champs triés par clé
+func (l *SimpleLogger) print(level int, logger *log.Logger, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+	fields := l.redactor.RedactFields(l.with(fieldsFromPairs(keysAndValues)).fields)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var line strings.Builder
+	line.WriteString(l.redactor.RedactString(msg))
+	for _, key := range keys {
+		fmt.Fprintf(&line, " %s=%q", key, fmt.Sprint(fields[key]))
 	}
+	logger.Println(line.String())
 }
 
-// WithField ajoute un champ au logger (implémentation simple)
+// with retourne une copie du logger enrichie des champs
+func (l *SimpleLogger) with(fields map[string]interface{}) *SimpleLogger {
+	if len(fields) == 0 {
+		return l
+	}
+	child := *l
+	child.fields = make(map[string]interface{}, len(l.fields)+len(fields))
+	for key, value := range l.fields {
+		child.fields[key] = value
+	}
+	for key, value := range fields {
+		child.fields[key] = value
+	}
+	return &child
+}
+
+// WithField ajoute un champ au logger
 func (l *SimpleLogger) WithField(key string, value interface{}) Logger {
-	// Pour SimpleLogger, on retourne le même logger
-	// Les champs ne sont pas supportés dans cette implémentation simple
-	return l
+	return l.with(map[string]interface{}{key: value})
 }
 
-// WithFields ajoute plusieurs champs au logger (implémentation simple)
+// WithFields ajoute plusieurs champs au logger
 func (l *SimpleLogger) WithFields(fields map[string]interface{}) Logger {
-	// Pour SimpleLogger, on retourne le même logger
-	// Les champs ne sont pas supportés dans cette implémentation simple
-	return l
+	return l.with(fields)
 }
 
-// SetLevel définit le niveau de log (implémentation simple)
+// WithContext ajoute les champs de corrélation du contexte
+func (l *SimpleLogger) WithContext(ctx context.Context) Logger {
+	return l.with(LogFieldsFromContext(ctx))
+}
+
+// SetLevel définit le niveau de log
 func (l *SimpleLogger) SetLevel(level string) {
-	// SimpleLogger ne supporte pas la configuration de niveau
-	// Cette méthode est présente pour l'interface
+	l.level = parseLevel(level)
+}
+
+// SetFormat est sans effet: SimpleLogger écrit toujours au format texte
+func (l *SimpleLogger) SetFormat(format string) {}
+
+// SetRedactor définit le masquage des données personnelles
+func (l *SimpleLogger) SetRedactor(redactor *Redactor) {
+	if redactor == nil {
+		redactor = defaultRedactor
+	}
+	l.redactor = redactor
 }
 
 // Logger global
 var DefaultLogger Logger = NewSimpleLogger()
 
 // Fonctions de convenance
-func Info(msg string, args ...interface{}) {
-	DefaultLogger.Info(msg, args...)
+func Info(msg string, keysAndValues ...interface{}) {
+	DefaultLogger.Info(msg, keysAndValues...)
 }
 
-func Error(msg string, args ...interface{}) {
-	DefaultLogger.Error(msg, args...)
+func Error(msg string, keysAndValues ...interface{}) {
+	DefaultLogger.Error(msg, keysAndValues...)
 }
 
-func Debug(msg string, args ...interface{}) {
-	DefaultLogger.Debug(msg, args...)
+func Debug(msg string, keysAndValues ...interface{}) {
+	DefaultLogger.Debug(msg, keysAndValues...)
 }
 
-func Warn(msg string, args ...interface{}) {
-	DefaultLogger.Warn(msg, args...)
+func Warn(msg string, keysAndValues ...interface{}) {
+	DefaultLogger.Warn(msg, keysAndValues...)
 }
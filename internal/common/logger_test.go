@@ -0,0 +1,99 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// decodeLines décode une entrée JSON par ligne
+func decodeLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestSugarLogger_StructuredJSON(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	logger := newSugarLogger(&out)
+	logger.SetFormat("json")
+	ctx := WithLogFields(context.Background(), LogKeyRequestID, "req-1", LogKeyMethod, "/ndugu.v1.AuthService/GetUser")
+	ctx = WithLogFields(ctx, LogKeyUserID, "user-1")
+
+	// Act
+	logger.WithField("component", "test").WithContext(ctx).Error("Échec", "customerId", "c-1", "error", errors.New("boom"))
+
+	// Assert
+	entries := decodeLines(t, &out)
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1:\n%s", len(entries), out.String())
+	}
+	want := map[string]interface{}{
+		"msg":           "Échec",
+		"level":         "error",
+		"customerId":    "c-1",
+		"error":         "boom",
+		"component":     "test",
+		LogKeyRequestID: "req-1",
+		LogKeyUserID:    "user-1",
+		LogKeyMethod:    "/ndugu.v1.AuthService/GetUser",
+	}
+	for key, value := range want {
+		if entries[0][key] != value {
+			t.Errorf("field %q = %v, want %v", key, entries[0][key], value)
+		}
+	}
+}
+
+func TestSugarLogger_Level(t *testing.T) {
+	var out bytes.Buffer
+	logger := newSugarLogger(&out)
+	logger.SetFormat("json")
+	derived := logger.WithField("component", "test")
+
+	logger.SetLevel("warn")
+	derived.Info("ignoré")
+	derived.Debug("ignoré")
+	derived.Warn("conservé")
+
+	entries := decodeLines(t, &out)
+	if len(entries) != 1 || entries[0]["msg"] != "conservé" {
+		t.Errorf("entries = %v, want only the warning", entries)
+	}
+}
+
+func TestFieldsFromPairs_BadKey(t *testing.T) {
+	fields := fieldsFromPairs([]interface{}{"userId", "u-1", 42, "orphan"})
+
+	if fields["userId"] != "u-1" {
+		t.Errorf("userId = %v, want u-1", fields["userId"])
+	}
+	if fields[badKey] != "orphan" {
+		t.Errorf("%s = %v, want the last value without key", badKey, fields[badKey])
+	}
+}
+
+func TestRequestIDOrNew(t *testing.T) {
+	if got := RequestIDOrNew("apisix-8f14e45f"); got != "apisix-8f14e45f" {
+		t.Errorf("RequestIDOrNew() = %q, want the client identifier", got)
+	}
+	for _, invalid := range []string{"", "with space", "line\nbreak", strings.Repeat("a", 129)} {
+		if got := RequestIDOrNew(invalid); got == invalid || len(got) != 36 {
+			t.Errorf("RequestIDOrNew(%q) = %q, want a generated UUID", invalid, got)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RedactionPolicy définit le traitement des données personnelles dans les logs
+type RedactionPolicy string
+
+const (
+	// RedactMask masque partiellement la valeur (j***@example.com, +243*******78)
+	RedactMask RedactionPolicy = "mask"
+	// RedactHash remplace la valeur par une empreinte HMAC-SHA256 tronquée, stable
+	// pour une même clé: elle permet de corréler les logs d'un même utilisateur
+	RedactHash RedactionPolicy = "hash"
+	// RedactDrop supprime le champ (ou remplace la valeur détectée dans un texte)
+	RedactDrop RedactionPolicy = "drop"
+)
+
+// redactedValue valeur affichée à la place d'un secret
+const redactedValue = "[REDACTED]"
+
+// Catégories de données sensibles
+const (
+	kindNone = iota
+	// kindSecret mot de passe, jeton, cookie: jamais journalisé, quelle que soit la politique
+	kindSecret
+	kindEmail
+	kindPhone
+	kindName
+)
+
+// Noms de champs sensibles, comparés après normalisation (minuscules, sans "_" ni "-")
+var (
+	secretFieldMarkers = []string{"password", "secret", "token", "cookie", "authorization", "credential"}
+	emailFieldMarkers  = []string{"email"}
+	phoneFieldMarkers  = []string{"phonenumber", "phone", "mobile", "msisdn"}
+	nameFields         = map[string]bool{"firstname": true, "lastname": true, "fullname": true, "givenname": true, "familyname": true}
+	// phoneCode n'est qu'un indicatif pays: il reste lisible
+	nonSensitiveFields = map[string]bool{"phonecode": true, "tokentype": true}
+)
+
+// Motifs détectés dans les valeurs textuelles (messages d'erreur, etc.)
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern  = regexp.MustCompile(`\+[1-9][0-9]{7,14}\b`)
+	secretPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/\-]+=*|\bory_[a-z]{2}_[A-Za-z0-9]+|\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+)
+
+// Redactor masque les données personnelles et les secrets des champs de log,
+// par nom de champ et par motif de valeur
+type Redactor struct {
+	policy RedactionPolicy
+	key    []byte
+}
+
+// NewRedactor crée un Redactor. La clé n'est utilisée que par la politique "hash";
+// sans clé, les empreintes d'emails ou de numéros restent exposées aux attaques par dictionnaire.
+func NewRedactor(policy RedactionPolicy, key string) (*Redactor, error) {
+	switch policy {
+	case RedactMask, RedactHash, RedactDrop:
+	case "":
+		policy = RedactMask
+	default:
+		return nil, fmt.Errorf("politique de masquage inconnue %q", policy)
+	}
+	return &Redactor{policy: policy, key: []byte(key)}, nil
+}
+
+// defaultRedactor politique appliquée tant que SetRedactor n'a pas été appelé
+var defaultRedactor = &Redactor{policy: RedactMask}
+
+// Policy retourne la politique appliquée
+func (r *Redactor) Policy() RedactionPolicy {
+	return r.policy
+}
+
+// RedactFields retourne une copie des champs dont les valeurs sensibles sont masquées.
+// Les champs d'origine ne sont pas modifiés.
+func (r *Redactor) RedactFields(fields map[string]interface{}) map[string]interface{} {
+	if len(fields) == 0 {
+		return fields
+	}
+	redacted := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if value, keep := r.redactField(key, value); keep {
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// RedactString masque les emails, numéros de téléphone et jetons présents dans un texte
+func (r *Redactor) RedactString(s string) string {
+	s = secretPattern.ReplaceAllString(s, redactedValue)
+	s = emailPattern.ReplaceAllStringFunc(s, func(m string) string { return r.inline(kindEmail, m) })
+	return phonePattern.ReplaceAllStringFunc(s, func(m string) string { return r.inline(kindPhone, m) })
+}
+
+// redactField masque la valeur d'un champ; keep vaut false si le champ doit être supprimé
+func (r *Redactor) redactField(key string, value interface{}) (interface{}, bool) {
+	kind := fieldKind(key)
+	if kind == kindNone {
+		return r.redactValue(value), true
+	}
+	if r.policy == RedactDrop {
+		return nil, false
+	}
+	if kind == kindSecret {
+		return redactedValue, true
+	}
+	text := fmt.Sprint(value)
+	if text == "" {
+		return text, true
+	}
+	if r.policy == RedactHash {
+		return r.hash(text), true
+	}
+	return mask(kind, text), true
+}
+
+// redactValue applique la détection par motif aux valeurs textuelles et aux erreurs
+func (r *Redactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.RedactString(v)
+	case error:
+		return r.RedactString(v.Error())
+	default:
+		return value
+	}
+}
+
+// inline remplace une valeur détectée au sein d'un texte
+func (r *Redactor) inline(kind int, value string) string {
+	switch r.policy {
+	case RedactDrop:
+		return redactedValue
+	case RedactHash:
+		return r.hash(value)
+	default:
+		return mask(kind, value)
+	}
+}
+
+// hash retourne une empreinte courte et stable de la valeur
+func (r *Redactor) hash(value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(value))
+	return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// fieldKind détermine la catégorie d'un champ d'après son nom
+func fieldKind(key string) int {
+	name := strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+	switch {
+	case nonSensitiveFields[name]:
+		return kindNone
+	case containsAny(name, secretFieldMarkers):
+		return kindSecret
+	case containsAny(name, emailFieldMarkers):
+		return kindEmail
+	case containsAny(name, phoneFieldMarkers):
+		return kindPhone
+	case nameFields[name]:
+		return kindName
+	default:
+		return kindNone
+	}
+}
+
+func containsAny(s string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// mask masque partiellement une valeur selon sa catégorie
+func mask(kind int, value string) string {
+	runes := []rune(value)
+	switch kind {
+	case kindEmail:
+		at := strings.LastIndex(value, "@")
+		if at <= 0 {
+			return maskRunes([]rune(value), 1, 0)
+		}
+		return maskRunes([]rune(value[:at]), 1, 0) + value[at:]
+	case kindPhone:
+		// Conserver l'indicatif et les deux derniers chiffres
+		return maskRunes(runes, 4, 2)
+	default:
+		return maskRunes(runes, 1, 0)
+	}
+}
+
+// maskRunes conserve les keepStart premiers et keepEnd derniers caractères;
+// une valeur trop courte est entièrement masquée
+func maskRunes(runes []rune, keepStart, keepEnd int) string {
+	if len(runes) <= keepStart+keepEnd+1 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:keepStart]) + strings.Repeat("*", len(runes)-keepStart-keepEnd) + string(runes[len(runes)-keepEnd:])
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_RedactFields(t *testing.T) {
+	fields := map[string]interface{}{
+		"email":        "jean.dupont@example.com",
+		"phone_number": "+243812345678",
+		"firstName":    "Jean",
+		"password":     "Secret123!",
+		"sessionToken": "ory_st_abcdef0123456789",
+		"phoneCode":    "+243",
+		"customerId":   "c-1",
+		"error":        errors.New("identité jean.dupont@example.com introuvable"),
+	}
+
+	tests := []struct {
+		name   string
+		policy RedactionPolicy
+		want   map[string]interface{}
+	}{
+		{
+			name:   "partial mask",
+			policy: RedactMask,
+			want: map[string]interface{}{
+				"email":        "j**********@example.com",
+				"phone_number": "+243*******78",
+				"firstName":    "J***",
+				"password":     redactedValue,
+				"sessionToken": redactedValue,
+				"phoneCode":    "+243",
+				"customerId":   "c-1",
+				"error":        "identité j**********@example.com introuvable",
+			},
+		},
+		{
+			name:   "drop",
+			policy: RedactDrop,
+			want: map[string]interface{}{
+				"phoneCode":  "+243",
+				"customerId": "c-1",
+				"error":      "identité [REDACTED] introuvable",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			redactor, err := NewRedactor(tt.policy, "")
+			if err != nil {
+				t.Fatalf("NewRedactor() error = %v", err)
+			}
+
+			// Act
+			got := redactor.RedactFields(fields)
+
+			// Assert
+			if len(got) != len(tt.want) {
+				t.Errorf("RedactFields() = %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("field %q = %v, want %v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactor_Hash(t *testing.T) {
+	redactor, _ := NewRedactor(RedactHash, "cle-de-test")
+	other, _ := NewRedactor(RedactHash, "autre-cle")
+
+	first := redactor.RedactFields(map[string]interface{}{"email": "jean@example.com"})["email"]
+	second := redactor.RedactFields(map[string]interface{}{"userEmail": "jean@example.com"})["userEmail"]
+	token := redactor.RedactFields(map[string]interface{}{"access_token": "eyJhbGciOiJIUzI1NiJ9.e30.sig"})["access_token"]
+
+	if first != second || !strings.HasPrefix(first.(string), "sha256:") {
+		t.Errorf("hash = %v and %v, want the same stable fingerprint", first, second)
+	}
+	if first == other.RedactFields(map[string]interface{}{"email": "jean@example.com"})["email"] {
+		t.Error("hash does not depend on the key")
+	}
+	if token != redactedValue {
+		t.Errorf("token = %v, want secrets never hashed", token)
+	}
+}
+
+func TestRedactor_RedactString(t *testing.T) {
+	redactor, _ := NewRedactor(RedactMask, "")
+
+	got := redactor.RedactString("Authorization: Bearer abc.def-ghi pour +243812345678")
+
+	if strings.Contains(got, "abc.def-ghi") || strings.Contains(got, "812345678") {
+		t.Errorf("RedactString() = %q, secret or phone number not redacted", got)
+	}
+}
+
+func TestNewRedactor_UnknownPolicy(t *testing.T) {
+	if _, err := NewRedactor("encrypt", ""); err == nil {
+		t.Error("NewRedactor() error = nil, want unknown policy error")
+	}
+}
+
+func TestLoggers_Redact(t *testing.T) {
+	redactor, _ := NewRedactor(RedactMask, "")
+	var out bytes.Buffer
+
+	sugar := newSugarLogger(&out)
+	sugar.SetFormat("json")
+	derived := sugar.WithField("email", "jean@example.com")
+	sugar.SetRedactor(redactor)
+	derived.Info("Utilisateur jean@example.com créé", "password", "Secret123!")
+
+	simple := NewSimpleLogger()
+	simple.infoLogger.SetOutput(&out)
+	simple.SetRedactor(redactor)
+	simple.WithField("email", "jean@example.com").Info("Connexion", "session_token", "ory_st_abc")
+
+	if strings.Contains(out.String(), "jean@") || strings.Contains(out.String(), "Secret123!") || strings.Contains(out.String(), "ory_st_abc") {
+		t.Errorf("logs contain personal data or secrets:\n%s", out.String())
+	}
+}
@@ -53,6 +53,13 @@ func WriteError(w http.ResponseWriter, err *AppError) {
 	WriteJSON(w, err.HTTPStatus, resp)
 }
 
+// WriteLocalizedError écrit une réponse d'erreur traduite dans la langue de l'en-tête Accept-Language
+func WriteLocalizedError(w http.ResponseWriter, r *http.Request, err *AppError) {
+	lang := RequestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	WriteError(w, err.Localize(lang))
+}
+
 // WriteInternalError écrit une erreur interne
 func WriteInternalError(w http.ResponseWriter, message string) {
 	err := NewAppError(ErrCodeInternal, message)
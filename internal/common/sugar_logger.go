@@ -1,84 +1,152 @@
 package common
 
 import (
+	"context"
+	"io"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 // SugarLogger implémente l'interface Logger avec logrus
 type SugarLogger struct {
-	logger *logrus.Logger
+	entry     *logrus.Entry
+	redaction *redactionHook
 }
 
-// NewSugarLogger crée une nouvelle instance de SugarLogger
+// NewSugarLogger crée une nouvelle instance de SugarLogger (format texte, niveau info)
 func NewSugarLogger() Logger {
+	return newSugarLogger(os.Stdout)
+}
+
+// newSugarLogger crée un SugarLogger écrivant dans out
+func newSugarLogger(out io.Writer) *SugarLogger {
 	logger := logrus.New()
 
 	// Configuration du format
-	logger.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:   true,
-		TimestampFormat: "2006-01-02 15:04:05",
-		ForceColors:     true,
-	})
+	logger.SetFormatter(textFormatter())
 
 	// Configuration du niveau de log
 	logger.SetLevel(logrus.InfoLevel)
 
 	// Configuration de la sortie
-	logger.SetOutput(os.Stdout)
+	logger.SetOutput(out)
+
+	// Masquage des données personnelles, appliqué à toutes les entrées avant formatage
+	hook := &redactionHook{}
+	hook.redactor.Store(defaultRedactor)
+	logger.AddHook(hook)
 
 	return &SugarLogger{
-		logger: logger,
+		entry:     logrus.NewEntry(logger),
+		redaction: hook,
+	}
+}
+
+// redactionHook masque les champs et le message de chaque entrée logrus
+type redactionHook struct {
+	redactor atomic.Pointer[Redactor]
+}
+
+// Levels retourne les niveaux concernés par le hook
+func (h *redactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire masque l'entrée; logrus passe une copie, les champs du logger ne sont pas modifiés
+func (h *redactionHook) Fire(entry *logrus.Entry) error {
+	redactor := h.redactor.Load()
+	entry.Data = redactor.RedactFields(entry.Data)
+	entry.Message = redactor.RedactString(entry.Message)
+	return nil
+}
+
+// textFormatter format lisible pour le développement
+func textFormatter() logrus.Formatter {
+	return &logrus.TextFormatter{
+		FullTimestamp:   true,
+		TimestampFormat: "2006-01-02 15:04:05",
+		ForceColors:     true,
 	}
 }
 
 // Info enregistre un message d'information
-func (l *SugarLogger) Info(format string, args ...interface{}) {
-	l.logger.Infof(format, args...)
+func (l *SugarLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(fieldsFromPairs(keysAndValues)).Info(msg)
 }
 
 // Warn enregistre un message d'avertissement
-func (l *SugarLogger) Warn(format string, args ...interface{}) {
-	l.logger.Warnf(format, args...)
+func (l *SugarLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(fieldsFromPairs(keysAndValues)).Warn(msg)
 }
 
 // Error enregistre un message d'erreur
-func (l *SugarLogger) Error(format string, args ...interface{}) {
-	l.logger.Errorf(format, args...)
+func (l *SugarLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(fieldsFromPairs(keysAndValues)).Error(msg)
 }
 
 // Debug enregistre un message de débogage
-func (l *SugarLogger) Debug(format string, args ...interface{}) {
-	l.logger.Debugf(format, args...)
+func (l *SugarLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.entry.WithFields(fieldsFromPairs(keysAndValues)).Debug(msg)
 }
 
 // WithField ajoute un champ au logger
 func (l *SugarLogger) WithField(key string, value interface{}) Logger {
-	return &SugarLogger{
-		logger: l.logger.WithField(key, value).Logger,
-	}
+	return &SugarLogger{entry: l.entry.WithField(key, value), redaction: l.redaction}
 }
 
 // WithFields ajoute plusieurs champs au logger
 func (l *SugarLogger) WithFields(fields map[string]interface{}) Logger {
-	return &SugarLogger{
-		logger: l.logger.WithFields(fields).Logger,
+	return &SugarLogger{entry: l.entry.WithFields(fields), redaction: l.redaction}
+}
+
+// WithContext ajoute les champs de corrélation du contexte
+func (l *SugarLogger) WithContext(ctx context.Context) Logger {
+	fields := LogFieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
 	}
+	return &SugarLogger{entry: l.entry.WithFields(fields), redaction: l.redaction}
 }
 
-// SetLevel définit le niveau de log
+// SetLevel définit le niveau de log; il s'applique aussi aux loggers dérivés
 func (l *SugarLogger) SetLevel(level string) {
-	switch level {
-	case "debug":
-		l.logger.SetLevel(logrus.DebugLevel)
-	case "info":
-		l.logger.SetLevel(logrus.InfoLevel)
-	case "warn":
-		l.logger.SetLevel(logrus.WarnLevel)
-	case "error":
-		l.logger.SetLevel(logrus.ErrorLevel)
+	switch parseLevel(level) {
+	case levelDebug:
+		l.entry.Logger.SetLevel(logrus.DebugLevel)
+	case levelWarn:
+		l.entry.Logger.SetLevel(logrus.WarnLevel)
+	case levelError:
+		l.entry.Logger.SetLevel(logrus.ErrorLevel)
 	default:
-		l.logger.SetLevel(logrus.InfoLevel)
+		l.entry.Logger.SetLevel(logrus.InfoLevel)
+	}
+}
+
+// SetFormat définit le format de sortie ("json" pour la collecte centralisée, sinon texte);
+// il s'applique aussi aux loggers dérivés
+func (l *SugarLogger) SetFormat(format string) {
+	if format == "json" {
+		l.entry.Logger.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano})
+		return
+	}
+	l.entry.Logger.SetFormatter(textFormatter())
+}
+
+// SetRedactor définit le masquage des données personnelles; il s'applique aussi aux loggers dérivés
+func (l *SugarLogger) SetRedactor(redactor *Redactor) {
+	if redactor == nil {
+		redactor = defaultRedactor
+	}
+	l.redaction.redactor.Store(redactor)
+}
+
+// Sync vide les tampons de la sortie des logs (appelé à l'arrêt du service)
+func (l *SugarLogger) Sync() error {
+	if file, ok := l.entry.Logger.Out.(*os.File); ok {
+		return file.Sync()
 	}
+	return nil
 }
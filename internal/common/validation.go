@@ -2,6 +2,7 @@ package common
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -16,13 +17,23 @@ var EmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]
 // PhoneRegex pour valider les numéros de téléphone
 var PhoneRegex = regexp.MustCompile(`^\+?[1-9]\d{6,14}$`)
 
+// NewViolation crée une violation dont la description provient du catalogue (langue par défaut)
+func NewViolation(field, rule, param string) FieldViolation {
+	return FieldViolation{
+		Field:       field,
+		Rule:        rule,
+		Param:       param,
+		Description: Translate(DefaultLanguage, "validation."+rule, map[string]string{"param": param}),
+	}
+}
+
 // ValidateEmail valide un email
 func ValidateEmail(email string) error {
 	if email == "" {
-		return NewAppError(ErrCodeInvalidInput, "Email requis")
+		return NewValidationError(NewViolation("email", "required", ""))
 	}
 	if !EmailRegex.MatchString(email) {
-		return NewAppError(ErrCodeInvalidInput, "Format d'email invalide")
+		return NewValidationError(NewViolation("email", "email", ""))
 	}
 	return nil
 }
@@ -30,10 +41,10 @@ func ValidateEmail(email string) error {
 // ValidatePhone valide un numéro de téléphone
 func ValidatePhone(phone string) error {
 	if phone == "" {
-		return NewAppError(ErrCodeInvalidInput, "Numéro de téléphone requis")
+		return NewValidationError(NewViolation("phone", "required", ""))
 	}
 	if !PhoneRegex.MatchString(phone) {
-		return NewAppError(ErrCodeInvalidInput, "Format de numéro de téléphone invalide")
+		return NewValidationError(NewViolation("phone", "e164", ""))
 	}
 	return nil
 }
@@ -41,10 +52,10 @@ func ValidatePhone(phone string) error {
 // ValidatePassword valide un mot de passe
 func ValidatePassword(password string) error {
 	if password == "" {
-		return NewAppError(ErrCodeInvalidInput, "Mot de passe requis")
+		return NewValidationError(NewViolation("password", "required", ""))
 	}
 	if len(password) < 8 {
-		return NewAppError(ErrCodeInvalidInput, "Le mot de passe doit contenir au moins 8 caractères")
+		return NewValidationError(NewViolation("password", "min", "8"))
 	}
 	return nil
 }
@@ -52,7 +63,7 @@ func ValidatePassword(password string) error {
 // ValidateRequired valide qu'un champ est requis
 func ValidateRequired(value, fieldName string) error {
 	if strings.TrimSpace(value) == "" {
-		return NewAppError(ErrCodeInvalidInput, fieldName+" est requis")
+		return newCatalogError(ErrCodeInvalidInput, "validation.required_named", map[string]string{"field": fieldName})
 	}
 	return nil
 }
@@ -61,10 +72,10 @@ func ValidateRequired(value, fieldName string) error {
 func ValidateLength(value, fieldName string, min, max int) error {
 	length := len(strings.TrimSpace(value))
 	if length < min {
-		return NewAppError(ErrCodeInvalidInput, fieldName+" doit contenir au moins "+string(rune(min))+" caractères")
+		return NewValidationError(NewViolation(fieldName, "min", strconv.Itoa(min)))
 	}
 	if length > max {
-		return NewAppError(ErrCodeInvalidInput, fieldName+" ne peut pas dépasser "+string(rune(max))+" caractères")
+		return NewValidationError(NewViolation(fieldName, "max", strconv.Itoa(max)))
 	}
 	return nil
 }
@@ -1,36 +1,71 @@
 package config
 
 import (
-	"os"
-	"strconv"
+	"fmt"
+	"net/url"
 	"time"
 )
 
-// Config contient la configuration de l'application
+// Config contient la configuration de l'application.
+// Chaque champ porte son chemin (tag json, utilisé par le fichier et les options
+// de ligne de commande) et sa variable d'environnement (tag env).
+// Les champs marqués secret sont masqués par --print-config.
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Ory      OryConfig      `json:"ory"`
-	Logging  LoggingConfig  `json:"logging"`
+	Server          ServerConfig          `json:"server"`
+	Database        DatabaseConfig        `json:"database"`
+	Ory             OryConfig             `json:"ory"`
+	Logging         LoggingConfig         `json:"logging"`
+	Tracing         TracingConfig         `json:"tracing"`
+	Health          HealthConfig          `json:"health"`
+	Password        PasswordConfig        `json:"password"`
+	Reconciliation  ReconciliationConfig  `json:"reconciliation"`
+	Idempotency     IdempotencyConfig     `json:"idempotency"`
+	SessionCache    SessionCacheConfig    `json:"session_cache"`
+	PermissionCache PermissionCacheConfig `json:"permission_cache"`
 }
 
 // ServerConfig contient la configuration du serveur
 type ServerConfig struct {
-	Host         string        `json:"host"`
-	Port         string        `json:"port"`
-	ReadTimeout  time.Duration `json:"read_timeout"`
-	WriteTimeout time.Duration `json:"write_timeout"`
-	IdleTimeout  time.Duration `json:"idle_timeout"`
+	Host         string        `json:"host" env:"SERVER_HOST"`
+	Port         string        `json:"port" env:"SERVER_PORT"`
+	ReadTimeout  time.Duration `json:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout time.Duration `json:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `json:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+
+	GRPCPort string `json:"grpc_port" env:"GRPC_PORT"`
+
+	// Port d'administration (métriques), à ne pas exposer via la passerelle
+	AdminPort string `json:"admin_port" env:"ADMIN_PORT"`
+
+	// Arrêt gracieux: délai de retrait du service puis échéance de l'arrêt
+	ShutdownDelay   time.Duration `json:"shutdown_delay" env:"SERVER_SHUTDOWN_DELAY"`
+	ShutdownTimeout time.Duration `json:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
 }
 
 // DatabaseConfig contient la configuration de la base de données
 type DatabaseConfig struct {
-	Host     string `json:"host"`
-	Port     int    `json:"port"`
-	User     string `json:"user"`
-	Password string `json:"password"`
-	Name     string `json:"name"`
-	SSLMode  string `json:"ssl_mode"`
+	Host     string `json:"host" env:"DB_HOST"`
+	Port     int    `json:"port" env:"DB_PORT"`
+	User     string `json:"user" env:"DB_USER"`
+	Password string `json:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `json:"name" env:"DB_NAME"`
+	SSLMode  string `json:"ssl_mode" env:"DB_SSL_MODE"`
+
+	MaxOpenConns    int           `json:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `json:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+}
+
+// DSN construit la chaîne de connexion PostgreSQL
+func (c DatabaseConfig) DSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": []string{c.SSLMode}}.Encode(),
+	}
+	return dsn.String()
 }
 
 // OryConfig contient la configuration des services Ory
@@ -42,91 +77,177 @@ type OryConfig struct {
 
 // KratosConfig contient la configuration de Kratos
 type KratosConfig struct {
-	PublicURL string `json:"public_url"`
-	AdminURL  string `json:"admin_url"`
+	PublicURL string `json:"public_url" env:"KRATOS_PUBLIC_URL"`
+	AdminURL  string `json:"admin_url" env:"KRATOS_ADMIN_URL"`
 }
 
 // HydraConfig contient la configuration de Hydra
 type HydraConfig struct {
-	PublicURL string `json:"public_url"`
-	AdminURL  string `json:"admin_url"`
+	PublicURL string `json:"public_url" env:"HYDRA_PUBLIC_URL"`
+	AdminURL  string `json:"admin_url" env:"HYDRA_ADMIN_URL"`
+
+	// Durées pendant lesquelles Hydra mémorise l'authentification et le consentement
+	LoginRememberFor   time.Duration `json:"login_remember_for" env:"HYDRA_LOGIN_REMEMBER_FOR"`
+	ConsentRememberFor time.Duration `json:"consent_remember_for" env:"HYDRA_CONSENT_REMEMBER_FOR"`
 }
 
 // KetoConfig contient la configuration de Keto
 type KetoConfig struct {
-	ReadURL  string `json:"read_url"`
-	WriteURL string `json:"write_url"`
+	ReadURL  string `json:"read_url" env:"KETO_READ_URL"`
+	WriteURL string `json:"write_url" env:"KETO_WRITE_URL"`
 }
 
 // LoggingConfig contient la configuration du logging
 type LoggingConfig struct {
-	Level  string `json:"level"`
-	Format string `json:"format"`
+	Level  string `json:"level" env:"LOG_LEVEL"`
+	Format string `json:"format" env:"LOG_FORMAT"`
+
+	// Masquage des données personnelles: mask, hash ou drop
+	Redaction    string `json:"redaction" env:"LOG_REDACTION"`
+	RedactionKey string `json:"redaction_key" env:"LOG_REDACTION_KEY" secret:"true"` // clé HMAC de la politique hash
+}
+
+// TracingConfig contient la configuration du traçage OpenTelemetry
+type TracingConfig struct {
+	Exporter     string  `json:"exporter" env:"TRACING_EXPORTER"`                 // otlp, stdout ou none
+	OTLPEndpoint string  `json:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"` // collecteur OTLP/gRPC; http:// désactive TLS
+	ServiceName  string  `json:"service_name" env:"OTEL_SERVICE_NAME"`
+	SampleRatio  float64 `json:"sample_ratio" env:"TRACING_SAMPLE_RATIO"` // part des traces racines conservées, de 0 à 1
+}
+
+// HealthConfig contient la configuration des sondes de dépendances
+type HealthConfig struct {
+	CheckInterval time.Duration `json:"check_interval" env:"HEALTH_CHECK_INTERVAL"`
+	CheckTimeout  time.Duration `json:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+}
+
+// ReconciliationConfig contient la configuration de la réconciliation des utilisateurs Kratos
+type ReconciliationConfig struct {
+	Interval time.Duration `json:"interval" env:"RECONCILE_INTERVAL"` // 0 désactive la réconciliation
+	PageSize int           `json:"page_size" env:"RECONCILE_PAGE_SIZE"`
+}
+
+// IdempotencyConfig contient la configuration des clés d'idempotence des méthodes de modification
+type IdempotencyConfig struct {
+	Store           string        `json:"store" env:"IDEMPOTENCY_STORE"`                       // postgres ou memory
+	TTL             time.Duration `json:"ttl" env:"IDEMPOTENCY_TTL"`                           // conservation des réponses
+	LockTimeout     time.Duration `json:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT"`         // réservation d'une clé en cours de traitement
+	CleanupInterval time.Duration `json:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL"` // purge des clés expirées
+}
+
+// SessionCacheConfig contient la configuration du cache des sessions Kratos validées
+type SessionCacheConfig struct {
+	Enabled bool          `json:"enabled" env:"SESSION_CACHE_ENABLED"`
+	Size    int           `json:"size" env:"SESSION_CACHE_SIZE"` // nombre maximal de sessions
+	TTL     time.Duration `json:"ttl" env:"SESSION_CACHE_TTL"`   // durée maximale de conservation d'une session
+}
+
+// PermissionCacheConfig contient la configuration du cache des décisions Keto
+type PermissionCacheConfig struct {
+	Enabled bool          `json:"enabled" env:"PERMISSION_CACHE_ENABLED"`
+	Size    int           `json:"size" env:"PERMISSION_CACHE_SIZE"` // nombre maximal de décisions
+	TTL     time.Duration `json:"ttl" env:"PERMISSION_CACHE_TTL"`   // durée maximale de conservation d'une décision
+}
+
+// PasswordConfig contient les paramètres de hachage des mots de passe
+type PasswordConfig struct {
+	Algorithm string `json:"algorithm" env:"PASSWORD_HASH_ALGORITHM"` // argon2id ou bcrypt
+
+	Argon2Memory      uint32 `json:"argon2_memory" env:"PASSWORD_ARGON2_MEMORY"` // en KiB
+	Argon2Iterations  uint32 `json:"argon2_iterations" env:"PASSWORD_ARGON2_ITERATIONS"`
+	Argon2Parallelism uint8  `json:"argon2_parallelism" env:"PASSWORD_ARGON2_PARALLELISM"`
+	Argon2SaltLength  uint32 `json:"argon2_salt_length" env:"PASSWORD_ARGON2_SALT_LENGTH"`
+	Argon2KeyLength   uint32 `json:"argon2_key_length" env:"PASSWORD_ARGON2_KEY_LENGTH"`
+
+	BcryptCost int `json:"bcrypt_cost" env:"PASSWORD_BCRYPT_COST"`
 }
 
-// Load charge la configuration depuis les variables d'environnement
-func Load() *Config {
+// Default retourne la configuration par défaut (développement local)
+func Default() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
-			Port:         getEnv("SERVER_PORT", "8080"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second),
-			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			Host:         "0.0.0.0",
+			Port:         "8080",
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+			GRPCPort:     "50051",
+			AdminPort:    "9090",
+
+			ShutdownTimeout: 30 * time.Second,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getIntEnv("DB_PORT", 5432),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
-			Name:     getEnv("DB_NAME", "ndugu"),
-			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "password",
+			Name:     "ndugu",
+			SSLMode:  "disable",
+
+			MaxOpenConns:    20,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
 		},
 		Ory: OryConfig{
 			Kratos: KratosConfig{
-				PublicURL: getEnv("KRATOS_PUBLIC_URL", "http://localhost:4433"),
-				AdminURL:  getEnv("KRATOS_ADMIN_URL", "http://localhost:4434"),
+				PublicURL: "http://localhost:4433",
+				AdminURL:  "http://localhost:4434",
 			},
 			Hydra: HydraConfig{
-				PublicURL: getEnv("HYDRA_PUBLIC_URL", "http://localhost:4444"),
-				AdminURL:  getEnv("HYDRA_ADMIN_URL", "http://localhost:4445"),
+				PublicURL: "http://localhost:4444",
+				AdminURL:  "http://localhost:4445",
+
+				LoginRememberFor:   time.Hour,
+				ConsentRememberFor: 30 * 24 * time.Hour,
 			},
 			Keto: KetoConfig{
-				ReadURL:  getEnv("KETO_READ_URL", "http://localhost:4466"),
-				WriteURL: getEnv("KETO_WRITE_URL", "http://localhost:4467"),
+				ReadURL:  "http://localhost:4466",
+				WriteURL: "http://localhost:4467",
 			},
 		},
 		Logging: LoggingConfig{
-			Level:  getEnv("LOG_LEVEL", "info"),
-			Format: getEnv("LOG_FORMAT", "text"),
+			Level:     "info",
+			Format:    "text",
+			Redaction: "mask",
+		},
+		Tracing: TracingConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "http://localhost:4317",
+			ServiceName:  "ndugu-coreapi",
+			SampleRatio:  1,
+		},
+		Health: HealthConfig{
+			CheckInterval: 10 * time.Second,
+			CheckTimeout:  2 * time.Second,
+		},
+		Password: PasswordConfig{
+			Algorithm:         "argon2id",
+			Argon2Memory:      64 * 1024,
+			Argon2Iterations:  3,
+			Argon2Parallelism: 2,
+			Argon2SaltLength:  16,
+			Argon2KeyLength:   32,
+			BcryptCost:        12,
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval: time.Hour,
+			PageSize: 250,
+		},
+		Idempotency: IdempotencyConfig{
+			Store:           "postgres",
+			TTL:             24 * time.Hour,
+			LockTimeout:     time.Minute,
+			CleanupInterval: 10 * time.Minute,
+		},
+		SessionCache: SessionCacheConfig{
+			Enabled: true,
+			Size:    10000,
+			TTL:     time.Minute,
+		},
+		PermissionCache: PermissionCacheConfig{
+			Enabled: true,
+			Size:    10000,
+			TTL:     5 * time.Second,
 		},
 	}
 }
-
-// getEnv récupère une variable d'environnement avec une valeur par défaut
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-// getIntEnv récupère une variable d'environnement entière avec une valeur par défaut
-func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
-
-// getDurationEnv récupère une variable d'environnement de durée avec une valeur par défaut
-func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
-		}
-	}
-	return defaultValue
-}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Layers(t *testing.T) {
+	// Arrange: le fichier surcharge les défauts, l'environnement le fichier, les options l'environnement
+	file := writeFile(t, "coreapi.yaml", `
+server:
+  port: 9000
+  grpc_port: 9001
+  read_timeout: 5s
+database:
+  host: db.internal
+  name: from-file
+ory:
+  kratos:
+    public_url: http://kratos.internal:4433
+`)
+	t.Setenv("DB_NAME", "from-env")
+	t.Setenv("GRPC_PORT", "9101")
+
+	// Act
+	cfg, opts, err := Load([]string{"--config", file, "--server.grpc_port=9201"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if opts.File != file {
+		t.Errorf("Options.File = %q, want %q", opts.File, file)
+	}
+	if cfg.Server.Port != "9000" || cfg.Server.ReadTimeout != 5*time.Second {
+		t.Errorf("file layer not applied: port=%s read_timeout=%v", cfg.Server.Port, cfg.Server.ReadTimeout)
+	}
+	if cfg.Database.Host != "db.internal" || cfg.Ory.Kratos.PublicURL != "http://kratos.internal:4433" {
+		t.Errorf("file layer not applied: %+v", cfg.Database)
+	}
+	if cfg.Database.Name != "from-env" {
+		t.Errorf("database.name = %q, want env to override the file", cfg.Database.Name)
+	}
+	if cfg.Server.GRPCPort != "9201" {
+		t.Errorf("server.grpc_port = %q, want flag to override env", cfg.Server.GRPCPort)
+	}
+	if cfg.Ory.Hydra.AdminURL != "http://localhost:4445" {
+		t.Errorf("ory.hydra.admin_url = %q, want default", cfg.Ory.Hydra.AdminURL)
+	}
+}
+
+func TestLoad_SecretFromFile(t *testing.T) {
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "s3cret\n"))
+
+	cfg, _, err := Load(nil)
+
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Database.Password != "s3cret" {
+		t.Errorf("database.password = %q, want the file content without trailing newline", cfg.Database.Password)
+	}
+}
+
+func TestLoad_SecretFileConflict(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "inline")
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "s3cret"))
+
+	if _, _, err := Load(nil); err == nil || !strings.Contains(err.Error(), "DB_PASSWORD_FILE") {
+		t.Errorf("Load() error = %v, want conflict between DB_PASSWORD and DB_PASSWORD_FILE", err)
+	}
+}
+
+func TestLoad_ReportsEveryInvalidField(t *testing.T) {
+	file := writeFile(t, "coreapi.json", `{"logging": {"level": "loud"}, "databse": {"host": "typo"}}`)
+	t.Setenv("DB_PORT", "not-a-port")
+
+	_, _, err := Load([]string{"--config", file, "--ory.keto.read_url=keto:4466", "--server.read_timeout=soon"})
+
+	if err == nil {
+		t.Fatal("Load() error = nil, want validation errors")
+	}
+	for _, want := range []string{"logging.level", `clé inconnue "databse.host"`, "DB_PORT", "ory.keto.read_url", "--server.read_timeout"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Load() error does not mention %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoad_Reconciliation(t *testing.T) {
+	tests := []struct {
+		name         string
+		interval     string
+		pageSize     string
+		wantInterval time.Duration
+		wantErr      string
+	}{
+		{name: "disabled", interval: "0", pageSize: "250", wantInterval: 0},
+		{name: "every 15 minutes", interval: "15m", pageSize: "500", wantInterval: 15 * time.Minute},
+		{name: "negative interval", interval: "-1m", pageSize: "250", wantErr: "reconciliation.interval"},
+		{name: "page too large", interval: "1h", pageSize: "5000", wantErr: "reconciliation.page_size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("RECONCILE_INTERVAL", tt.interval)
+			t.Setenv("RECONCILE_PAGE_SIZE", tt.pageSize)
+
+			// Act
+			cfg, _, err := Load(nil)
+
+			// Assert
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Load() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Reconciliation.Interval != tt.wantInterval {
+				t.Errorf("reconciliation.interval = %v, want %v", cfg.Reconciliation.Interval, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestLoad_SessionCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     string
+		size        string
+		wantEnabled bool
+		wantErr     string
+	}{
+		{name: "enabled", enabled: "true", size: "500", wantEnabled: true},
+		{name: "disabled ignores size", enabled: "false", size: "0", wantEnabled: false},
+		{name: "empty cache", enabled: "true", size: "0", wantErr: "session_cache.size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("SESSION_CACHE_ENABLED", tt.enabled)
+			t.Setenv("SESSION_CACHE_SIZE", tt.size)
+
+			// Act
+			cfg, _, err := Load(nil)
+
+			// Assert
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Load() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.SessionCache.Enabled != tt.wantEnabled {
+				t.Errorf("session_cache.enabled = %v, want %v", cfg.SessionCache.Enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestLoad_UnknownFlag(t *testing.T) {
+	if _, _, err := Load([]string{"--server.unknown=1"}); err == nil {
+		t.Error("Load() error = nil, want unknown flag error")
+	}
+}
+
+func TestConfig_PrintRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "s3cret"
+	var out bytes.Buffer
+
+	if err := cfg.Print(&out); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	if strings.Contains(out.String(), "s3cret") {
+		t.Errorf("Print() leaks the database password:\n%s", out.String())
+	}
+	for _, want := range []string{"password: '******'", "grpc_port: 50051", "shutdown_timeout: 30s"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Print() output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDefault_IsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Default().Validate() error = %v", err)
+	}
+}
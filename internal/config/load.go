@@ -0,0 +1,295 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted remplace la valeur des champs secrets à l'affichage
+const redacted = "******"
+
+// Options contient les options de ligne de commande qui ne font pas partie de la configuration
+type Options struct {
+	File        string // Fichier de configuration YAML ou JSON (--config ou CONFIG_FILE)
+	PrintConfig bool   // Afficher la configuration effective puis quitter (--print-config)
+}
+
+// Load charge la configuration par couches: valeurs par défaut, fichier YAML/JSON,
+// variables d'environnement (ou leur variante *_FILE) puis options de ligne de commande.
+// La configuration est validée; toutes les erreurs sont rapportées ensemble.
+// La configuration chargée est retournée même en cas d'erreur, pour --print-config.
+func Load(args []string) (*Config, Options, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	// Les options sont lues en premier pour connaître le fichier, mais appliquées en dernier
+	opts, overrides, err := parseFlags(args, fields)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	var errs []error
+	if opts.File != "" {
+		errs = append(errs, loadFile(opts.File, fields)...)
+	}
+	errs = append(errs, loadEnv(fields)...)
+	for _, override := range overrides {
+		errs = append(errs, override()...)
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return cfg, opts, errors.Join(errs...)
+}
+
+// field champ de configuration adressable
+type field struct {
+	path   string // chemin des tags json, ex: "database.password"
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+// set affecte une valeur textuelle au champ selon son type
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case f.value.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: durée invalide %q", f.path, raw)
+		}
+		f.value.SetInt(int64(d))
+	case f.value.Kind() == reflect.String:
+		f.value.SetString(raw)
+	case f.value.Kind() == reflect.Int:
+		n, err := strconv.ParseInt(raw, 10, 0)
+		if err != nil {
+			return fmt.Errorf("%s: entier invalide %q", f.path, raw)
+		}
+		f.value.SetInt(n)
+	case f.value.Kind() == reflect.Uint8 || f.value.Kind() == reflect.Uint32:
+		n, err := strconv.ParseUint(raw, 10, f.value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: entier positif invalide %q", f.path, raw)
+		}
+		f.value.SetUint(n)
+	case f.value.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: nombre invalide %q", f.path, raw)
+		}
+		f.value.SetFloat(n)
+	case f.value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: booléen invalide %q", f.path, raw)
+		}
+		f.value.SetBool(b)
+	default:
+		return fmt.Errorf("%s: type non supporté %s", f.path, f.value.Type())
+	}
+	return nil
+}
+
+// fields énumère les champs de la configuration dans l'ordre de déclaration
+func (c *Config) fields() []field {
+	var fields []field
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeOf(time.Duration(0)) {
+				walk(name, v.Field(i))
+				continue
+			}
+			fields = append(fields, field{
+				path:   name,
+				env:    sf.Tag.Get("env"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk("", reflect.ValueOf(c).Elem())
+	return fields
+}
+
+// parseFlags lit les options de ligne de commande. Chaque champ est exposé sous
+// son chemin (--server.port=8080); les affectations sont différées pour être appliquées en dernier.
+func parseFlags(args []string, fields []field) (Options, []func() []error, error) {
+	opts := Options{File: os.Getenv("CONFIG_FILE")}
+	var overrides []func() []error
+
+	fs := flag.NewFlagSet("coreapi", flag.ContinueOnError)
+	fs.StringVar(&opts.File, "config", opts.File, "Fichier de configuration YAML ou JSON (CONFIG_FILE)")
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "Afficher la configuration effective (secrets masqués) puis quitter")
+	for _, f := range fields {
+		f := f
+		fs.Func(f.path, "Surcharge "+f.env, func(raw string) error {
+			overrides = append(overrides, func() []error {
+				if err := f.set(raw); err != nil {
+					return []error{fmt.Errorf("option --%s: %w", f.path, err)}
+				}
+				return nil
+			})
+			return nil
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if fs.NArg() > 0 {
+		return opts, nil, fmt.Errorf("arguments inattendus: %v", fs.Args())
+	}
+	return opts, overrides, nil
+}
+
+// loadFile applique un fichier YAML ou JSON (JSON est un sous-ensemble de YAML).
+// Les clés inconnues sont rapportées pour détecter les fautes de frappe.
+func loadFile(path string, fields []field) []error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return []error{fmt.Errorf("lecture du fichier de configuration: %w", err)}
+	}
+
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return []error{fmt.Errorf("fichier de configuration %s invalide: %w", filepath.Base(path), err)}
+	}
+
+	values := map[string]string{}
+	flatten("", document, values)
+
+	var errs []error
+	for _, f := range fields {
+		raw, ok := values[f.path]
+		if !ok {
+			continue
+		}
+		delete(values, f.path)
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("fichier %s: %w", filepath.Base(path), err))
+		}
+	}
+
+	unknown := make([]string, 0, len(values))
+	for key := range values {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("fichier %s: clé inconnue %q", filepath.Base(path), key))
+	}
+
+	return errs
+}
+
+// flatten aplatit un document en chemins "section.cle" vers des valeurs textuelles
+func flatten(prefix string, node interface{}, values map[string]string) {
+	if children, ok := node.(map[string]interface{}); ok {
+		for key, child := range children {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, child, values)
+		}
+		return
+	}
+	if node != nil {
+		values[prefix] = fmt.Sprint(node)
+	}
+}
+
+// loadEnv applique les variables d'environnement. NOM_FILE désigne un fichier
+// contenant la valeur (secrets Docker); il ne peut pas être combiné avec NOM.
+func loadEnv(fields []field) []error {
+	var errs []error
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+
+		raw, set := os.LookupEnv(f.env)
+		if path := os.Getenv(f.env + "_FILE"); path != "" {
+			if set {
+				errs = append(errs, fmt.Errorf("%s et %s_FILE ne peuvent pas être définis ensemble", f.env, f.env))
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s_FILE: %w", f.env, err))
+				continue
+			}
+			raw, set = strings.TrimRight(string(content), "\r\n"), true
+		}
+		if !set || raw == "" {
+			continue
+		}
+
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("variable %s: %w", f.env, err))
+		}
+	}
+	return errs
+}
+
+// Print écrit la configuration effective en YAML, secrets masqués
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range c.fields() {
+		value := fmt.Sprint(f.value.Interface())
+		if f.secret && value != "" {
+			value = redacted
+		}
+
+		// Créer les sections intermédiaires dans l'ordre de déclaration
+		node := root
+		parts := strings.Split(f.path, ".")
+		for _, section := range parts[:len(parts)-1] {
+			node = childMapping(node, section)
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// childMapping retourne (en la créant si besoin) la section key d'un nœud YAML
+func childMapping(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Validate vérifie la cohérence de la configuration et rapporte tous les champs invalides
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// Serveur
+	v.port("server.port", c.Server.Port)
+	v.port("server.grpc_port", c.Server.GRPCPort)
+	v.check(c.Server.Port != c.Server.GRPCPort, "server.grpc_port", "doit différer de server.port")
+	v.port("server.admin_port", c.Server.AdminPort)
+	v.check(c.Server.AdminPort != c.Server.Port && c.Server.AdminPort != c.Server.GRPCPort, "server.admin_port", "doit différer de server.port et server.grpc_port")
+	v.positive("server.read_timeout", int64(c.Server.ReadTimeout))
+	v.positive("server.write_timeout", int64(c.Server.WriteTimeout))
+	v.positive("server.idle_timeout", int64(c.Server.IdleTimeout))
+	v.check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay", "ne peut pas être négatif")
+	v.positive("server.shutdown_timeout", int64(c.Server.ShutdownTimeout))
+
+	// Base de données
+	v.required("database.host", c.Database.Host)
+	v.check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port", "doit être compris entre 1 et 65535")
+	v.required("database.user", c.Database.User)
+	v.required("database.name", c.Database.Name)
+	v.oneOf("database.ssl_mode", c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	v.positive("database.max_open_conns", int64(c.Database.MaxOpenConns))
+	v.check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns", "ne peut pas être négatif")
+	v.check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime", "ne peut pas être négatif")
+
+	// Services Ory
+	v.httpURL("ory.kratos.public_url", c.Ory.Kratos.PublicURL)
+	v.httpURL("ory.kratos.admin_url", c.Ory.Kratos.AdminURL)
+	v.httpURL("ory.hydra.public_url", c.Ory.Hydra.PublicURL)
+	v.httpURL("ory.hydra.admin_url", c.Ory.Hydra.AdminURL)
+	v.check(c.Ory.Hydra.LoginRememberFor >= 0, "ory.hydra.login_remember_for", "ne peut pas être négatif")
+	v.check(c.Ory.Hydra.ConsentRememberFor >= 0, "ory.hydra.consent_remember_for", "ne peut pas être négatif")
+	v.httpURL("ory.keto.read_url", c.Ory.Keto.ReadURL)
+	v.httpURL("ory.keto.write_url", c.Ory.Keto.WriteURL)
+
+	// Logging
+	v.oneOf("logging.level", c.Logging.Level, "debug", "info", "warn", "error")
+	v.oneOf("logging.format", c.Logging.Format, "text", "json")
+	v.oneOf("logging.redaction", c.Logging.Redaction, "mask", "hash", "drop")
+	v.check(c.Logging.Redaction != "hash" || c.Logging.RedactionKey != "", "logging.redaction_key", "requis pour la politique hash")
+
+	// Traçage
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, "otlp", "stdout", "none")
+	if c.Tracing.Exporter == "otlp" {
+		v.httpURL("tracing.otlp_endpoint", c.Tracing.OTLPEndpoint)
+	}
+	v.required("tracing.service_name", c.Tracing.ServiceName)
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "doit être compris entre 0 et 1")
+
+	// Sondes de santé
+	v.positive("health.check_interval", int64(c.Health.CheckInterval))
+	v.positive("health.check_timeout", int64(c.Health.CheckTimeout))
+	v.check(c.Health.CheckTimeout <= c.Health.CheckInterval, "health.check_timeout", "ne peut pas dépasser health.check_interval")
+
+	// Hachage des mots de passe
+	v.oneOf("password.algorithm", c.Password.Algorithm, "argon2id", "bcrypt")
+	if c.Password.Algorithm == "argon2id" {
+		v.positive("password.argon2_memory", int64(c.Password.Argon2Memory))
+		v.positive("password.argon2_iterations", int64(c.Password.Argon2Iterations))
+		v.positive("password.argon2_parallelism", int64(c.Password.Argon2Parallelism))
+		v.positive("password.argon2_salt_length", int64(c.Password.Argon2SaltLength))
+		v.positive("password.argon2_key_length", int64(c.Password.Argon2KeyLength))
+	}
+	if c.Password.Algorithm == "bcrypt" {
+		v.check(c.Password.BcryptCost >= 4 && c.Password.BcryptCost <= 31, "password.bcrypt_cost", "doit être compris entre 4 et 31")
+	}
+
+	// Réconciliation des utilisateurs
+	v.check(c.Reconciliation.Interval >= 0, "reconciliation.interval", "ne peut pas être négatif")
+	v.check(c.Reconciliation.PageSize >= 1 && c.Reconciliation.PageSize <= 1000, "reconciliation.page_size", "doit être compris entre 1 et 1000")
+
+	// Idempotence
+	v.oneOf("idempotency.store", c.Idempotency.Store, "postgres", "memory")
+	v.positive("idempotency.ttl", int64(c.Idempotency.TTL))
+	v.positive("idempotency.lock_timeout", int64(c.Idempotency.LockTimeout))
+	v.check(c.Idempotency.LockTimeout <= c.Idempotency.TTL, "idempotency.lock_timeout", "ne peut pas dépasser idempotency.ttl")
+	v.positive("idempotency.cleanup_interval", int64(c.Idempotency.CleanupInterval))
+
+	// Cache des sessions
+	if c.SessionCache.Enabled {
+		v.positive("session_cache.size", int64(c.SessionCache.Size))
+		v.positive("session_cache.ttl", int64(c.SessionCache.TTL))
+	}
+
+	// Cache des permissions
+	if c.PermissionCache.Enabled {
+		v.positive("permission_cache.size", int64(c.PermissionCache.Size))
+		v.positive("permission_cache.ttl", int64(c.PermissionCache.TTL))
+	}
+
+	return v.err()
+}
+
+// validator accumule les erreurs de validation
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, path, message string) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", path, message))
+	}
+}
+
+func (v *validator) required(path, value string) {
+	v.check(value != "", path, "requis")
+}
+
+func (v *validator) positive(path string, value int64) {
+	v.check(value > 0, path, "doit être strictement positif")
+}
+
+func (v *validator) port(path, value string) {
+	port, err := strconv.Atoi(value)
+	v.check(err == nil && port > 0 && port <= 65535, path, fmt.Sprintf("port invalide %q", value))
+}
+
+func (v *validator) oneOf(path, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, path, fmt.Sprintf("valeur %q invalide, valeurs acceptées: %v", value, allowed))
+}
+
+func (v *validator) httpURL(path, value string) {
+	u, err := url.Parse(value)
+	v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", path, fmt.Sprintf("URL http(s) invalide %q", value))
+}
+
+func (v *validator) err() error {
+	return errors.Join(v.errs...)
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // Driver PostgreSQL pour database/sql
+)
+
+// migrationLockID identifie le verrou consultatif utilisé pendant les migrations
+const migrationLockID = 7268190347
+
+// Open ouvre un pool de connexions PostgreSQL et vérifie qu'il est joignable
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("pgx", cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'ouverture de la base de données: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(pingCtx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("base de données injoignable (%s:%d): %w", cfg.Host, cfg.Port, err)
+	}
+
+	return db, nil
+}
+
+// Migrate applique dans l'ordre les migrations *.up.sql qui ne l'ont pas encore été
+func Migrate(ctx context.Context, db *sql.DB, migrations fs.FS, logger common.Logger) error {
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return fmt.Errorf("erreur lors de la lecture des migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	// Une connexion dédiée est nécessaire pour conserver le verrou consultatif
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'obtention d'une connexion: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("erreur lors du verrouillage des migrations: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("erreur lors de la création de la table schema_migrations: %w", err)
+	}
+
+	for _, file := range files {
+		version := strings.TrimSuffix(file, ".up.sql")
+
+		var applied bool
+		if err := conn.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version,
+		).Scan(&applied); err != nil {
+			return fmt.Errorf("erreur lors de la vérification de la migration %s: %w", version, err)
+		}
+		if applied {
+			continue
+		}
+
+		content, err := fs.ReadFile(migrations, file)
+		if err != nil {
+			return fmt.Errorf("erreur lors de la lecture de la migration %s: %w", version, err)
+		}
+
+		if err := applyMigration(ctx, conn, version, string(content)); err != nil {
+			return err
+		}
+
+		logger.Info("Migration appliquée", "version", version)
+	}
+
+	return nil
+}
+
+// applyMigration exécute une migration et l'enregistre dans une même transaction
+func applyMigration(ctx context.Context, conn *sql.Conn, version, content string) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erreur lors du démarrage de la migration %s: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, content); err != nil {
+		return fmt.Errorf("erreur lors de l'application de la migration %s: %w", version, err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+		return fmt.Errorf("erreur lors de l'enregistrement de la migration %s: %w", version, err)
+	}
+
+	return tx.Commit()
+}
@@ -7,8 +7,11 @@
 package v1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -287,27 +290,32 @@ func (x *GetUserResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type ValidateSessionRequest struct {
+type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionToken  string                 `protobuf:"bytes,1,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName     string                 `protobuf:"bytes,3,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName      string                 `protobuf:"bytes,4,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateSessionRequest) Reset() {
-	*x = ValidateSessionRequest{}
+func (x *User) Reset() {
+	*x = User{}
 	mi := &file_api_coreapi_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateSessionRequest) String() string {
+func (x *User) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateSessionRequest) ProtoMessage() {}
+func (*User) ProtoMessage() {}
 
-func (x *ValidateSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *User) ProtoReflect() protoreflect.Message {
 	mi := &file_api_coreapi_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -319,42 +327,80 @@ func (x *ValidateSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateSessionRequest.ProtoReflect.Descriptor instead.
-func (*ValidateSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
 	return file_api_coreapi_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ValidateSessionRequest) GetSessionToken() string {
+func (x *User) GetUserId() string {
 	if x != nil {
-		return x.SessionToken
+		return x.UserId
 	}
 	return ""
 }
 
-type ValidateSessionResponse struct {
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *User) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// updateMask liste les champs à modifier (email, firstName, lastName);
+// sans masque, seuls les champs non vides sont modifiés
+type UpdateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName     string                 `protobuf:"bytes,3,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName      string                 `protobuf:"bytes,4,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateSessionResponse) Reset() {
-	*x = ValidateSessionResponse{}
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
 	mi := &file_api_coreapi_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateSessionResponse) String() string {
+func (x *UpdateUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateSessionResponse) ProtoMessage() {}
+func (*UpdateUserRequest) ProtoMessage() {}
 
-func (x *ValidateSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_coreapi_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -366,64 +412,2556 @@ func (x *ValidateSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateSessionResponse.ProtoReflect.Descriptor instead.
-func (*ValidateSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
 	return file_api_coreapi_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ValidateSessionResponse) GetValid() bool {
+func (x *UpdateUserRequest) GetUserId() string {
 	if x != nil {
-		return x.Valid
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-func (x *ValidateSessionResponse) GetUserId() string {
+func (x *UpdateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// L'identité Kratos, l'utilisateur local et ses permissions Keto sont supprimés
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteUserRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *ValidateSessionResponse) GetEmail() string {
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[8]
 	if x != nil {
-		return x.Email
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ValidateSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
+// Les filtres sont optionnels: préfixe de l'email (insensible à la casse) et
+// intervalle de création [createdAfter, createdBefore)
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	EmailPrefix   string                 `protobuf:"bytes,3,opt,name=emailPrefix,proto3" json:"emailPrefix,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdAfter,proto3" json:"createdAfter,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdBefore,proto3" json:"createdBefore,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[9]
 	if x != nil {
-		return x.ExpiresAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetEmailPrefix() string {
+	if x != nil {
+		return x.EmailPrefix
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
 	}
 	return nil
 }
 
-// Messages pour OAuth2
-type CreateOAuth2ClientRequest struct {
+func (x *ListUsersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
-	ClientName    string                 `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
-	RedirectUri   string                 `protobuf:"bytes,3,opt,name=redirectUri,proto3" json:"redirectUri,omitempty"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Le token (clients natifs, X-Session-Token) ou le cookie ory_kratos_session (navigateurs) est requis
+type ValidateSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionToken  string                 `protobuf:"bytes,1,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	SessionCookie string                 `protobuf:"bytes,2,opt,name=sessionCookie,proto3" json:"sessionCookie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateSessionRequest) Reset() {
+	*x = ValidateSessionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateSessionRequest) ProtoMessage() {}
+
+func (x *ValidateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateSessionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidateSessionRequest) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *ValidateSessionRequest) GetSessionCookie() string {
+	if x != nil {
+		return x.SessionCookie
+	}
+	return ""
+}
+
+type ValidateSessionResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Valid                 bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	UserId                string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Email                 string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	ExpiresAt             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	AuthenticatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=authenticatedAt,proto3" json:"authenticatedAt,omitempty"`
+	Aal                   string                 `protobuf:"bytes,6,opt,name=aal,proto3" json:"aal,omitempty"`
+	AuthenticationMethods []string               `protobuf:"bytes,7,rep,name=authenticationMethods,proto3" json:"authenticationMethods,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ValidateSessionResponse) Reset() {
+	*x = ValidateSessionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateSessionResponse) ProtoMessage() {}
+
+func (x *ValidateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateSessionResponse.ProtoReflect.Descriptor instead.
+func (*ValidateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidateSessionResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateSessionResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ValidateSessionResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ValidateSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ValidateSessionResponse) GetAuthenticatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthenticatedAt
+	}
+	return nil
+}
+
+func (x *ValidateSessionResponse) GetAal() string {
+	if x != nil {
+		return x.Aal
+	}
+	return ""
+}
+
+func (x *ValidateSessionResponse) GetAuthenticationMethods() []string {
+	if x != nil {
+		return x.AuthenticationMethods
+	}
+	return nil
+}
+
+// Déconnexion d'un client natif: le token de session est révoqué dans Kratos
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionToken  string                 `protobuf:"bytes,1,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RevokeSessionRequest) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RevokeSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Messages pour OAuth2
+// Le secret n'est jamais inclus: il n'est renvoyé qu'à la création et à la rotation
+type OAuth2Client struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ClientId                string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	ClientName              string                 `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	RedirectUris            []string               `protobuf:"bytes,3,rep,name=redirectUris,proto3" json:"redirectUris,omitempty"`
+	GrantTypes              []string               `protobuf:"bytes,4,rep,name=grantTypes,proto3" json:"grantTypes,omitempty"`
+	ResponseTypes           []string               `protobuf:"bytes,5,rep,name=responseTypes,proto3" json:"responseTypes,omitempty"`
+	Scopes                  []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	TokenEndpointAuthMethod string                 `protobuf:"bytes,7,opt,name=tokenEndpointAuthMethod,proto3" json:"tokenEndpointAuthMethod,omitempty"`
+	CreatedAt               *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt               *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *OAuth2Client) Reset() {
+	*x = OAuth2Client{}
+	mi := &file_api_coreapi_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2Client) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2Client) ProtoMessage() {}
+
+func (x *OAuth2Client) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2Client.ProtoReflect.Descriptor instead.
+func (*OAuth2Client) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OAuth2Client) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuth2Client) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *OAuth2Client) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *OAuth2Client) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *OAuth2Client) GetResponseTypes() []string {
+	if x != nil {
+		return x.ResponseTypes
+	}
+	return nil
+}
+
+func (x *OAuth2Client) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuth2Client) GetTokenEndpointAuthMethod() string {
+	if x != nil {
+		return x.TokenEndpointAuthMethod
+	}
+	return ""
+}
+
+func (x *OAuth2Client) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OAuth2Client) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// clientId est optionnel (généré par Hydra); redirectUri est conservé pour compatibilité
+type CreateOAuth2ClientRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ClientId                string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	ClientName              string                 `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	RedirectUri             string                 `protobuf:"bytes,3,opt,name=redirectUri,proto3" json:"redirectUri,omitempty"`
+	RedirectUris            []string               `protobuf:"bytes,4,rep,name=redirectUris,proto3" json:"redirectUris,omitempty"`
+	GrantTypes              []string               `protobuf:"bytes,5,rep,name=grantTypes,proto3" json:"grantTypes,omitempty"`
+	ResponseTypes           []string               `protobuf:"bytes,6,rep,name=responseTypes,proto3" json:"responseTypes,omitempty"`
+	Scopes                  []string               `protobuf:"bytes,7,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	TokenEndpointAuthMethod string                 `protobuf:"bytes,8,opt,name=tokenEndpointAuthMethod,proto3" json:"tokenEndpointAuthMethod,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *CreateOAuth2ClientRequest) Reset() {
+	*x = CreateOAuth2ClientRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuth2ClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuth2ClientRequest) ProtoMessage() {}
+
+func (x *CreateOAuth2ClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuth2ClientRequest.ProtoReflect.Descriptor instead.
+func (*CreateOAuth2ClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateOAuth2ClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CreateOAuth2ClientRequest) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *CreateOAuth2ClientRequest) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+func (x *CreateOAuth2ClientRequest) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *CreateOAuth2ClientRequest) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *CreateOAuth2ClientRequest) GetResponseTypes() []string {
+	if x != nil {
+		return x.ResponseTypes
+	}
+	return nil
+}
+
+func (x *CreateOAuth2ClientRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateOAuth2ClientRequest) GetTokenEndpointAuthMethod() string {
+	if x != nil {
+		return x.TokenEndpointAuthMethod
+	}
+	return ""
+}
+
+type CreateOAuth2ClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	ClientName    string                 `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,3,opt,name=clientSecret,proto3" json:"clientSecret,omitempty"`
+	RedirectUris  []string               `protobuf:"bytes,4,rep,name=redirectUris,proto3" json:"redirectUris,omitempty"`
+	Client        *OAuth2Client          `protobuf:"bytes,5,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuth2ClientResponse) Reset() {
+	*x = CreateOAuth2ClientResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuth2ClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuth2ClientResponse) ProtoMessage() {}
+
+func (x *CreateOAuth2ClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuth2ClientResponse.ProtoReflect.Descriptor instead.
+func (*CreateOAuth2ClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateOAuth2ClientResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CreateOAuth2ClientResponse) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *CreateOAuth2ClientResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *CreateOAuth2ClientResponse) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *CreateOAuth2ClientResponse) GetClient() *OAuth2Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type GetOAuth2ClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuth2ClientRequest) Reset() {
+	*x = GetOAuth2ClientRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuth2ClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuth2ClientRequest) ProtoMessage() {}
+
+func (x *GetOAuth2ClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuth2ClientRequest.ProtoReflect.Descriptor instead.
+func (*GetOAuth2ClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetOAuth2ClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type GetOAuth2ClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        *OAuth2Client          `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuth2ClientResponse) Reset() {
+	*x = GetOAuth2ClientResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuth2ClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuth2ClientResponse) ProtoMessage() {}
+
+func (x *GetOAuth2ClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuth2ClientResponse.ProtoReflect.Descriptor instead.
+func (*GetOAuth2ClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetOAuth2ClientResponse) GetClient() *OAuth2Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type ListOAuth2ClientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuth2ClientsRequest) Reset() {
+	*x = ListOAuth2ClientsRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuth2ClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuth2ClientsRequest) ProtoMessage() {}
+
+func (x *ListOAuth2ClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuth2ClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListOAuth2ClientsRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListOAuth2ClientsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOAuth2ClientsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOAuth2ClientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clients       []*OAuth2Client        `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuth2ClientsResponse) Reset() {
+	*x = ListOAuth2ClientsResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuth2ClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuth2ClientsResponse) ProtoMessage() {}
+
+func (x *ListOAuth2ClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuth2ClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListOAuth2ClientsResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListOAuth2ClientsResponse) GetClients() []*OAuth2Client {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *ListOAuth2ClientsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Les champs vides ne sont pas modifiés
+type UpdateOAuth2ClientRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ClientId                string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	ClientName              string                 `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
+	RedirectUris            []string               `protobuf:"bytes,3,rep,name=redirectUris,proto3" json:"redirectUris,omitempty"`
+	GrantTypes              []string               `protobuf:"bytes,4,rep,name=grantTypes,proto3" json:"grantTypes,omitempty"`
+	ResponseTypes           []string               `protobuf:"bytes,5,rep,name=responseTypes,proto3" json:"responseTypes,omitempty"`
+	Scopes                  []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	TokenEndpointAuthMethod string                 `protobuf:"bytes,7,opt,name=tokenEndpointAuthMethod,proto3" json:"tokenEndpointAuthMethod,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *UpdateOAuth2ClientRequest) Reset() {
+	*x = UpdateOAuth2ClientRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOAuth2ClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOAuth2ClientRequest) ProtoMessage() {}
+
+func (x *UpdateOAuth2ClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOAuth2ClientRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOAuth2ClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateOAuth2ClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *UpdateOAuth2ClientRequest) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *UpdateOAuth2ClientRequest) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *UpdateOAuth2ClientRequest) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *UpdateOAuth2ClientRequest) GetResponseTypes() []string {
+	if x != nil {
+		return x.ResponseTypes
+	}
+	return nil
+}
+
+func (x *UpdateOAuth2ClientRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *UpdateOAuth2ClientRequest) GetTokenEndpointAuthMethod() string {
+	if x != nil {
+		return x.TokenEndpointAuthMethod
+	}
+	return ""
+}
+
+type UpdateOAuth2ClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        *OAuth2Client          `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOAuth2ClientResponse) Reset() {
+	*x = UpdateOAuth2ClientResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOAuth2ClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOAuth2ClientResponse) ProtoMessage() {}
+
+func (x *UpdateOAuth2ClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOAuth2ClientResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOAuth2ClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateOAuth2ClientResponse) GetClient() *OAuth2Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type DeleteOAuth2ClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOAuth2ClientRequest) Reset() {
+	*x = DeleteOAuth2ClientRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOAuth2ClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOAuth2ClientRequest) ProtoMessage() {}
+
+func (x *DeleteOAuth2ClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOAuth2ClientRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOAuth2ClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteOAuth2ClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type DeleteOAuth2ClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOAuth2ClientResponse) Reset() {
+	*x = DeleteOAuth2ClientResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOAuth2ClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOAuth2ClientResponse) ProtoMessage() {}
+
+func (x *DeleteOAuth2ClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOAuth2ClientResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOAuth2ClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteOAuth2ClientResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteOAuth2ClientResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RotateOAuth2ClientSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOAuth2ClientSecretRequest) Reset() {
+	*x = RotateOAuth2ClientSecretRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOAuth2ClientSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOAuth2ClientSecretRequest) ProtoMessage() {}
+
+func (x *RotateOAuth2ClientSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOAuth2ClientSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateOAuth2ClientSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RotateOAuth2ClientSecretRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RotateOAuth2ClientSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,2,opt,name=clientSecret,proto3" json:"clientSecret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOAuth2ClientSecretResponse) Reset() {
+	*x = RotateOAuth2ClientSecretResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOAuth2ClientSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOAuth2ClientSecretResponse) ProtoMessage() {}
+
+func (x *RotateOAuth2ClientSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOAuth2ClientSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateOAuth2ClientSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RotateOAuth2ClientSecretResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RotateOAuth2ClientSecretResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+// Messages pour les permissions
+type CreatePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePermissionRequest) Reset() {
+	*x = CreatePermissionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePermissionRequest) ProtoMessage() {}
+
+func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreatePermissionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CreatePermissionRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *CreatePermissionRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *CreatePermissionRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type CreatePermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePermissionResponse) Reset() {
+	*x = CreatePermissionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePermissionResponse) ProtoMessage() {}
+
+func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePermissionResponse.ProtoReflect.Descriptor instead.
+func (*CreatePermissionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreatePermissionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreatePermissionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Le sujet est un identifiant ou un subject set "namespace:object#relation"
+type DeletePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePermissionRequest) Reset() {
+	*x = DeletePermissionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePermissionRequest) ProtoMessage() {}
+
+func (x *DeletePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePermissionRequest.ProtoReflect.Descriptor instead.
+func (*DeletePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeletePermissionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeletePermissionRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *DeletePermissionRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *DeletePermissionRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type DeletePermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePermissionResponse) Reset() {
+	*x = DeletePermissionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePermissionResponse) ProtoMessage() {}
+
+func (x *DeletePermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePermissionResponse.ProtoReflect.Descriptor instead.
+func (*DeletePermissionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeletePermissionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeletePermissionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CheckPermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionRequest) Reset() {
+	*x = CheckPermissionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionRequest) ProtoMessage() {}
+
+func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionRequest.ProtoReflect.Descriptor instead.
+func (*CheckPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CheckPermissionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type CheckPermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HasPermission bool                   `protobuf:"varint,1,opt,name=hasPermission,proto3" json:"hasPermission,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionResponse) Reset() {
+	*x = CheckPermissionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionResponse) ProtoMessage() {}
+
+func (x *CheckPermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionResponse.ProtoReflect.Descriptor instead.
+func (*CheckPermissionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CheckPermissionResponse) GetHasPermission() bool {
+	if x != nil {
+		return x.HasPermission
+	}
+	return false
+}
+
+func (x *CheckPermissionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Vérifie plusieurs tuples en une requête (100 au plus)
+type BatchCheckPermissionRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Checks        []*CheckPermissionRequest `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCheckPermissionRequest) Reset() {
+	*x = BatchCheckPermissionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCheckPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCheckPermissionRequest) ProtoMessage() {}
+
+func (x *BatchCheckPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCheckPermissionRequest.ProtoReflect.Descriptor instead.
+func (*BatchCheckPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BatchCheckPermissionRequest) GetChecks() []*CheckPermissionRequest {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+// Un résultat par tuple, dans l'ordre de la requête
+type BatchCheckPermissionResponse struct {
+	state         protoimpl.MessageState                 `protogen:"open.v1"`
+	Results       []*BatchCheckPermissionResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCheckPermissionResponse) Reset() {
+	*x = BatchCheckPermissionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCheckPermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCheckPermissionResponse) ProtoMessage() {}
+
+func (x *BatchCheckPermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCheckPermissionResponse.ProtoReflect.Descriptor instead.
+func (*BatchCheckPermissionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BatchCheckPermissionResponse) GetResults() []*BatchCheckPermissionResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// maxDepth limite la profondeur de l'arbre; 0 applique la limite de Keto
+type ExpandPermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,4,opt,name=maxDepth,proto3" json:"maxDepth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandPermissionRequest) Reset() {
+	*x = ExpandPermissionRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandPermissionRequest) ProtoMessage() {}
+
+func (x *ExpandPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandPermissionRequest.ProtoReflect.Descriptor instead.
+func (*ExpandPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ExpandPermissionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ExpandPermissionRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ExpandPermissionRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ExpandPermissionRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+// Nœud de l'arbre des sujets d'une relation
+type SubjectTree struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// union, exclusion, intersection, leaf, ...
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Identifiant ou subject set "namespace:object#relation", développé par children
+	Subject       string         `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Children      []*SubjectTree `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubjectTree) Reset() {
+	*x = SubjectTree{}
+	mi := &file_api_coreapi_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubjectTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectTree) ProtoMessage() {}
+
+func (x *SubjectTree) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectTree.ProtoReflect.Descriptor instead.
+func (*SubjectTree) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubjectTree) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SubjectTree) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SubjectTree) GetChildren() []*SubjectTree {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// tree est absent si aucun sujet n'a la relation
+type ExpandPermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *SubjectTree           `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandPermissionResponse) Reset() {
+	*x = ExpandPermissionResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandPermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandPermissionResponse) ProtoMessage() {}
+
+func (x *ExpandPermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandPermissionResponse.ProtoReflect.Descriptor instead.
+func (*ExpandPermissionResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExpandPermissionResponse) GetTree() *SubjectTree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+type RelationTuple struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationTuple) Reset() {
+	*x = RelationTuple{}
+	mi := &file_api_coreapi_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationTuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationTuple) ProtoMessage() {}
+
+func (x *RelationTuple) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationTuple.ProtoReflect.Descriptor instead.
+func (*RelationTuple) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RelationTuple) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RelationTuple) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *RelationTuple) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *RelationTuple) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// Les filtres sont optionnels et combinables; pageToken est le nextPageToken de la page précédente
+type ListRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsRequest) Reset() {
+	*x = ListRelationsRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsRequest) ProtoMessage() {}
+
+func (x *ListRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListRelationsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListRelationsRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ListRelationsRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ListRelationsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListRelationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRelationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relations     []*RelationTuple       `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsResponse) Reset() {
+	*x = ListRelationsResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsResponse) ProtoMessage() {}
+
+func (x *ListRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListRelationsResponse) GetRelations() []*RelationTuple {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *ListRelationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Messages pour CustomerService
+type Customer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customerId,proto3" json:"customerId,omitempty"`
+	PhoneCode     string                 `protobuf:"bytes,2,opt,name=phoneCode,proto3" json:"phoneCode,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	IsActive      bool                   `protobuf:"varint,4,opt,name=isActive,proto3" json:"isActive,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Customer) Reset() {
+	*x = Customer{}
+	mi := &file_api_coreapi_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Customer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Customer) ProtoMessage() {}
+
+func (x *Customer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Customer.ProtoReflect.Descriptor instead.
+func (*Customer) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Customer) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Customer) GetPhoneCode() string {
+	if x != nil {
+		return x.PhoneCode
+	}
+	return ""
+}
+
+func (x *Customer) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Customer) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Customer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Customer) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhoneCode     string                 `protobuf:"bytes,1,opt,name=phoneCode,proto3" json:"phoneCode,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,2,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerRequest) Reset() {
+	*x = CreateCustomerRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerRequest) ProtoMessage() {}
+
+func (x *CreateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateCustomerRequest) GetPhoneCode() string {
+	if x != nil {
+		return x.PhoneCode
+	}
+	return ""
+}
+
+func (x *CreateCustomerRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *CreateCustomerRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerResponse) Reset() {
+	*x = CreateCustomerResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerResponse) ProtoMessage() {}
+
+func (x *CreateCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerResponse.ProtoReflect.Descriptor instead.
+func (*CreateCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateCustomerResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type GetCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customerId,proto3" json:"customerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerRequest) Reset() {
+	*x = GetCustomerRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerRequest) ProtoMessage() {}
+
+func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type GetCustomerByPhoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhoneCode     string                 `protobuf:"bytes,1,opt,name=phoneCode,proto3" json:"phoneCode,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,2,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerByPhoneRequest) Reset() {
+	*x = GetCustomerByPhoneRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerByPhoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerByPhoneRequest) ProtoMessage() {}
+
+func (x *GetCustomerByPhoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerByPhoneRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerByPhoneRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetCustomerByPhoneRequest) GetPhoneCode() string {
+	if x != nil {
+		return x.PhoneCode
+	}
+	return ""
+}
+
+func (x *GetCustomerByPhoneRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type GetCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerResponse) Reset() {
+	*x = GetCustomerResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerResponse) ProtoMessage() {}
+
+func (x *GetCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetCustomerResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+// Les champs vides ne sont pas modifiés
+type UpdateCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customerId,proto3" json:"customerId,omitempty"`
+	PhoneCode     string                 `protobuf:"bytes,2,opt,name=phoneCode,proto3" json:"phoneCode,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOAuth2ClientRequest) Reset() {
-	*x = CreateOAuth2ClientRequest{}
-	mi := &file_api_coreapi_proto_msgTypes[6]
+func (x *UpdateCustomerRequest) Reset() {
+	*x = UpdateCustomerRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOAuth2ClientRequest) String() string {
+func (x *UpdateCustomerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOAuth2ClientRequest) ProtoMessage() {}
+func (*UpdateCustomerRequest) ProtoMessage() {}
 
-func (x *CreateOAuth2ClientRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_coreapi_proto_msgTypes[6]
+func (x *UpdateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,57 +2972,61 @@ func (x *CreateOAuth2ClientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOAuth2ClientRequest.ProtoReflect.Descriptor instead.
-func (*CreateOAuth2ClientRequest) Descriptor() ([]byte, []int) {
-	return file_api_coreapi_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UpdateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *CreateOAuth2ClientRequest) GetClientId() string {
+func (x *UpdateCustomerRequest) GetCustomerId() string {
 	if x != nil {
-		return x.ClientId
+		return x.CustomerId
 	}
 	return ""
 }
 
-func (x *CreateOAuth2ClientRequest) GetClientName() string {
+func (x *UpdateCustomerRequest) GetPhoneCode() string {
 	if x != nil {
-		return x.ClientName
+		return x.PhoneCode
 	}
 	return ""
 }
 
-func (x *CreateOAuth2ClientRequest) GetRedirectUri() string {
+func (x *UpdateCustomerRequest) GetPhoneNumber() string {
 	if x != nil {
-		return x.RedirectUri
+		return x.PhoneNumber
 	}
 	return ""
 }
 
-type CreateOAuth2ClientResponse struct {
+func (x *UpdateCustomerRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type UpdateCustomerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
-	ClientName    string                 `protobuf:"bytes,2,opt,name=clientName,proto3" json:"clientName,omitempty"`
-	ClientSecret  string                 `protobuf:"bytes,3,opt,name=clientSecret,proto3" json:"clientSecret,omitempty"`
-	RedirectUris  []string               `protobuf:"bytes,4,rep,name=redirectUris,proto3" json:"redirectUris,omitempty"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOAuth2ClientResponse) Reset() {
-	*x = CreateOAuth2ClientResponse{}
-	mi := &file_api_coreapi_proto_msgTypes[7]
+func (x *UpdateCustomerResponse) Reset() {
+	*x = UpdateCustomerResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOAuth2ClientResponse) String() string {
+func (x *UpdateCustomerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOAuth2ClientResponse) ProtoMessage() {}
+func (*UpdateCustomerResponse) ProtoMessage() {}
 
-func (x *CreateOAuth2ClientResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_coreapi_proto_msgTypes[7]
+func (x *UpdateCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,65 +3037,84 @@ func (x *CreateOAuth2ClientResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOAuth2ClientResponse.ProtoReflect.Descriptor instead.
-func (*CreateOAuth2ClientResponse) Descriptor() ([]byte, []int) {
-	return file_api_coreapi_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use UpdateCustomerResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *CreateOAuth2ClientResponse) GetClientId() string {
+func (x *UpdateCustomerResponse) GetCustomer() *Customer {
 	if x != nil {
-		return x.ClientId
+		return x.Customer
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateOAuth2ClientResponse) GetClientName() string {
-	if x != nil {
-		return x.ClientName
-	}
-	return ""
+type DeactivateCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customerId,proto3" json:"customerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOAuth2ClientResponse) GetClientSecret() string {
+func (x *DeactivateCustomerRequest) Reset() {
+	*x = DeactivateCustomerRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateCustomerRequest) ProtoMessage() {}
+
+func (x *DeactivateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[50]
 	if x != nil {
-		return x.ClientSecret
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateOAuth2ClientResponse) GetRedirectUris() []string {
+// Deprecated: Use DeactivateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DeactivateCustomerRequest) GetCustomerId() string {
 	if x != nil {
-		return x.RedirectUris
+		return x.CustomerId
 	}
-	return nil
+	return ""
 }
 
-// Messages pour les permissions
-type CreatePermissionRequest struct {
+type DeactivateCustomerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
-	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
-	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePermissionRequest) Reset() {
-	*x = CreatePermissionRequest{}
-	mi := &file_api_coreapi_proto_msgTypes[8]
+func (x *DeactivateCustomerResponse) Reset() {
+	*x = DeactivateCustomerResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePermissionRequest) String() string {
+func (x *DeactivateCustomerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePermissionRequest) ProtoMessage() {}
+func (*DeactivateCustomerResponse) ProtoMessage() {}
 
-func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_coreapi_proto_msgTypes[8]
+func (x *DeactivateCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,62 +3125,92 @@ func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
-func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_api_coreapi_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use DeactivateCustomerResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *CreatePermissionRequest) GetNamespace() string {
+func (x *DeactivateCustomerResponse) GetCustomer() *Customer {
 	if x != nil {
-		return x.Namespace
+		return x.Customer
 	}
-	return ""
+	return nil
 }
 
-func (x *CreatePermissionRequest) GetObject() string {
+type ListCustomersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomersRequest) Reset() {
+	*x = ListCustomersRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersRequest) ProtoMessage() {}
+
+func (x *ListCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[52]
 	if x != nil {
-		return x.Object
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreatePermissionRequest) GetRelation() string {
+// Deprecated: Use ListCustomersRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListCustomersRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Relation
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *CreatePermissionRequest) GetSubject() string {
+func (x *ListCustomersRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Subject
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-type CreatePermissionResponse struct {
+type ListCustomersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Customers     []*Customer            `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePermissionResponse) Reset() {
-	*x = CreatePermissionResponse{}
-	mi := &file_api_coreapi_proto_msgTypes[9]
+func (x *ListCustomersResponse) Reset() {
+	*x = ListCustomersResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePermissionResponse) String() string {
+func (x *ListCustomersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePermissionResponse) ProtoMessage() {}
+func (*ListCustomersResponse) ProtoMessage() {}
 
-func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_coreapi_proto_msgTypes[9]
+func (x *ListCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -630,50 +3221,42 @@ func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePermissionResponse.ProtoReflect.Descriptor instead.
-func (*CreatePermissionResponse) Descriptor() ([]byte, []int) {
-	return file_api_coreapi_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *CreatePermissionResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use ListCustomersResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *CreatePermissionResponse) GetMessage() string {
+func (x *ListCustomersResponse) GetCustomers() []*Customer {
 	if x != nil {
-		return x.Message
+		return x.Customers
 	}
-	return ""
+	return nil
 }
 
-type CheckPermissionRequest struct {
+type VerifyCustomerCredentialsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Object        string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
-	Relation      string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
-	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	PhoneCode     string                 `protobuf:"bytes,1,opt,name=phoneCode,proto3" json:"phoneCode,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,2,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckPermissionRequest) Reset() {
-	*x = CheckPermissionRequest{}
-	mi := &file_api_coreapi_proto_msgTypes[10]
+func (x *VerifyCustomerCredentialsRequest) Reset() {
+	*x = VerifyCustomerCredentialsRequest{}
+	mi := &file_api_coreapi_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckPermissionRequest) String() string {
+func (x *VerifyCustomerCredentialsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckPermissionRequest) ProtoMessage() {}
+func (*VerifyCustomerCredentialsRequest) ProtoMessage() {}
 
-func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_coreapi_proto_msgTypes[10]
+func (x *VerifyCustomerCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -684,62 +3267,100 @@ func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckPermissionRequest.ProtoReflect.Descriptor instead.
-func (*CheckPermissionRequest) Descriptor() ([]byte, []int) {
-	return file_api_coreapi_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use VerifyCustomerCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCustomerCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *CheckPermissionRequest) GetNamespace() string {
+func (x *VerifyCustomerCredentialsRequest) GetPhoneCode() string {
 	if x != nil {
-		return x.Namespace
+		return x.PhoneCode
 	}
 	return ""
 }
 
-func (x *CheckPermissionRequest) GetObject() string {
+func (x *VerifyCustomerCredentialsRequest) GetPhoneNumber() string {
 	if x != nil {
-		return x.Object
+		return x.PhoneNumber
 	}
 	return ""
 }
 
-func (x *CheckPermissionRequest) GetRelation() string {
+func (x *VerifyCustomerCredentialsRequest) GetPassword() string {
 	if x != nil {
-		return x.Relation
+		return x.Password
 	}
 	return ""
 }
 
-func (x *CheckPermissionRequest) GetSubject() string {
+type VerifyCustomerCredentialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCustomerCredentialsResponse) Reset() {
+	*x = VerifyCustomerCredentialsResponse{}
+	mi := &file_api_coreapi_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCustomerCredentialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCustomerCredentialsResponse) ProtoMessage() {}
+
+func (x *VerifyCustomerCredentialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[55]
 	if x != nil {
-		return x.Subject
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type CheckPermissionResponse struct {
+// Deprecated: Use VerifyCustomerCredentialsResponse.ProtoReflect.Descriptor instead.
+func (*VerifyCustomerCredentialsResponse) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *VerifyCustomerCredentialsResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type BatchCheckPermissionResponse_Result struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HasPermission bool                   `protobuf:"varint,1,opt,name=hasPermission,proto3" json:"hasPermission,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Présent si le tuple n'a pas pu être vérifié (tuple invalide, Keto indisponible)
+	Error         *status.Status `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckPermissionResponse) Reset() {
-	*x = CheckPermissionResponse{}
-	mi := &file_api_coreapi_proto_msgTypes[11]
+func (x *BatchCheckPermissionResponse_Result) Reset() {
+	*x = BatchCheckPermissionResponse_Result{}
+	mi := &file_api_coreapi_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckPermissionResponse) String() string {
+func (x *BatchCheckPermissionResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckPermissionResponse) ProtoMessage() {}
+func (*BatchCheckPermissionResponse_Result) ProtoMessage() {}
 
-func (x *CheckPermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_coreapi_proto_msgTypes[11]
+func (x *BatchCheckPermissionResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_api_coreapi_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,30 +3371,30 @@ func (x *CheckPermissionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckPermissionResponse.ProtoReflect.Descriptor instead.
-func (*CheckPermissionResponse) Descriptor() ([]byte, []int) {
-	return file_api_coreapi_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use BatchCheckPermissionResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchCheckPermissionResponse_Result) Descriptor() ([]byte, []int) {
+	return file_api_coreapi_proto_rawDescGZIP(), []int{35, 0}
 }
 
-func (x *CheckPermissionResponse) GetHasPermission() bool {
+func (x *BatchCheckPermissionResponse_Result) GetHasPermission() bool {
 	if x != nil {
 		return x.HasPermission
 	}
 	return false
 }
 
-func (x *CheckPermissionResponse) GetMessage() string {
+func (x *BatchCheckPermissionResponse_Result) GetError() *status.Status {
 	if x != nil {
-		return x.Message
+		return x.Error
 	}
-	return ""
+	return nil
 }
 
 var File_api_coreapi_proto protoreflect.FileDescriptor
 
 const file_api_coreapi_proto_rawDesc = "" +
 	"\n" +
-	"\x11api/coreapi.proto\x12\bndugu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"c\n" +
+	"\x11api/coreapi.proto\x12\bndugu.v1\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17google/rpc/status.proto\"c\n" +
 	"\x11CreateUserRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1c\n" +
 	"\tfirstName\x18\x02 \x01(\tR\tfirstName\x12\x1a\n" +
@@ -792,27 +3413,123 @@ const file_api_coreapi_proto_rawDesc = "" +
 	"\tfirstName\x18\x03 \x01(\tR\tfirstName\x12\x1a\n" +
 	"\blastName\x18\x04 \x01(\tR\blastName\x128\n" +
 	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"<\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe2\x01\n" +
+	"\x04User\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1c\n" +
+	"\tfirstName\x18\x03 \x01(\tR\tfirstName\x12\x1a\n" +
+	"\blastName\x18\x04 \x01(\tR\blastName\x128\n" +
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb7\x01\n" +
+	"\x11UpdateUserRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1c\n" +
+	"\tfirstName\x18\x03 \x01(\tR\tfirstName\x12\x1a\n" +
+	"\blastName\x18\x04 \x01(\tR\blastName\x12:\n" +
+	"\n" +
+	"updateMask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"8\n" +
+	"\x12UpdateUserResponse\x12\"\n" +
+	"\x04user\x18\x01 \x01(\v2\x0e.ndugu.v1.UserR\x04user\"+\n" +
+	"\x11DeleteUserRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"H\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xf0\x01\n" +
+	"\x10ListUsersRequest\x12\x1a\n" +
+	"\bpageSize\x18\x01 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\x12 \n" +
+	"\vemailPrefix\x18\x03 \x01(\tR\vemailPrefix\x12>\n" +
+	"\fcreatedAfter\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12@\n" +
+	"\rcreatedBefore\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\"_\n" +
+	"\x11ListUsersResponse\x12$\n" +
+	"\x05users\x18\x01 \x03(\v2\x0e.ndugu.v1.UserR\x05users\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"b\n" +
 	"\x16ValidateSessionRequest\x12\"\n" +
-	"\fsessionToken\x18\x01 \x01(\tR\fsessionToken\"\x97\x01\n" +
+	"\fsessionToken\x18\x01 \x01(\tR\fsessionToken\x12$\n" +
+	"\rsessionCookie\x18\x02 \x01(\tR\rsessionCookie\"\xa5\x02\n" +
 	"\x17ValidateSessionResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
 	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x128\n" +
-	"\texpiresAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"y\n" +
+	"\texpiresAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12D\n" +
+	"\x0fauthenticatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fauthenticatedAt\x12\x10\n" +
+	"\x03aal\x18\x06 \x01(\tR\x03aal\x124\n" +
+	"\x15authenticationMethods\x18\a \x03(\tR\x15authenticationMethods\":\n" +
+	"\x14RevokeSessionRequest\x12\"\n" +
+	"\fsessionToken\x18\x01 \x01(\tR\fsessionToken\"K\n" +
+	"\x15RevokeSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xfa\x02\n" +
+	"\fOAuth2Client\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x1e\n" +
+	"\n" +
+	"clientName\x18\x02 \x01(\tR\n" +
+	"clientName\x12\"\n" +
+	"\fredirectUris\x18\x03 \x03(\tR\fredirectUris\x12\x1e\n" +
+	"\n" +
+	"grantTypes\x18\x04 \x03(\tR\n" +
+	"grantTypes\x12$\n" +
+	"\rresponseTypes\x18\x05 \x03(\tR\rresponseTypes\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x128\n" +
+	"\x17tokenEndpointAuthMethod\x18\a \x01(\tR\x17tokenEndpointAuthMethod\x128\n" +
+	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb5\x02\n" +
 	"\x19CreateOAuth2ClientRequest\x12\x1a\n" +
 	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x1e\n" +
 	"\n" +
 	"clientName\x18\x02 \x01(\tR\n" +
 	"clientName\x12 \n" +
-	"\vredirectUri\x18\x03 \x01(\tR\vredirectUri\"\xa0\x01\n" +
+	"\vredirectUri\x18\x03 \x01(\tR\vredirectUri\x12\"\n" +
+	"\fredirectUris\x18\x04 \x03(\tR\fredirectUris\x12\x1e\n" +
+	"\n" +
+	"grantTypes\x18\x05 \x03(\tR\n" +
+	"grantTypes\x12$\n" +
+	"\rresponseTypes\x18\x06 \x03(\tR\rresponseTypes\x12\x16\n" +
+	"\x06scopes\x18\a \x03(\tR\x06scopes\x128\n" +
+	"\x17tokenEndpointAuthMethod\x18\b \x01(\tR\x17tokenEndpointAuthMethod\"\xd0\x01\n" +
 	"\x1aCreateOAuth2ClientResponse\x12\x1a\n" +
 	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x1e\n" +
 	"\n" +
 	"clientName\x18\x02 \x01(\tR\n" +
 	"clientName\x12\"\n" +
 	"\fclientSecret\x18\x03 \x01(\tR\fclientSecret\x12\"\n" +
-	"\fredirectUris\x18\x04 \x03(\tR\fredirectUris\"\x85\x01\n" +
+	"\fredirectUris\x18\x04 \x03(\tR\fredirectUris\x12.\n" +
+	"\x06client\x18\x05 \x01(\v2\x16.ndugu.v1.OAuth2ClientR\x06client\"4\n" +
+	"\x16GetOAuth2ClientRequest\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\"I\n" +
+	"\x17GetOAuth2ClientResponse\x12.\n" +
+	"\x06client\x18\x01 \x01(\v2\x16.ndugu.v1.OAuth2ClientR\x06client\"T\n" +
+	"\x18ListOAuth2ClientsRequest\x12\x1a\n" +
+	"\bpageSize\x18\x01 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\"s\n" +
+	"\x19ListOAuth2ClientsResponse\x120\n" +
+	"\aclients\x18\x01 \x03(\v2\x16.ndugu.v1.OAuth2ClientR\aclients\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\x93\x02\n" +
+	"\x19UpdateOAuth2ClientRequest\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x1e\n" +
+	"\n" +
+	"clientName\x18\x02 \x01(\tR\n" +
+	"clientName\x12\"\n" +
+	"\fredirectUris\x18\x03 \x03(\tR\fredirectUris\x12\x1e\n" +
+	"\n" +
+	"grantTypes\x18\x04 \x03(\tR\n" +
+	"grantTypes\x12$\n" +
+	"\rresponseTypes\x18\x05 \x03(\tR\rresponseTypes\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x128\n" +
+	"\x17tokenEndpointAuthMethod\x18\a \x01(\tR\x17tokenEndpointAuthMethod\"L\n" +
+	"\x1aUpdateOAuth2ClientResponse\x12.\n" +
+	"\x06client\x18\x01 \x01(\v2\x16.ndugu.v1.OAuth2ClientR\x06client\"7\n" +
+	"\x19DeleteOAuth2ClientRequest\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\"P\n" +
+	"\x1aDeleteOAuth2ClientResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"=\n" +
+	"\x1fRotateOAuth2ClientSecretRequest\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\"b\n" +
+	" RotateOAuth2ClientSecretResponse\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\"\n" +
+	"\fclientSecret\x18\x02 \x01(\tR\fclientSecret\"\x85\x01\n" +
 	"\x17CreatePermissionRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
 	"\x06object\x18\x02 \x01(\tR\x06object\x12\x1a\n" +
@@ -820,6 +3537,14 @@ const file_api_coreapi_proto_rawDesc = "" +
 	"\asubject\x18\x04 \x01(\tR\asubject\"N\n" +
 	"\x18CreatePermissionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x85\x01\n" +
+	"\x17DeletePermissionRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\"N\n" +
+	"\x18DeletePermissionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"\x84\x01\n" +
 	"\x16CheckPermissionRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
@@ -828,15 +3553,121 @@ const file_api_coreapi_proto_rawDesc = "" +
 	"\asubject\x18\x04 \x01(\tR\asubject\"Y\n" +
 	"\x17CheckPermissionResponse\x12$\n" +
 	"\rhasPermission\x18\x01 \x01(\bR\rhasPermission\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\x82\x04\n" +
-	"\vAuthService\x12G\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"W\n" +
+	"\x1bBatchCheckPermissionRequest\x128\n" +
+	"\x06checks\x18\x01 \x03(\v2 .ndugu.v1.CheckPermissionRequestR\x06checks\"\xc1\x01\n" +
+	"\x1cBatchCheckPermissionResponse\x12G\n" +
+	"\aresults\x18\x01 \x03(\v2-.ndugu.v1.BatchCheckPermissionResponse.ResultR\aresults\x1aX\n" +
+	"\x06Result\x12$\n" +
+	"\rhasPermission\x18\x01 \x01(\bR\rhasPermission\x12(\n" +
+	"\x05error\x18\x02 \x01(\v2\x12.google.rpc.StatusR\x05error\"\x87\x01\n" +
+	"\x17ExpandPermissionRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x1a\n" +
+	"\bmaxDepth\x18\x04 \x01(\x05R\bmaxDepth\"n\n" +
+	"\vSubjectTree\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x121\n" +
+	"\bchildren\x18\x03 \x03(\v2\x15.ndugu.v1.SubjectTreeR\bchildren\"E\n" +
+	"\x18ExpandPermissionResponse\x12)\n" +
+	"\x04tree\x18\x01 \x01(\v2\x15.ndugu.v1.SubjectTreeR\x04tree\"{\n" +
+	"\rRelationTuple\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\"\xbc\x01\n" +
+	"\x14ListRelationsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x1a\n" +
+	"\bpageSize\x18\x05 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x06 \x01(\tR\tpageToken\"t\n" +
+	"\x15ListRelationsResponse\x125\n" +
+	"\trelations\x18\x01 \x03(\v2\x17.ndugu.v1.RelationTupleR\trelations\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\xfa\x01\n" +
+	"\bCustomer\x12\x1e\n" +
+	"\n" +
+	"customerId\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1c\n" +
+	"\tphoneCode\x18\x02 \x01(\tR\tphoneCode\x12 \n" +
+	"\vphoneNumber\x18\x03 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bisActive\x18\x04 \x01(\bR\bisActive\x128\n" +
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"s\n" +
+	"\x15CreateCustomerRequest\x12\x1c\n" +
+	"\tphoneCode\x18\x01 \x01(\tR\tphoneCode\x12 \n" +
+	"\vphoneNumber\x18\x02 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"H\n" +
+	"\x16CreateCustomerResponse\x12.\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x12.ndugu.v1.CustomerR\bcustomer\"4\n" +
+	"\x12GetCustomerRequest\x12\x1e\n" +
+	"\n" +
+	"customerId\x18\x01 \x01(\tR\n" +
+	"customerId\"[\n" +
+	"\x19GetCustomerByPhoneRequest\x12\x1c\n" +
+	"\tphoneCode\x18\x01 \x01(\tR\tphoneCode\x12 \n" +
+	"\vphoneNumber\x18\x02 \x01(\tR\vphoneNumber\"E\n" +
+	"\x13GetCustomerResponse\x12.\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x12.ndugu.v1.CustomerR\bcustomer\"\x93\x01\n" +
+	"\x15UpdateCustomerRequest\x12\x1e\n" +
+	"\n" +
+	"customerId\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1c\n" +
+	"\tphoneCode\x18\x02 \x01(\tR\tphoneCode\x12 \n" +
+	"\vphoneNumber\x18\x03 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\"H\n" +
+	"\x16UpdateCustomerResponse\x12.\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x12.ndugu.v1.CustomerR\bcustomer\";\n" +
+	"\x19DeactivateCustomerRequest\x12\x1e\n" +
+	"\n" +
+	"customerId\x18\x01 \x01(\tR\n" +
+	"customerId\"L\n" +
+	"\x1aDeactivateCustomerResponse\x12.\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x12.ndugu.v1.CustomerR\bcustomer\"D\n" +
+	"\x14ListCustomersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"I\n" +
+	"\x15ListCustomersResponse\x120\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x12.ndugu.v1.CustomerR\tcustomers\"~\n" +
+	" VerifyCustomerCredentialsRequest\x12\x1c\n" +
+	"\tphoneCode\x18\x01 \x01(\tR\tphoneCode\x12 \n" +
+	"\vphoneNumber\x18\x02 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"S\n" +
+	"!VerifyCustomerCredentialsResponse\x12.\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x12.ndugu.v1.CustomerR\bcustomer2\xe8\x11\n" +
+	"\vAuthService\x12]\n" +
+	"\n" +
+	"CreateUser\x12\x1b.ndugu.v1.CreateUserRequest\x1a\x1c.ndugu.v1.CreateUserResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12Z\n" +
+	"\aGetUser\x12\x18.ndugu.v1.GetUserRequest\x1a\x19.ndugu.v1.GetUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userId}\x12f\n" +
+	"\n" +
+	"UpdateUser\x12\x1b.ndugu.v1.UpdateUserRequest\x1a\x1c.ndugu.v1.UpdateUserResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*2\x12/v1/users/{userId}\x12c\n" +
 	"\n" +
-	"CreateUser\x12\x1b.ndugu.v1.CreateUserRequest\x1a\x1c.ndugu.v1.CreateUserResponse\x12>\n" +
-	"\aGetUser\x12\x18.ndugu.v1.GetUserRequest\x1a\x19.ndugu.v1.GetUserResponse\x12V\n" +
-	"\x0fValidateSession\x12 .ndugu.v1.ValidateSessionRequest\x1a!.ndugu.v1.ValidateSessionResponse\x12_\n" +
-	"\x12CreateOAuth2Client\x12#.ndugu.v1.CreateOAuth2ClientRequest\x1a$.ndugu.v1.CreateOAuth2ClientResponse\x12Y\n" +
-	"\x10CreatePermission\x12!.ndugu.v1.CreatePermissionRequest\x1a\".ndugu.v1.CreatePermissionResponse\x12V\n" +
-	"\x0fCheckPermission\x12 .ndugu.v1.CheckPermissionRequest\x1a!.ndugu.v1.CheckPermissionResponseB$Z\"ndugu-backend/internal/grpc/api/v1b\x06proto3"
+	"DeleteUser\x12\x1b.ndugu.v1.DeleteUserRequest\x1a\x1c.ndugu.v1.DeleteUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userId}\x12W\n" +
+	"\tListUsers\x12\x1a.ndugu.v1.ListUsersRequest\x1a\x1b.ndugu.v1.ListUsersResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12x\n" +
+	"\x0fValidateSession\x12 .ndugu.v1.ValidateSessionRequest\x1a!.ndugu.v1.ValidateSessionResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/sessions:validate\x12p\n" +
+	"\rRevokeSession\x12\x1e.ndugu.v1.RevokeSessionRequest\x1a\x1f.ndugu.v1.RevokeSessionResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/sessions:revoke\x12~\n" +
+	"\x12CreateOAuth2Client\x12#.ndugu.v1.CreateOAuth2ClientRequest\x1a$.ndugu.v1.CreateOAuth2ClientResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/oauth2/clients\x12}\n" +
+	"\x0fGetOAuth2Client\x12 .ndugu.v1.GetOAuth2ClientRequest\x1a!.ndugu.v1.GetOAuth2ClientResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/oauth2/clients/{clientId}\x12x\n" +
+	"\x11ListOAuth2Clients\x12\".ndugu.v1.ListOAuth2ClientsRequest\x1a#.ndugu.v1.ListOAuth2ClientsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/oauth2/clients\x12\x89\x01\n" +
+	"\x12UpdateOAuth2Client\x12#.ndugu.v1.UpdateOAuth2ClientRequest\x1a$.ndugu.v1.UpdateOAuth2ClientResponse\"(\x82\xd3\xe4\x93\x02\":\x01*2\x1d/v1/oauth2/clients/{clientId}\x12\x86\x01\n" +
+	"\x12DeleteOAuth2Client\x12#.ndugu.v1.DeleteOAuth2ClientRequest\x1a$.ndugu.v1.DeleteOAuth2ClientResponse\"%\x82\xd3\xe4\x93\x02\x1f*\x1d/v1/oauth2/clients/{clientId}\x12\xa8\x01\n" +
+	"\x18RotateOAuth2ClientSecret\x12).ndugu.v1.RotateOAuth2ClientSecretRequest\x1a*.ndugu.v1.RotateOAuth2ClientSecretResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/v1/oauth2/clients/{clientId}:rotateSecret\x12u\n" +
+	"\x10CreatePermission\x12!.ndugu.v1.CreatePermissionRequest\x1a\".ndugu.v1.CreatePermissionResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/permissions\x12r\n" +
+	"\x10DeletePermission\x12!.ndugu.v1.DeletePermissionRequest\x1a\".ndugu.v1.DeletePermissionResponse\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/permissions\x12u\n" +
+	"\x0fCheckPermission\x12 .ndugu.v1.CheckPermissionRequest\x1a!.ndugu.v1.CheckPermissionResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/permissions:check\x12\x8c\x01\n" +
+	"\x14BatchCheckPermission\x12%.ndugu.v1.BatchCheckPermissionRequest\x1a&.ndugu.v1.BatchCheckPermissionResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/permissions:batchCheck\x12y\n" +
+	"\x10ExpandPermission\x12!.ndugu.v1.ExpandPermissionRequest\x1a\".ndugu.v1.ExpandPermissionResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/permissions:expand\x12g\n" +
+	"\rListRelations\x12\x1e.ndugu.v1.ListRelationsRequest\x1a\x1f.ndugu.v1.ListRelationsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/relations2\x85\a\n" +
+	"\x0fCustomerService\x12m\n" +
+	"\x0eCreateCustomer\x12\x1f.ndugu.v1.CreateCustomerRequest\x1a .ndugu.v1.CreateCustomerResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/customers\x12n\n" +
+	"\vGetCustomer\x12\x1c.ndugu.v1.GetCustomerRequest\x1a\x1d.ndugu.v1.GetCustomerResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/customers/{customerId}\x12w\n" +
+	"\x12GetCustomerByPhone\x12#.ndugu.v1.GetCustomerByPhoneRequest\x1a\x1d.ndugu.v1.GetCustomerResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/customers:byPhone\x12z\n" +
+	"\x0eUpdateCustomer\x12\x1f.ndugu.v1.UpdateCustomerRequest\x1a .ndugu.v1.UpdateCustomerResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*2\x1a/v1/customers/{customerId}\x12\x91\x01\n" +
+	"\x12DeactivateCustomer\x12#.ndugu.v1.DeactivateCustomerRequest\x1a$.ndugu.v1.DeactivateCustomerResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/customers/{customerId}:deactivate\x12g\n" +
+	"\rListCustomers\x12\x1e.ndugu.v1.ListCustomersRequest\x1a\x1f.ndugu.v1.ListCustomersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/customers\x12\xa0\x01\n" +
+	"\x19VerifyCustomerCredentials\x12*.ndugu.v1.VerifyCustomerCredentialsRequest\x1a+.ndugu.v1.VerifyCustomerCredentialsResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/customers:verifyCredentialsB$Z\"ndugu-backend/internal/grpc/api/v1b\x06proto3"
 
 var (
 	file_api_coreapi_proto_rawDescOnce sync.Once
@@ -850,44 +3681,159 @@ func file_api_coreapi_proto_rawDescGZIP() []byte {
 	return file_api_coreapi_proto_rawDescData
 }
 
-var file_api_coreapi_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_coreapi_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_api_coreapi_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),          // 0: ndugu.v1.CreateUserRequest
-	(*CreateUserResponse)(nil),         // 1: ndugu.v1.CreateUserResponse
-	(*GetUserRequest)(nil),             // 2: ndugu.v1.GetUserRequest
-	(*GetUserResponse)(nil),            // 3: ndugu.v1.GetUserResponse
-	(*ValidateSessionRequest)(nil),     // 4: ndugu.v1.ValidateSessionRequest
-	(*ValidateSessionResponse)(nil),    // 5: ndugu.v1.ValidateSessionResponse
-	(*CreateOAuth2ClientRequest)(nil),  // 6: ndugu.v1.CreateOAuth2ClientRequest
-	(*CreateOAuth2ClientResponse)(nil), // 7: ndugu.v1.CreateOAuth2ClientResponse
-	(*CreatePermissionRequest)(nil),    // 8: ndugu.v1.CreatePermissionRequest
-	(*CreatePermissionResponse)(nil),   // 9: ndugu.v1.CreatePermissionResponse
-	(*CheckPermissionRequest)(nil),     // 10: ndugu.v1.CheckPermissionRequest
-	(*CheckPermissionResponse)(nil),    // 11: ndugu.v1.CheckPermissionResponse
-	(*timestamppb.Timestamp)(nil),      // 12: google.protobuf.Timestamp
+	(*CreateUserRequest)(nil),                   // 0: ndugu.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),                  // 1: ndugu.v1.CreateUserResponse
+	(*GetUserRequest)(nil),                      // 2: ndugu.v1.GetUserRequest
+	(*GetUserResponse)(nil),                     // 3: ndugu.v1.GetUserResponse
+	(*User)(nil),                                // 4: ndugu.v1.User
+	(*UpdateUserRequest)(nil),                   // 5: ndugu.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),                  // 6: ndugu.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),                   // 7: ndugu.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),                  // 8: ndugu.v1.DeleteUserResponse
+	(*ListUsersRequest)(nil),                    // 9: ndugu.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),                   // 10: ndugu.v1.ListUsersResponse
+	(*ValidateSessionRequest)(nil),              // 11: ndugu.v1.ValidateSessionRequest
+	(*ValidateSessionResponse)(nil),             // 12: ndugu.v1.ValidateSessionResponse
+	(*RevokeSessionRequest)(nil),                // 13: ndugu.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),               // 14: ndugu.v1.RevokeSessionResponse
+	(*OAuth2Client)(nil),                        // 15: ndugu.v1.OAuth2Client
+	(*CreateOAuth2ClientRequest)(nil),           // 16: ndugu.v1.CreateOAuth2ClientRequest
+	(*CreateOAuth2ClientResponse)(nil),          // 17: ndugu.v1.CreateOAuth2ClientResponse
+	(*GetOAuth2ClientRequest)(nil),              // 18: ndugu.v1.GetOAuth2ClientRequest
+	(*GetOAuth2ClientResponse)(nil),             // 19: ndugu.v1.GetOAuth2ClientResponse
+	(*ListOAuth2ClientsRequest)(nil),            // 20: ndugu.v1.ListOAuth2ClientsRequest
+	(*ListOAuth2ClientsResponse)(nil),           // 21: ndugu.v1.ListOAuth2ClientsResponse
+	(*UpdateOAuth2ClientRequest)(nil),           // 22: ndugu.v1.UpdateOAuth2ClientRequest
+	(*UpdateOAuth2ClientResponse)(nil),          // 23: ndugu.v1.UpdateOAuth2ClientResponse
+	(*DeleteOAuth2ClientRequest)(nil),           // 24: ndugu.v1.DeleteOAuth2ClientRequest
+	(*DeleteOAuth2ClientResponse)(nil),          // 25: ndugu.v1.DeleteOAuth2ClientResponse
+	(*RotateOAuth2ClientSecretRequest)(nil),     // 26: ndugu.v1.RotateOAuth2ClientSecretRequest
+	(*RotateOAuth2ClientSecretResponse)(nil),    // 27: ndugu.v1.RotateOAuth2ClientSecretResponse
+	(*CreatePermissionRequest)(nil),             // 28: ndugu.v1.CreatePermissionRequest
+	(*CreatePermissionResponse)(nil),            // 29: ndugu.v1.CreatePermissionResponse
+	(*DeletePermissionRequest)(nil),             // 30: ndugu.v1.DeletePermissionRequest
+	(*DeletePermissionResponse)(nil),            // 31: ndugu.v1.DeletePermissionResponse
+	(*CheckPermissionRequest)(nil),              // 32: ndugu.v1.CheckPermissionRequest
+	(*CheckPermissionResponse)(nil),             // 33: ndugu.v1.CheckPermissionResponse
+	(*BatchCheckPermissionRequest)(nil),         // 34: ndugu.v1.BatchCheckPermissionRequest
+	(*BatchCheckPermissionResponse)(nil),        // 35: ndugu.v1.BatchCheckPermissionResponse
+	(*ExpandPermissionRequest)(nil),             // 36: ndugu.v1.ExpandPermissionRequest
+	(*SubjectTree)(nil),                         // 37: ndugu.v1.SubjectTree
+	(*ExpandPermissionResponse)(nil),            // 38: ndugu.v1.ExpandPermissionResponse
+	(*RelationTuple)(nil),                       // 39: ndugu.v1.RelationTuple
+	(*ListRelationsRequest)(nil),                // 40: ndugu.v1.ListRelationsRequest
+	(*ListRelationsResponse)(nil),               // 41: ndugu.v1.ListRelationsResponse
+	(*Customer)(nil),                            // 42: ndugu.v1.Customer
+	(*CreateCustomerRequest)(nil),               // 43: ndugu.v1.CreateCustomerRequest
+	(*CreateCustomerResponse)(nil),              // 44: ndugu.v1.CreateCustomerResponse
+	(*GetCustomerRequest)(nil),                  // 45: ndugu.v1.GetCustomerRequest
+	(*GetCustomerByPhoneRequest)(nil),           // 46: ndugu.v1.GetCustomerByPhoneRequest
+	(*GetCustomerResponse)(nil),                 // 47: ndugu.v1.GetCustomerResponse
+	(*UpdateCustomerRequest)(nil),               // 48: ndugu.v1.UpdateCustomerRequest
+	(*UpdateCustomerResponse)(nil),              // 49: ndugu.v1.UpdateCustomerResponse
+	(*DeactivateCustomerRequest)(nil),           // 50: ndugu.v1.DeactivateCustomerRequest
+	(*DeactivateCustomerResponse)(nil),          // 51: ndugu.v1.DeactivateCustomerResponse
+	(*ListCustomersRequest)(nil),                // 52: ndugu.v1.ListCustomersRequest
+	(*ListCustomersResponse)(nil),               // 53: ndugu.v1.ListCustomersResponse
+	(*VerifyCustomerCredentialsRequest)(nil),    // 54: ndugu.v1.VerifyCustomerCredentialsRequest
+	(*VerifyCustomerCredentialsResponse)(nil),   // 55: ndugu.v1.VerifyCustomerCredentialsResponse
+	(*BatchCheckPermissionResponse_Result)(nil), // 56: ndugu.v1.BatchCheckPermissionResponse.Result
+	(*timestamppb.Timestamp)(nil),               // 57: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),               // 58: google.protobuf.FieldMask
+	(*status.Status)(nil),                       // 59: google.rpc.Status
 }
 var file_api_coreapi_proto_depIdxs = []int32{
-	12, // 0: ndugu.v1.CreateUserResponse.createdAt:type_name -> google.protobuf.Timestamp
-	12, // 1: ndugu.v1.GetUserResponse.createdAt:type_name -> google.protobuf.Timestamp
-	12, // 2: ndugu.v1.GetUserResponse.updatedAt:type_name -> google.protobuf.Timestamp
-	12, // 3: ndugu.v1.ValidateSessionResponse.expiresAt:type_name -> google.protobuf.Timestamp
-	0,  // 4: ndugu.v1.AuthService.CreateUser:input_type -> ndugu.v1.CreateUserRequest
-	2,  // 5: ndugu.v1.AuthService.GetUser:input_type -> ndugu.v1.GetUserRequest
-	4,  // 6: ndugu.v1.AuthService.ValidateSession:input_type -> ndugu.v1.ValidateSessionRequest
-	6,  // 7: ndugu.v1.AuthService.CreateOAuth2Client:input_type -> ndugu.v1.CreateOAuth2ClientRequest
-	8,  // 8: ndugu.v1.AuthService.CreatePermission:input_type -> ndugu.v1.CreatePermissionRequest
-	10, // 9: ndugu.v1.AuthService.CheckPermission:input_type -> ndugu.v1.CheckPermissionRequest
-	1,  // 10: ndugu.v1.AuthService.CreateUser:output_type -> ndugu.v1.CreateUserResponse
-	3,  // 11: ndugu.v1.AuthService.GetUser:output_type -> ndugu.v1.GetUserResponse
-	5,  // 12: ndugu.v1.AuthService.ValidateSession:output_type -> ndugu.v1.ValidateSessionResponse
-	7,  // 13: ndugu.v1.AuthService.CreateOAuth2Client:output_type -> ndugu.v1.CreateOAuth2ClientResponse
-	9,  // 14: ndugu.v1.AuthService.CreatePermission:output_type -> ndugu.v1.CreatePermissionResponse
-	11, // 15: ndugu.v1.AuthService.CheckPermission:output_type -> ndugu.v1.CheckPermissionResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	57, // 0: ndugu.v1.CreateUserResponse.createdAt:type_name -> google.protobuf.Timestamp
+	57, // 1: ndugu.v1.GetUserResponse.createdAt:type_name -> google.protobuf.Timestamp
+	57, // 2: ndugu.v1.GetUserResponse.updatedAt:type_name -> google.protobuf.Timestamp
+	57, // 3: ndugu.v1.User.createdAt:type_name -> google.protobuf.Timestamp
+	57, // 4: ndugu.v1.User.updatedAt:type_name -> google.protobuf.Timestamp
+	58, // 5: ndugu.v1.UpdateUserRequest.updateMask:type_name -> google.protobuf.FieldMask
+	4,  // 6: ndugu.v1.UpdateUserResponse.user:type_name -> ndugu.v1.User
+	57, // 7: ndugu.v1.ListUsersRequest.createdAfter:type_name -> google.protobuf.Timestamp
+	57, // 8: ndugu.v1.ListUsersRequest.createdBefore:type_name -> google.protobuf.Timestamp
+	4,  // 9: ndugu.v1.ListUsersResponse.users:type_name -> ndugu.v1.User
+	57, // 10: ndugu.v1.ValidateSessionResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	57, // 11: ndugu.v1.ValidateSessionResponse.authenticatedAt:type_name -> google.protobuf.Timestamp
+	57, // 12: ndugu.v1.OAuth2Client.createdAt:type_name -> google.protobuf.Timestamp
+	57, // 13: ndugu.v1.OAuth2Client.updatedAt:type_name -> google.protobuf.Timestamp
+	15, // 14: ndugu.v1.CreateOAuth2ClientResponse.client:type_name -> ndugu.v1.OAuth2Client
+	15, // 15: ndugu.v1.GetOAuth2ClientResponse.client:type_name -> ndugu.v1.OAuth2Client
+	15, // 16: ndugu.v1.ListOAuth2ClientsResponse.clients:type_name -> ndugu.v1.OAuth2Client
+	15, // 17: ndugu.v1.UpdateOAuth2ClientResponse.client:type_name -> ndugu.v1.OAuth2Client
+	32, // 18: ndugu.v1.BatchCheckPermissionRequest.checks:type_name -> ndugu.v1.CheckPermissionRequest
+	56, // 19: ndugu.v1.BatchCheckPermissionResponse.results:type_name -> ndugu.v1.BatchCheckPermissionResponse.Result
+	37, // 20: ndugu.v1.SubjectTree.children:type_name -> ndugu.v1.SubjectTree
+	37, // 21: ndugu.v1.ExpandPermissionResponse.tree:type_name -> ndugu.v1.SubjectTree
+	39, // 22: ndugu.v1.ListRelationsResponse.relations:type_name -> ndugu.v1.RelationTuple
+	57, // 23: ndugu.v1.Customer.createdAt:type_name -> google.protobuf.Timestamp
+	57, // 24: ndugu.v1.Customer.updatedAt:type_name -> google.protobuf.Timestamp
+	42, // 25: ndugu.v1.CreateCustomerResponse.customer:type_name -> ndugu.v1.Customer
+	42, // 26: ndugu.v1.GetCustomerResponse.customer:type_name -> ndugu.v1.Customer
+	42, // 27: ndugu.v1.UpdateCustomerResponse.customer:type_name -> ndugu.v1.Customer
+	42, // 28: ndugu.v1.DeactivateCustomerResponse.customer:type_name -> ndugu.v1.Customer
+	42, // 29: ndugu.v1.ListCustomersResponse.customers:type_name -> ndugu.v1.Customer
+	42, // 30: ndugu.v1.VerifyCustomerCredentialsResponse.customer:type_name -> ndugu.v1.Customer
+	59, // 31: ndugu.v1.BatchCheckPermissionResponse.Result.error:type_name -> google.rpc.Status
+	0,  // 32: ndugu.v1.AuthService.CreateUser:input_type -> ndugu.v1.CreateUserRequest
+	2,  // 33: ndugu.v1.AuthService.GetUser:input_type -> ndugu.v1.GetUserRequest
+	5,  // 34: ndugu.v1.AuthService.UpdateUser:input_type -> ndugu.v1.UpdateUserRequest
+	7,  // 35: ndugu.v1.AuthService.DeleteUser:input_type -> ndugu.v1.DeleteUserRequest
+	9,  // 36: ndugu.v1.AuthService.ListUsers:input_type -> ndugu.v1.ListUsersRequest
+	11, // 37: ndugu.v1.AuthService.ValidateSession:input_type -> ndugu.v1.ValidateSessionRequest
+	13, // 38: ndugu.v1.AuthService.RevokeSession:input_type -> ndugu.v1.RevokeSessionRequest
+	16, // 39: ndugu.v1.AuthService.CreateOAuth2Client:input_type -> ndugu.v1.CreateOAuth2ClientRequest
+	18, // 40: ndugu.v1.AuthService.GetOAuth2Client:input_type -> ndugu.v1.GetOAuth2ClientRequest
+	20, // 41: ndugu.v1.AuthService.ListOAuth2Clients:input_type -> ndugu.v1.ListOAuth2ClientsRequest
+	22, // 42: ndugu.v1.AuthService.UpdateOAuth2Client:input_type -> ndugu.v1.UpdateOAuth2ClientRequest
+	24, // 43: ndugu.v1.AuthService.DeleteOAuth2Client:input_type -> ndugu.v1.DeleteOAuth2ClientRequest
+	26, // 44: ndugu.v1.AuthService.RotateOAuth2ClientSecret:input_type -> ndugu.v1.RotateOAuth2ClientSecretRequest
+	28, // 45: ndugu.v1.AuthService.CreatePermission:input_type -> ndugu.v1.CreatePermissionRequest
+	30, // 46: ndugu.v1.AuthService.DeletePermission:input_type -> ndugu.v1.DeletePermissionRequest
+	32, // 47: ndugu.v1.AuthService.CheckPermission:input_type -> ndugu.v1.CheckPermissionRequest
+	34, // 48: ndugu.v1.AuthService.BatchCheckPermission:input_type -> ndugu.v1.BatchCheckPermissionRequest
+	36, // 49: ndugu.v1.AuthService.ExpandPermission:input_type -> ndugu.v1.ExpandPermissionRequest
+	40, // 50: ndugu.v1.AuthService.ListRelations:input_type -> ndugu.v1.ListRelationsRequest
+	43, // 51: ndugu.v1.CustomerService.CreateCustomer:input_type -> ndugu.v1.CreateCustomerRequest
+	45, // 52: ndugu.v1.CustomerService.GetCustomer:input_type -> ndugu.v1.GetCustomerRequest
+	46, // 53: ndugu.v1.CustomerService.GetCustomerByPhone:input_type -> ndugu.v1.GetCustomerByPhoneRequest
+	48, // 54: ndugu.v1.CustomerService.UpdateCustomer:input_type -> ndugu.v1.UpdateCustomerRequest
+	50, // 55: ndugu.v1.CustomerService.DeactivateCustomer:input_type -> ndugu.v1.DeactivateCustomerRequest
+	52, // 56: ndugu.v1.CustomerService.ListCustomers:input_type -> ndugu.v1.ListCustomersRequest
+	54, // 57: ndugu.v1.CustomerService.VerifyCustomerCredentials:input_type -> ndugu.v1.VerifyCustomerCredentialsRequest
+	1,  // 58: ndugu.v1.AuthService.CreateUser:output_type -> ndugu.v1.CreateUserResponse
+	3,  // 59: ndugu.v1.AuthService.GetUser:output_type -> ndugu.v1.GetUserResponse
+	6,  // 60: ndugu.v1.AuthService.UpdateUser:output_type -> ndugu.v1.UpdateUserResponse
+	8,  // 61: ndugu.v1.AuthService.DeleteUser:output_type -> ndugu.v1.DeleteUserResponse
+	10, // 62: ndugu.v1.AuthService.ListUsers:output_type -> ndugu.v1.ListUsersResponse
+	12, // 63: ndugu.v1.AuthService.ValidateSession:output_type -> ndugu.v1.ValidateSessionResponse
+	14, // 64: ndugu.v1.AuthService.RevokeSession:output_type -> ndugu.v1.RevokeSessionResponse
+	17, // 65: ndugu.v1.AuthService.CreateOAuth2Client:output_type -> ndugu.v1.CreateOAuth2ClientResponse
+	19, // 66: ndugu.v1.AuthService.GetOAuth2Client:output_type -> ndugu.v1.GetOAuth2ClientResponse
+	21, // 67: ndugu.v1.AuthService.ListOAuth2Clients:output_type -> ndugu.v1.ListOAuth2ClientsResponse
+	23, // 68: ndugu.v1.AuthService.UpdateOAuth2Client:output_type -> ndugu.v1.UpdateOAuth2ClientResponse
+	25, // 69: ndugu.v1.AuthService.DeleteOAuth2Client:output_type -> ndugu.v1.DeleteOAuth2ClientResponse
+	27, // 70: ndugu.v1.AuthService.RotateOAuth2ClientSecret:output_type -> ndugu.v1.RotateOAuth2ClientSecretResponse
+	29, // 71: ndugu.v1.AuthService.CreatePermission:output_type -> ndugu.v1.CreatePermissionResponse
+	31, // 72: ndugu.v1.AuthService.DeletePermission:output_type -> ndugu.v1.DeletePermissionResponse
+	33, // 73: ndugu.v1.AuthService.CheckPermission:output_type -> ndugu.v1.CheckPermissionResponse
+	35, // 74: ndugu.v1.AuthService.BatchCheckPermission:output_type -> ndugu.v1.BatchCheckPermissionResponse
+	38, // 75: ndugu.v1.AuthService.ExpandPermission:output_type -> ndugu.v1.ExpandPermissionResponse
+	41, // 76: ndugu.v1.AuthService.ListRelations:output_type -> ndugu.v1.ListRelationsResponse
+	44, // 77: ndugu.v1.CustomerService.CreateCustomer:output_type -> ndugu.v1.CreateCustomerResponse
+	47, // 78: ndugu.v1.CustomerService.GetCustomer:output_type -> ndugu.v1.GetCustomerResponse
+	47, // 79: ndugu.v1.CustomerService.GetCustomerByPhone:output_type -> ndugu.v1.GetCustomerResponse
+	49, // 80: ndugu.v1.CustomerService.UpdateCustomer:output_type -> ndugu.v1.UpdateCustomerResponse
+	51, // 81: ndugu.v1.CustomerService.DeactivateCustomer:output_type -> ndugu.v1.DeactivateCustomerResponse
+	53, // 82: ndugu.v1.CustomerService.ListCustomers:output_type -> ndugu.v1.ListCustomersResponse
+	55, // 83: ndugu.v1.CustomerService.VerifyCustomerCredentials:output_type -> ndugu.v1.VerifyCustomerCredentialsResponse
+	58, // [58:84] is the sub-list for method output_type
+	32, // [32:58] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_api_coreapi_proto_init() }
@@ -901,9 +3847,9 @@ func file_api_coreapi_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_coreapi_proto_rawDesc), len(file_api_coreapi_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   57,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_coreapi_proto_goTypes,
 		DependencyIndexes: file_api_coreapi_proto_depIdxs,
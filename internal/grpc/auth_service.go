@@ -2,7 +2,6 @@ package grpc
 
 import (
 	"context"
-	"time"
 
 	"ndugu-backend/internal/auth"
 )
@@ -98,7 +97,7 @@ func (s *AuthServiceServer) ValidateSession(ctx context.Context, req *ValidateSe
 		Valid:     true,
 		UserId:    session.Identity.Id,
 		Email:     email,
-		ExpiresAt: time.Now().Add(24 * time.Hour), // Exemple
+		ExpiresAt: session.GetExpiresAt(),
 	}, nil
 }
 
@@ -0,0 +1,41 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Report réponse des endpoints de santé HTTP
+type Report struct {
+	Status       string             `json:"status"` // SERVING ou NOT_SERVING
+	Dependencies []DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// LiveHandler sonde de vivacité (liveness): le processus répond, sans consulter les dépendances.
+// Une dépendance indisponible ne doit pas provoquer le redémarrage du conteneur.
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: "SERVING"})
+	})
+}
+
+// ReadyHandler sonde de disponibilité (readiness): 200 si le service peut recevoir du trafic,
+// 503 pendant le démarrage, l'arrêt ou l'indisponibilité d'une dépendance
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := Report{Status: "SERVING", Dependencies: c.Statuses()}
+		status := http.StatusOK
+		if !c.Ready() {
+			report.Status = "NOT_SERVING"
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,226 @@
+// Package health sonde périodiquement les dépendances du service (base de données,
+// Kratos, Hydra, Keto) et en déduit l'état du service de santé gRPC standard
+// (grpc.health.v1) ainsi que la disponibilité HTTP.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"ndugu-backend/internal/common"
+)
+
+// Probe vérifie une dépendance; une erreur la rend indisponible
+type Probe func(ctx context.Context) error
+
+// Dependency dépendance sondée par le Checker
+type Dependency struct {
+	Name  string
+	Probe Probe
+}
+
+// DependencyStatus résultat de la dernière sonde d'une dépendance.
+// L'erreur n'est pas exposée par les endpoints HTTP (adresses internes): elle est journalisée.
+type DependencyStatus struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"-"`
+	LatencyMs int64     `json:"latencyMs"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// HTTPProbe sonde un endpoint de santé (ex: Kratos /health/ready); tout code 2xx est un succès
+func HTTPProbe(client *http.Client, url string) Probe {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("statut HTTP %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// PingProbe sonde une base de données
+func PingProbe(db *sql.DB) Probe {
+	return db.PingContext
+}
+
+// Checker sonde les dépendances à intervalle régulier et met à jour l'état de santé gRPC:
+// un service est SERVING si toutes ses dépendances répondent. Le service "" (état global)
+// dépend de toutes les dépendances.
+type Checker struct {
+	server       *health.Server
+	dependencies []Dependency
+	services     map[string][]string
+	interval     time.Duration
+	timeout      time.Duration
+	ready        func() bool
+	logger       common.Logger
+
+	mu       sync.RWMutex
+	statuses map[string]DependencyStatus
+	draining atomic.Bool
+}
+
+// NewChecker crée un Checker. services associe chaque service gRPC aux noms des dépendances
+// qu'il requiert; ready indique si le service a fini de démarrer.
+// Tous les services sont NOT_SERVING jusqu'à la première sonde.
+func NewChecker(server *health.Server, dependencies []Dependency, services map[string][]string, interval, timeout time.Duration, ready func() bool, logger common.Logger) *Checker {
+	c := &Checker{
+		server:       server,
+		dependencies: dependencies,
+		services:     services,
+		interval:     interval,
+		timeout:      timeout,
+		ready:        ready,
+		logger:       logger,
+		statuses:     make(map[string]DependencyStatus),
+	}
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for service := range services {
+		c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return c
+}
+
+// Run sonde les dépendances immédiatement puis à chaque intervalle, jusqu'à l'annulation de ctx
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check sonde toutes les dépendances en parallèle puis met à jour l'état des services
+func (c *Checker) Check(ctx context.Context) {
+	results := make([]DependencyStatus, len(c.dependencies))
+	var wg sync.WaitGroup
+	for i, dep := range c.dependencies {
+		wg.Add(1)
+		go func(i int, dep Dependency) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, dep)
+		}(i, dep)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	for _, result := range results {
+		previous, known := c.statuses[result.Name]
+		c.statuses[result.Name] = result
+		switch {
+		case !result.Healthy && (!known || previous.Healthy):
+			c.logger.Warn("Dépendance indisponible", "dependency", result.Name, "error", result.Error)
+		case result.Healthy && known && !previous.Healthy:
+			c.logger.Info("Dépendance rétablie", "dependency", result.Name)
+		}
+	}
+	c.mu.Unlock()
+
+	c.update()
+}
+
+// probe sonde une dépendance avec l'échéance configurée
+func (c *Checker) probe(ctx context.Context, dep Dependency) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.Probe(ctx)
+	status := DependencyStatus{Name: dep.Name, Healthy: err == nil, LatencyMs: time.Since(start).Milliseconds(), CheckedAt: start}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// update applique l'état des dépendances aux services gRPC
+func (c *Checker) update() {
+	if c.draining.Load() {
+		return
+	}
+	c.server.SetServingStatus("", c.servingStatus(c.dependencyNames()))
+	for service, required := range c.services {
+		c.server.SetServingStatus(service, c.servingStatus(required))
+	}
+}
+
+// servingStatus SERVING si toutes les dépendances nommées ont répondu à leur dernière sonde
+func (c *Checker) servingStatus(required []string) healthpb.HealthCheckResponse_ServingStatus {
+	if c.healthy(required) {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// healthy indique si toutes les dépendances nommées ont répondu à leur dernière sonde
+func (c *Checker) healthy(required []string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, name := range required {
+		if status, ok := c.statuses[name]; !ok || !status.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// Drain passe définitivement tous les services à NOT_SERVING: appelé au début de l'arrêt,
+// pour que les clients et répartiteurs de charge cessent d'envoyer du trafic
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+	c.server.Shutdown()
+}
+
+// Ready indique si le service peut recevoir du trafic: démarré, pas en arrêt, dépendances disponibles
+func (c *Checker) Ready() bool {
+	return c.ready() && !c.draining.Load() && c.healthy(c.dependencyNames())
+}
+
+// dependencyNames retourne le nom de toutes les dépendances sondées
+func (c *Checker) dependencyNames() []string {
+	names := make([]string, 0, len(c.dependencies))
+	for _, dep := range c.dependencies {
+		names = append(names, dep.Name)
+	}
+	return names
+}
+
+// Statuses retourne le résultat de la dernière sonde de chaque dépendance, triés par nom
+func (c *Checker) Statuses() []DependencyStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	statuses := make([]DependencyStatus, 0, len(c.statuses))
+	for _, status := range c.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,221 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"ndugu-backend/internal/common"
+)
+
+// fakeProbe sonde dont le résultat est piloté par le test
+type fakeProbe struct {
+	err atomic.Pointer[error]
+}
+
+func (p *fakeProbe) fail(err error) { p.err.Store(&err) }
+
+func (p *fakeProbe) recover() { p.err.Store(nil) }
+
+func (p *fakeProbe) probe(ctx context.Context) error {
+	if err := p.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// newTestChecker crée un Checker sur deux dépendances: AuthService requiert les deux,
+// CustomerService aucune
+func newTestChecker(ready bool) (*Checker, *health.Server, *fakeProbe, *fakeProbe) {
+	server := health.NewServer()
+	database, kratos := &fakeProbe{}, &fakeProbe{}
+	checker := NewChecker(server,
+		[]Dependency{{Name: "database", Probe: database.probe}, {Name: "kratos", Probe: kratos.probe}},
+		map[string][]string{"ndugu.v1.AuthService": {"database", "kratos"}, "ndugu.v1.CustomerService": nil},
+		time.Minute, time.Second, func() bool { return ready }, common.NewSimpleLogger())
+	return checker, server, database, kratos
+}
+
+// servingStatus retourne l'état publié par le service de santé gRPC
+func servingStatus(t *testing.T, server *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q) error = %v", service, err)
+	}
+	return resp.Status
+}
+
+func TestChecker_ServingStatus(t *testing.T) {
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	tests := []struct {
+		name         string
+		kratosErr    error
+		wantOverall  healthpb.HealthCheckResponse_ServingStatus
+		wantAuth     healthpb.HealthCheckResponse_ServingStatus
+		wantCustomer healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "all dependencies healthy", wantOverall: serving, wantAuth: serving, wantCustomer: serving},
+		{name: "kratos unavailable", kratosErr: errors.New("connection refused"), wantOverall: notServing, wantAuth: notServing, wantCustomer: serving},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			checker, server, _, kratos := newTestChecker(true)
+			if tt.kratosErr != nil {
+				kratos.fail(tt.kratosErr)
+			}
+
+			// Act
+			checker.Check(context.Background())
+
+			// Assert
+			if got := servingStatus(t, server, ""); got != tt.wantOverall {
+				t.Errorf("overall status = %v, want %v", got, tt.wantOverall)
+			}
+			if got := servingStatus(t, server, "ndugu.v1.AuthService"); got != tt.wantAuth {
+				t.Errorf("AuthService status = %v, want %v", got, tt.wantAuth)
+			}
+			if got := servingStatus(t, server, "ndugu.v1.CustomerService"); got != tt.wantCustomer {
+				t.Errorf("CustomerService status = %v, want %v", got, tt.wantCustomer)
+			}
+		})
+	}
+}
+
+func TestChecker_NotServingBeforeFirstCheck(t *testing.T) {
+	_, server, _, _ := newTestChecker(true)
+
+	if got := servingStatus(t, server, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("overall status = %v, want NOT_SERVING", got)
+	}
+}
+
+func TestChecker_RecoversAfterFailure(t *testing.T) {
+	checker, server, database, _ := newTestChecker(true)
+	database.fail(errors.New("timeout"))
+	checker.Check(context.Background())
+
+	database.recover()
+	checker.Check(context.Background())
+
+	if got := servingStatus(t, server, ""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("overall status = %v, want SERVING", got)
+	}
+}
+
+func TestChecker_Drain(t *testing.T) {
+	// Arrange
+	checker, server, _, _ := newTestChecker(true)
+	checker.Check(context.Background())
+
+	// Act
+	checker.Drain()
+	checker.Check(context.Background())
+
+	// Assert
+	for _, service := range []string{"", "ndugu.v1.AuthService", "ndugu.v1.CustomerService"} {
+		if got := servingStatus(t, server, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("status(%q) = %v, want NOT_SERVING", service, got)
+		}
+	}
+	if checker.Ready() {
+		t.Error("Ready() = true while draining")
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		started    bool
+		kratosErr  error
+		wantStatus int
+		wantReport string
+	}{
+		{name: "ready", started: true, wantStatus: http.StatusOK, wantReport: "SERVING"},
+		{name: "starting", started: false, wantStatus: http.StatusServiceUnavailable, wantReport: "NOT_SERVING"},
+		{name: "dependency down", started: true, kratosErr: errors.New("dial tcp 10.0.0.12:4434: connection refused"), wantStatus: http.StatusServiceUnavailable, wantReport: "NOT_SERVING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			checker, _, _, kratos := newTestChecker(tt.started)
+			if tt.kratosErr != nil {
+				kratos.fail(tt.kratosErr)
+			}
+			checker.Check(context.Background())
+
+			// Act
+			rec := httptest.NewRecorder()
+			checker.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			// Assert
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status code = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			body := rec.Body.String()
+			if strings.Contains(body, "10.0.0.12") {
+				t.Errorf("response exposes probe error: %s", body)
+			}
+			var report Report
+			if err := json.Unmarshal([]byte(body), &report); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if report.Status != tt.wantReport {
+				t.Errorf("report status = %q, want %q", report.Status, tt.wantReport)
+			}
+			if len(report.Dependencies) != 2 {
+				t.Errorf("got %d dependencies, want 2", len(report.Dependencies))
+			}
+		})
+	}
+}
+
+func TestLiveHandler_IgnoresDependencies(t *testing.T) {
+	checker, _, database, _ := newTestChecker(true)
+	database.fail(errors.New("connection refused"))
+	checker.Check(context.Background())
+
+	rec := httptest.NewRecorder()
+	checker.LiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status code = %d, want 200", rec.Code)
+	}
+}
+
+func TestHTTPProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := &http.Client{Timeout: time.Second}
+
+	if err := HTTPProbe(client, server.URL+"/health/ready")(context.Background()); err != nil {
+		t.Errorf("probe(ready) error = %v, want nil", err)
+	}
+	if err := HTTPProbe(client, server.URL+"/health/alive")(context.Background()); err == nil {
+		t.Error("probe(503) error = nil, want error")
+	}
+	if err := HTTPProbe(client, "http://127.0.0.1:1/health/ready")(context.Background()); err == nil {
+		t.Error("probe(unreachable) error = nil, want error")
+	}
+}
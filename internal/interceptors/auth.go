@@ -0,0 +1,230 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+)
+
+// Access niveau d'accès requis par une méthode gRPC
+type Access int
+
+const (
+	// AccessPublic la méthode est accessible sans authentification
+	AccessPublic Access = iota
+	// AccessAuthenticated la méthode requiert une session ou un jeton d'accès valide
+	AccessAuthenticated
+	// AccessRelation la méthode requiert en plus une relation Keto sur un objet
+	AccessRelation
+	// AccessOwnerOrRelation la méthode est réservée au propriétaire de la ressource demandée;
+	// les autres sujets doivent avoir la relation Keto
+	AccessOwnerOrRelation
+)
+
+// Policy décrit les conditions d'accès à une méthode gRPC
+type Policy struct {
+	Access Access
+
+	// Relation Keto exigée du sujet authentifié (AccessRelation et AccessOwnerOrRelation)
+	Namespace string
+	Object    string
+	Relation  string
+
+	// Owner extrait de la requête le sujet propriétaire de la ressource (AccessOwnerOrRelation uniquement).
+	// Il reçoit nil pour un appel en flux.
+	Owner func(req interface{}) string
+}
+
+// Public politique d'une méthode accessible sans authentification
+func Public() Policy {
+	return Policy{Access: AccessPublic}
+}
+
+// Authenticated politique d'une méthode réservée aux appelants authentifiés
+func Authenticated() Policy {
+	return Policy{Access: AccessAuthenticated}
+}
+
+// RequireRelation politique d'une méthode réservée aux sujets ayant la relation Keto donnée
+func RequireRelation(namespace, object, relation string) Policy {
+	return Policy{Access: AccessRelation, Namespace: namespace, Object: object, Relation: relation}
+}
+
+// RequireOwnerOrRelation politique d'une méthode réservée au propriétaire de la ressource,
+// dont owner extrait l'identifiant de la requête, et aux sujets ayant la relation Keto donnée
+func RequireOwnerOrRelation(owner func(req interface{}) string, namespace, object, relation string) Policy {
+	return Policy{Access: AccessOwnerOrRelation, Namespace: namespace, Object: object, Relation: relation, Owner: owner}
+}
+
+// Authenticator valide les identifiants présentés et vérifie les relations Keto.
+// services.AuthService implémente cette interface.
+type Authenticator interface {
+	ValidateSession(ctx context.Context, req *models.ValidateSessionRequest) (*models.ValidateSessionResponse, error)
+	IntrospectAccessToken(ctx context.Context, token string) (*models.TokenIntrospection, error)
+	CheckPermission(ctx context.Context, req *models.CheckPermissionRequest) (*models.PermissionResponse, error)
+}
+
+// AuthInterceptor applique la table de politiques aux appels gRPC.
+// Les méthodes absentes de la table sont refusées.
+type AuthInterceptor struct {
+	authenticator Authenticator
+	policies      map[string]Policy
+	logger        common.Logger
+}
+
+// NewAuthInterceptor crée un intercepteur d'authentification.
+// policies associe le nom complet d'une méthode ("/ndugu.v1.AuthService/CreateUser") à sa politique.
+func NewAuthInterceptor(authenticator Authenticator, policies map[string]Policy, logger common.Logger) *AuthInterceptor {
+	return &AuthInterceptor{
+		authenticator: authenticator,
+		policies:      policies,
+		logger:        logger,
+	}
+}
+
+// Unary retourne l'intercepteur pour les appels unaires
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream retourne l'intercepteur pour les appels en flux
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(stream.Context(), info.FullMethod, nil)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorize applique la politique de la méthode à la requête et retourne le contexte enrichi de l'identité
+func (i *AuthInterceptor) authorize(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	policy, ok := i.policies[method]
+	if !ok {
+		i.logger.WithContext(ctx).Warn("Méthode gRPC sans politique d'accès", "method", method)
+		return nil, status.Error(codes.PermissionDenied, "Accès refusé")
+	}
+	if policy.Access == AccessPublic {
+		return ctx, nil
+	}
+
+	identity, err := i.authenticate(ctx)
+	if err != nil {
+		i.logger.WithContext(ctx).Info("Appel gRPC non authentifié", "method", method)
+		return nil, err
+	}
+
+	// Le propriétaire de la ressource n'a pas besoin de la relation Keto
+	requireRelation := policy.Access == AccessRelation
+	if policy.Access == AccessOwnerOrRelation {
+		owner := policy.Owner(req)
+		requireRelation = owner == "" || owner != identity.Subject
+	}
+
+	if requireRelation {
+		resp, err := i.authenticator.CheckPermission(ctx, &models.CheckPermissionRequest{
+			Namespace: policy.Namespace,
+			Object:    policy.Object,
+			Relation:  policy.Relation,
+			Subject:   identity.Subject,
+		})
+		if err != nil {
+			i.logger.WithContext(ctx).Error("Erreur lors de la vérification de la politique d'accès", "method", method, "error", err)
+			return nil, status.Error(codes.Unavailable, "Vérification des permissions indisponible")
+		}
+		if !resp.HasPermission {
+			i.logger.WithContext(ctx).Warn("Accès refusé par la politique", "method", method, "subject", identity.Subject, "relation", policy.Relation)
+			return nil, status.Error(codes.PermissionDenied, "Accès refusé")
+		}
+	}
+
+	// L'utilisateur est ajouté aux champs de corrélation des logs du handler
+	ctx = common.WithLogFields(ctx, common.LogKeyUserID, identity.Subject)
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+// authenticate résout l'identité à partir des métadonnées gRPC:
+// x-session-token (session Kratos) ou authorization: Bearer (jeton d'accès Hydra)
+func (i *AuthInterceptor) authenticate(ctx context.Context) (*auth.Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if token := firstValue(md, "x-session-token"); token != "" {
+		session, err := i.authenticator.ValidateSession(ctx, &models.ValidateSessionRequest{SessionToken: token})
+		if err != nil {
+			i.logger.WithContext(ctx).Error("Erreur lors de la validation de la session", "error", err)
+			return nil, status.Error(codes.Unavailable, "Validation de la session indisponible")
+		}
+		if !session.Valid {
+			return nil, status.Error(codes.Unauthenticated, "Session invalide")
+		}
+		return &auth.Identity{
+			Subject:               session.UserID,
+			Email:                 session.Email,
+			Method:                auth.MethodSession,
+			AAL:                   session.AAL,
+			AuthenticationMethods: session.AuthenticationMethods,
+		}, nil
+	}
+
+	if token := bearerToken(firstValue(md, "authorization")); token != "" {
+		introspection, err := i.authenticator.IntrospectAccessToken(ctx, token)
+		if err != nil {
+			i.logger.WithContext(ctx).Error("Erreur lors de l'introspection du jeton d'accès", "error", err)
+			return nil, status.Error(codes.Unavailable, "Validation du jeton d'accès indisponible")
+		}
+		// Un jeton de rafraîchissement actif n'autorise pas l'accès à l'API
+		if !introspection.Active || introspection.TokenUse != models.TokenUseAccessToken {
+			return nil, status.Error(codes.Unauthenticated, "Jeton d'accès invalide")
+		}
+		return &auth.Identity{
+			Subject:  introspection.Subject,
+			Method:   auth.MethodAccessToken,
+			ClientID: introspection.ClientID,
+			Scopes:   introspection.Scopes,
+		}, nil
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "Authentification requise")
+}
+
+// firstValue retourne la première valeur d'une clé de métadonnées
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// bearerToken extrait le jeton d'un en-tête "Bearer <token>"
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+		return strings.TrimSpace(header[len(prefix):])
+	}
+	return ""
+}
+
+// contextStream remplace le contexte d'un flux par un contexte enrichi (identité, langue)
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
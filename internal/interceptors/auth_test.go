@@ -0,0 +1,146 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+)
+
+// fakeAuthenticator accepte la session "valid-session" (user-1, administrateur)
+// et le jeton d'accès "valid-access-token" (user-2); "refresh-token" est un jeton de rafraîchissement actif
+type fakeAuthenticator struct{}
+
+func (f *fakeAuthenticator) ValidateSession(ctx context.Context, req *models.ValidateSessionRequest) (*models.ValidateSessionResponse, error) {
+	if req.SessionToken == "kratos-down" {
+		return nil, common.NewAppError(common.ErrCodeKratosError, "Kratos indisponible")
+	}
+	if req.SessionToken != "valid-session" {
+		return &models.ValidateSessionResponse{Valid: false}, nil
+	}
+	return &models.ValidateSessionResponse{Valid: true, UserID: "user-1", Email: "admin@example.com", AAL: "aal1"}, nil
+}
+
+func (f *fakeAuthenticator) IntrospectAccessToken(ctx context.Context, token string) (*models.TokenIntrospection, error) {
+	switch token {
+	case "valid-access-token":
+		return &models.TokenIntrospection{Active: true, Subject: "user-2", ClientID: "client-1", Scopes: []string{"openid"}, TokenUse: models.TokenUseAccessToken}, nil
+	case "refresh-token":
+		return &models.TokenIntrospection{Active: true, Subject: "user-2", ClientID: "client-1", Scopes: []string{"openid", "offline"}, TokenUse: "refresh_token"}, nil
+	}
+	return &models.TokenIntrospection{Active: false}, nil
+}
+
+func (f *fakeAuthenticator) CheckPermission(ctx context.Context, req *models.CheckPermissionRequest) (*models.PermissionResponse, error) {
+	allowed := req.Namespace == "system" && req.Object == "coreapi" && req.Relation == "admin" && req.Subject == "user-1"
+	return &models.PermissionResponse{HasPermission: allowed}, nil
+}
+
+// ownedRequest requête portant l'identifiant de l'utilisateur propriétaire de la ressource
+type ownedRequest struct {
+	UserID string
+}
+
+func ownedRequestOwner(req interface{}) string {
+	if r, ok := req.(*ownedRequest); ok {
+		return r.UserID
+	}
+	return ""
+}
+
+func newTestAuthInterceptor() *AuthInterceptor {
+	return NewAuthInterceptor(&fakeAuthenticator{}, map[string]Policy{
+		"/test.Service/Public":        Public(),
+		"/test.Service/Authenticated": Authenticated(),
+		"/test.Service/Admin":         RequireRelation("system", "coreapi", "admin"),
+		"/test.Service/Owned":         RequireOwnerOrRelation(ownedRequestOwner, "system", "coreapi", "admin"),
+	}, common.NewSimpleLogger())
+}
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		md          metadata.MD
+		req         interface{}
+		wantCode    codes.Code
+		wantSubject string
+	}{
+		{name: "public without credentials", method: "/test.Service/Public", wantCode: codes.OK},
+		{name: "unknown method", method: "/test.Service/Unknown", md: metadata.Pairs("x-session-token", "valid-session"), wantCode: codes.PermissionDenied},
+		{name: "authenticated without credentials", method: "/test.Service/Authenticated", wantCode: codes.Unauthenticated},
+		{name: "session token", method: "/test.Service/Authenticated", md: metadata.Pairs("x-session-token", "valid-session"), wantCode: codes.OK, wantSubject: "user-1"},
+		{name: "invalid session", method: "/test.Service/Authenticated", md: metadata.Pairs("x-session-token", "expired"), wantCode: codes.Unauthenticated},
+		{name: "kratos unavailable", method: "/test.Service/Authenticated", md: metadata.Pairs("x-session-token", "kratos-down"), wantCode: codes.Unavailable},
+		{name: "bearer access token", method: "/test.Service/Authenticated", md: metadata.Pairs("authorization", "Bearer valid-access-token"), wantCode: codes.OK, wantSubject: "user-2"},
+		{name: "refresh token used as access token", method: "/test.Service/Authenticated", md: metadata.Pairs("authorization", "Bearer refresh-token"), wantCode: codes.Unauthenticated},
+		{name: "inactive access token", method: "/test.Service/Authenticated", md: metadata.Pairs("authorization", "Bearer revoked"), wantCode: codes.Unauthenticated},
+		{name: "admin relation", method: "/test.Service/Admin", md: metadata.Pairs("x-session-token", "valid-session"), wantCode: codes.OK, wantSubject: "user-1"},
+		{name: "missing relation", method: "/test.Service/Admin", md: metadata.Pairs("authorization", "Bearer valid-access-token"), wantCode: codes.PermissionDenied},
+		{name: "owner reads own record", method: "/test.Service/Owned", md: metadata.Pairs("authorization", "Bearer valid-access-token"), req: &ownedRequest{UserID: "user-2"}, wantCode: codes.OK, wantSubject: "user-2"},
+		{name: "user reads another user's record", method: "/test.Service/Owned", md: metadata.Pairs("authorization", "Bearer valid-access-token"), req: &ownedRequest{UserID: "user-1"}, wantCode: codes.PermissionDenied},
+		{name: "user reads record without owner", method: "/test.Service/Owned", md: metadata.Pairs("authorization", "Bearer valid-access-token"), req: &ownedRequest{}, wantCode: codes.PermissionDenied},
+		{name: "admin reads another user's record", method: "/test.Service/Owned", md: metadata.Pairs("x-session-token", "valid-session"), req: &ownedRequest{UserID: "user-2"}, wantCode: codes.OK, wantSubject: "user-1"},
+		{name: "owned record without credentials", method: "/test.Service/Owned", req: &ownedRequest{UserID: "user-2"}, wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var gotSubject string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if identity, ok := auth.IdentityFromContext(ctx); ok {
+					gotSubject = identity.Subject
+				}
+				return "ok", nil
+			}
+
+			// Act
+			_, err := newTestAuthInterceptor().Unary()(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			// Assert
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if gotSubject != tt.wantSubject {
+				t.Errorf("identity subject = %q, want %q", gotSubject, tt.wantSubject)
+			}
+		})
+	}
+}
+
+// fakeServerStream flux gRPC minimal portant un contexte
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-session-token", "valid-session"))
+	var identity *auth.Identity
+
+	err := newTestAuthInterceptor().Stream()(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Authenticated"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			identity, _ = auth.IdentityFromContext(stream.Context())
+			return nil
+		})
+
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if identity == nil || identity.Subject != "user-1" || identity.Method != auth.MethodSession {
+		t.Errorf("Stream() identity = %+v, want user-1 via session", identity)
+	}
+}
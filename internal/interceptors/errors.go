@@ -0,0 +1,223 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/common"
+)
+
+// ErrorDomain domaine des google.rpc.ErrorInfo renvoyés par l'API
+const ErrorDomain = "ndugu.v1"
+
+// grpcCodes associe chaque code d'erreur applicatif à un code gRPC
+var grpcCodes = map[common.ErrorCode]codes.Code{
+	common.ErrCodeInternal:     codes.Internal,
+	common.ErrCodeInvalidInput: codes.InvalidArgument,
+	common.ErrCodeNotFound:     codes.NotFound,
+	common.ErrCodeUnauthorized: codes.Unauthenticated,
+	common.ErrCodeForbidden:    codes.PermissionDenied,
+	common.ErrCodeConflict:     codes.AlreadyExists,
+
+	common.ErrCodeUserNotFound:   codes.NotFound,
+	common.ErrCodeUserExists:     codes.AlreadyExists,
+	common.ErrCodeInvalidSession: codes.Unauthenticated,
+	common.ErrCodeSessionExpired: codes.Unauthenticated,
+
+	common.ErrCodeCustomerNotFound: codes.NotFound,
+	common.ErrCodeCustomerExists:   codes.AlreadyExists,
+	common.ErrCodeCustomerInactive: codes.PermissionDenied,
+
+	common.ErrCodeInvalidCredentials: codes.Unauthenticated,
+
+	common.ErrCodeOAuth2ClientNotFound: codes.NotFound,
+	common.ErrCodeOAuth2ClientExists:   codes.AlreadyExists,
+
+	// Une requête en cours peut être rejouée plus tard; une clé réutilisée ne réussira jamais
+	common.ErrCodeIdempotencyKeyInUse:  codes.Aborted,
+	common.ErrCodeIdempotencyKeyReused: codes.FailedPrecondition,
+
+	// Les services Ory sont des dépendances: leurs échecs sont temporaires pour le client
+	common.ErrCodeKratosError: codes.Unavailable,
+	common.ErrCodeHydraError:  codes.Unavailable,
+	common.ErrCodeKetoError:   codes.Unavailable,
+}
+
+// GRPCCode retourne le code gRPC correspondant à un code d'erreur applicatif
+func GRPCCode(code common.ErrorCode) codes.Code {
+	if c, ok := grpcCodes[code]; ok {
+		return c
+	}
+	return codes.Internal
+}
+
+// ToStatus convertit une erreur en statut gRPC avec les messages de la langue par défaut
+func ToStatus(err error) *status.Status {
+	return ToLocalizedStatus(err, common.DefaultLanguage)
+}
+
+// ToLocalizedStatus convertit une erreur en statut gRPC avec les messages traduits dans lang.
+// Une AppError porte un google.rpc.ErrorInfo (reason = code applicatif) et, pour
+// les erreurs de validation, un google.rpc.BadRequest listant les champs invalides.
+// Les statuts gRPC existants sont conservés; toute autre erreur devient Internal
+// sans exposer son message.
+func ToLocalizedStatus(err error, lang string) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, common.Translate(lang, "request.canceled", nil))
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, common.Translate(lang, "request.deadline_exceeded", nil))
+	}
+
+	internalMessage := common.Translate(lang, string(common.ErrCodeInternal), nil)
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) {
+		return status.New(codes.Internal, internalMessage)
+	}
+	appErr = appErr.Localize(lang)
+
+	code := GRPCCode(appErr.Code)
+	message := appErr.Message
+	if code == codes.Internal {
+		message = internalMessage
+	}
+
+	st, detailErr := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: string(appErr.Code),
+		Domain: ErrorDomain,
+	})
+	if detailErr != nil {
+		return status.New(code, message)
+	}
+
+	if len(appErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range appErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+				Reason:      v.Rule,
+			})
+		}
+		if withViolations, err := st.WithDetails(badRequest); err == nil {
+			st = withViolations
+		}
+	}
+
+	return st
+}
+
+// statusErrorCodes code applicatif des statuts gRPC sans google.rpc.ErrorInfo
+// (refus de l'intercepteur d'authentification, requêtes rejetées par la passerelle REST)
+var statusErrorCodes = map[codes.Code]common.ErrorCode{
+	codes.InvalidArgument:  common.ErrCodeInvalidInput,
+	codes.NotFound:         common.ErrCodeNotFound,
+	codes.AlreadyExists:    common.ErrCodeConflict,
+	codes.Unauthenticated:  common.ErrCodeUnauthorized,
+	codes.PermissionDenied: common.ErrCodeForbidden,
+}
+
+// statusHTTPStatuses statut HTTP des statuts gRPC sans code applicatif équivalent
+var statusHTTPStatuses = map[codes.Code]int{
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Unimplemented:     http.StatusNotImplemented,
+}
+
+// FromStatus reconstruit l'AppError portée par un statut gRPC (inverse de ToLocalizedStatus):
+// code applicatif du google.rpc.ErrorInfo, violations du google.rpc.BadRequest et statut HTTP
+// du code applicatif. Un statut sans ErrorInfo reçoit le code applicatif le plus proche.
+func FromStatus(st *status.Status) *common.AppError {
+	code, ok := statusErrorCodes[st.Code()]
+	if !ok {
+		code = common.ErrCodeInternal
+	}
+	var reason string
+	var violations []common.FieldViolation
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.Domain == ErrorDomain {
+				reason = d.Reason
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				violations = append(violations, common.FieldViolation{Field: v.Field, Rule: v.Reason, Description: v.Description})
+			}
+		}
+	}
+
+	appErr := common.NewAppError(code, st.Message())
+	if reason != "" {
+		appErr = common.NewAppError(common.ErrorCode(reason), st.Message())
+	} else if httpStatus, ok := statusHTTPStatuses[st.Code()]; ok {
+		appErr.HTTPStatus = httpStatus
+	}
+	appErr.Violations = violations
+	return appErr
+}
+
+// ErrorInterceptor traduit uniformément les erreurs des handlers en statuts gRPC,
+// dans la langue demandée par la métadonnée accept-language
+type ErrorInterceptor struct {
+	logger common.Logger
+}
+
+// NewErrorInterceptor crée l'intercepteur de traduction des erreurs
+func NewErrorInterceptor(logger common.Logger) *ErrorInterceptor {
+	return &ErrorInterceptor{logger: logger}
+}
+
+// Unary retourne l'intercepteur pour les appels unaires
+func (i *ErrorInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = common.WithLanguage(ctx, requestLanguage(ctx))
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, i.translate(ctx, info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+// Stream retourne l'intercepteur pour les appels en flux
+func (i *ErrorInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := common.WithLanguage(stream.Context(), requestLanguage(stream.Context()))
+		if err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx}); err != nil {
+			return i.translate(ctx, info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+// translate convertit l'erreur et journalise celles dont le détail est masqué au client
+func (i *ErrorInterceptor) translate(ctx context.Context, method string, err error) error {
+	st := ToLocalizedStatus(err, common.LanguageFromContext(ctx))
+	if st.Code() == codes.Internal {
+		i.logger.WithContext(ctx).Error("Erreur interne lors d'un appel gRPC", "method", method, "error", err)
+	}
+	return st.Err()
+}
+
+// requestLanguage retourne la langue demandée par la métadonnée accept-language
+func requestLanguage(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return common.ParseAcceptLanguage(strings.Join(md.Get("accept-language"), ","))
+}
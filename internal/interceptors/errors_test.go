@@ -0,0 +1,171 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/common"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+		wantReason  string
+	}{
+		{name: "invalid input", err: common.NewFieldError("email", "required"), wantCode: codes.InvalidArgument, wantMessage: "Champ requis", wantReason: "INVALID_INPUT"},
+		{name: "user not found", err: common.ErrUserNotFound, wantCode: codes.NotFound, wantMessage: common.ErrUserNotFound.Message, wantReason: "USER_NOT_FOUND"},
+		{name: "customer exists", err: common.ErrCustomerExists, wantCode: codes.AlreadyExists, wantMessage: common.ErrCustomerExists.Message, wantReason: "CUSTOMER_EXISTS"},
+		{name: "invalid credentials", err: common.ErrInvalidCredentials, wantCode: codes.Unauthenticated, wantMessage: common.ErrInvalidCredentials.Message, wantReason: "INVALID_CREDENTIALS"},
+		{name: "inactive customer", err: common.ErrCustomerInactive, wantCode: codes.PermissionDenied, wantMessage: common.ErrCustomerInactive.Message, wantReason: "CUSTOMER_INACTIVE"},
+		{name: "keto unavailable", err: common.NewAppError(common.ErrCodeKetoError, "Keto indisponible"), wantCode: codes.Unavailable, wantMessage: "Keto indisponible", wantReason: "KETO_ERROR"},
+		{name: "wrapped app error", err: fmt.Errorf("création: %w", common.ErrUserExists), wantCode: codes.AlreadyExists, wantMessage: common.ErrUserExists.Message, wantReason: "USER_EXISTS"},
+		{name: "internal app error hides message", err: common.NewAppError(common.ErrCodeInternal, "pq: connection refused"), wantCode: codes.Internal, wantMessage: "Erreur interne du serveur", wantReason: "INTERNAL_ERROR"},
+		{name: "plain error", err: errors.New("pq: connection refused"), wantCode: codes.Internal, wantMessage: "Erreur interne du serveur"},
+		{name: "existing status", err: status.Error(codes.Unauthenticated, "Session invalide"), wantCode: codes.Unauthenticated, wantMessage: "Session invalide"},
+		{name: "deadline exceeded", err: fmt.Errorf("keto: %w", context.DeadlineExceeded), wantCode: codes.DeadlineExceeded, wantMessage: "Délai de la requête dépassé"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			st := ToStatus(tt.err)
+
+			// Assert
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Fatalf("ToStatus() = %v %q, want %v %q", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+			var reason string
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					reason = info.Reason
+					if info.Domain != ErrorDomain {
+						t.Errorf("ErrorInfo.Domain = %q, want %q", info.Domain, ErrorDomain)
+					}
+				}
+			}
+			if reason != tt.wantReason {
+				t.Errorf("ErrorInfo.Reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestToStatus_FieldViolations(t *testing.T) {
+	err := common.NewValidationError(
+		common.FieldViolation{Field: "namespace", Description: "Le namespace est requis"},
+		common.FieldViolation{Field: "subject", Description: "Le sujet est requis"},
+	)
+
+	st := ToStatus(err)
+
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = br
+		}
+	}
+	if badRequest == nil || len(badRequest.FieldViolations) != 2 {
+		t.Fatalf("BadRequest = %v, want 2 field violations", badRequest)
+	}
+	if got := badRequest.FieldViolations[1]; got.Field != "subject" || got.Description != "Le sujet est requis" {
+		t.Errorf("FieldViolations[1] = %v, want subject violation", got)
+	}
+}
+
+func TestFromStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		st             *status.Status
+		wantCode       common.ErrorCode
+		wantHTTPStatus int
+	}{
+		{name: "application error", st: ToStatus(common.ErrCustomerNotFound), wantCode: common.ErrCodeCustomerNotFound, wantHTTPStatus: http.StatusNotFound},
+		{name: "ory error", st: ToStatus(common.NewAppError(common.ErrCodeKratosError, "Kratos indisponible")), wantCode: common.ErrCodeKratosError, wantHTTPStatus: http.StatusInternalServerError},
+		{name: "authentication required", st: status.New(codes.Unauthenticated, "Authentification requise"), wantCode: common.ErrCodeUnauthorized, wantHTTPStatus: http.StatusUnauthorized},
+		{name: "access denied", st: status.New(codes.PermissionDenied, "Accès refusé"), wantCode: common.ErrCodeForbidden, wantHTTPStatus: http.StatusForbidden},
+		{name: "dependency unavailable", st: status.New(codes.Unavailable, "Validation de la session indisponible"), wantCode: common.ErrCodeInternal, wantHTTPStatus: http.StatusServiceUnavailable},
+		{name: "plain internal", st: ToStatus(errors.New("pq: connection refused")), wantCode: common.ErrCodeInternal, wantHTTPStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := FromStatus(tt.st)
+
+			if appErr.Code != tt.wantCode || appErr.HTTPStatus != tt.wantHTTPStatus {
+				t.Errorf("FromStatus() = %s %d, want %s %d", appErr.Code, appErr.HTTPStatus, tt.wantCode, tt.wantHTTPStatus)
+			}
+			if appErr.Message != tt.st.Message() {
+				t.Errorf("message = %q, want %q", appErr.Message, tt.st.Message())
+			}
+		})
+	}
+}
+
+func TestFromStatus_FieldViolations(t *testing.T) {
+	err := common.NewValidationError(
+		common.NewViolation("phoneCode", "phoneCode", ""),
+		common.NewViolation("password", "required", ""),
+	)
+
+	appErr := FromStatus(ToStatus(err))
+
+	if appErr.Code != common.ErrCodeInvalidInput || appErr.HTTPStatus != http.StatusBadRequest {
+		t.Fatalf("FromStatus() = %s %d, want INVALID_INPUT 400", appErr.Code, appErr.HTTPStatus)
+	}
+	if len(appErr.Violations) != 2 || appErr.Violations[1].Field != "password" || appErr.Violations[1].Rule != "required" {
+		t.Errorf("violations = %+v, want phoneCode and password", appErr.Violations)
+	}
+}
+
+func TestErrorInterceptor_Unary(t *testing.T) {
+	interceptor := NewErrorInterceptor(common.NewSimpleLogger())
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, common.ErrOAuth2ClientNotFound
+	}
+
+	_, err := interceptor.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}, handler)
+
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("code = %v, want %v (%v)", code, codes.NotFound, err)
+	}
+}
+
+func TestErrorInterceptor_AcceptLanguage(t *testing.T) {
+	interceptor := NewErrorInterceptor(common.NewSimpleLogger())
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "en-US,en;q=0.9"))
+	var handlerLang string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerLang = common.LanguageFromContext(ctx)
+		return nil, common.NewValidationError(common.NewViolation("namespace", "ketoNamespace", ""))
+	}
+
+	_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Check"}, handler)
+
+	st := status.Convert(err)
+	if handlerLang != common.LangEN {
+		t.Errorf("handler language = %q, want %q", handlerLang, common.LangEN)
+	}
+	if st.Message() != "Unknown namespace" {
+		t.Errorf("message = %q, want english translation", st.Message())
+	}
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok && br.FieldViolations[0].Description != "Unknown namespace" {
+			t.Errorf("field violation = %q, want english translation", br.FieldViolations[0].Description)
+		}
+	}
+}
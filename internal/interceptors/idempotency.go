@@ -0,0 +1,186 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+)
+
+const (
+	// IdempotencyKeyHeader métadonnée gRPC portant la clé d'idempotence
+	// (en-tête HTTP Idempotency-Key via la passerelle REST)
+	IdempotencyKeyHeader = "idempotency-key"
+	// IdempotentReplayedHeader métadonnée de réponse présente lorsque la réponse est rejouée
+	IdempotentReplayedHeader = "idempotent-replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	// idempotencyStoreTimeout échéance de la mémorisation de la réponse, indépendante de celle de l'appel
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// IdempotencyStore mémorise les réponses des requêtes portant une clé d'idempotence.
+// repository.IdempotencyRepository implémente cette interface.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, key string, response []byte, expiresAt time.Time) error
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyInterceptor rejoue la réponse mémorisée d'une requête déjà traitée avec la même
+// clé d'idempotence. La clé est propre à la méthode et au sujet authentifié; elle est réservée
+// pendant le traitement (lockTimeout au plus) puis la réponse est conservée pendant ttl.
+// Les erreurs ne sont pas mémorisées: la requête peut être renvoyée avec la même clé.
+// Placé après l'intercepteur d'authentification, pour connaître le sujet.
+type IdempotencyInterceptor struct {
+	store       IdempotencyStore
+	methods     map[string]bool
+	ttl         time.Duration
+	lockTimeout time.Duration
+	logger      common.Logger
+	now         func() time.Time
+}
+
+// NewIdempotencyInterceptor crée l'intercepteur d'idempotence.
+// methods liste les méthodes concernées ("/ndugu.v1.AuthService/CreateUser"); la clé est ignorée pour les autres.
+func NewIdempotencyInterceptor(store IdempotencyStore, methods map[string]bool, ttl, lockTimeout time.Duration, logger common.Logger) *IdempotencyInterceptor {
+	return &IdempotencyInterceptor{
+		store:       store,
+		methods:     methods,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Unary retourne l'intercepteur pour les appels unaires
+func (i *IdempotencyInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := firstValue(md, IdempotencyKeyHeader)
+		message, ok := req.(proto.Message)
+		if key == "" || !ok || !i.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return nil, common.NewFieldError(IdempotencyKeyHeader, "max", strconv.Itoa(maxIdempotencyKeyLength))
+		}
+
+		requestHash, err := hashRequest(message)
+		if err != nil {
+			return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors du calcul de l'empreinte de la requête", err.Error())
+		}
+		now := i.now()
+		record := &models.IdempotencyRecord{
+			Key:         scopedIdempotencyKey(ctx, info.FullMethod, key),
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(i.lockTimeout),
+		}
+
+		existing, reserved, err := i.store.Reserve(ctx, record)
+		if err != nil {
+			i.logger.WithContext(ctx).Error("Erreur lors de la réservation de la clé d'idempotence", "method", info.FullMethod, "error", err)
+			return nil, err
+		}
+		if !reserved {
+			return i.replay(ctx, info.FullMethod, existing, requestHash)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// La réservation est libérée ou complétée même si l'appelant a abandonné la requête
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyStoreTimeout)
+		defer cancel()
+		if err != nil {
+			if releaseErr := i.store.Release(storeCtx, record.Key); releaseErr != nil {
+				i.logger.WithContext(ctx).Error("Erreur lors de la libération de la clé d'idempotence", "method", info.FullMethod, "error", releaseErr)
+			}
+			return resp, err
+		}
+
+		response, encodeErr := encodeResponse(resp)
+		if encodeErr == nil {
+			encodeErr = i.store.Complete(storeCtx, record.Key, response, i.now().Add(i.ttl))
+		}
+		if encodeErr != nil {
+			// La réponse est tout de même renvoyée; la clé reste réservée jusqu'à lockTimeout
+			i.logger.WithContext(ctx).Error("Erreur lors de la mémorisation de la réponse idempotente", "method", info.FullMethod, "error", encodeErr)
+		}
+		return resp, nil
+	}
+}
+
+// replay renvoie la réponse mémorisée d'une requête identique, ou refuse la requête
+func (i *IdempotencyInterceptor) replay(ctx context.Context, method string, existing *models.IdempotencyRecord, requestHash string) (interface{}, error) {
+	if existing.RequestHash != requestHash {
+		i.logger.WithContext(ctx).Warn("Clé d'idempotence réutilisée pour une requête différente", "method", method)
+		return nil, common.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, common.ErrIdempotencyKeyInUse
+	}
+
+	resp, err := decodeResponse(existing.Response)
+	if err != nil {
+		return nil, common.NewAppError(common.ErrCodeInternal, "Réponse idempotente illisible", err.Error())
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedHeader, "true"))
+	i.logger.WithContext(ctx).Info("Réponse idempotente rejouée", "method", method)
+	return resp, nil
+}
+
+// scopedIdempotencyKey empreinte de la clé, de la méthode et du sujet authentifié:
+// la même clé envoyée par deux appelants ou à deux méthodes désigne deux requêtes distinctes
+func scopedIdempotencyKey(ctx context.Context, method, key string) string {
+	subject := ""
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		subject = identity.Subject
+	}
+	sum := sha256.Sum256([]byte(method + "\x00" + subject + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashRequest empreinte du contenu de la requête
+func hashRequest(message proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// encodeResponse sérialise une réponse avec son type, pour la décoder sans connaître la méthode
+func encodeResponse(resp interface{}) ([]byte, error) {
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return nil, common.NewAppError(common.ErrCodeInternal, "Réponse gRPC non sérialisable")
+	}
+	wrapped, err := anypb.New(message)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(wrapped)
+}
+
+// decodeResponse décode une réponse sérialisée par encodeResponse
+func decodeResponse(data []byte) (proto.Message, error) {
+	wrapped := &anypb.Any{}
+	if err := proto.Unmarshal(data, wrapped); err != nil {
+		return nil, err
+	}
+	return wrapped.UnmarshalNew()
+}
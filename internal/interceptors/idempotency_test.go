@@ -0,0 +1,136 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/repository"
+)
+
+// idempotencyCall appel de la méthode de test avec une clé, un sujet et un contenu
+type idempotencyCall struct {
+	method  string
+	key     string
+	subject string
+	payload string
+}
+
+func (c idempotencyCall) context() context.Context {
+	ctx := context.Background()
+	if c.key != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, c.key))
+	}
+	if c.subject != "" {
+		ctx = auth.WithIdentity(ctx, &auth.Identity{Subject: c.subject})
+	}
+	return ctx
+}
+
+func TestIdempotencyInterceptor_Unary(t *testing.T) {
+	create := idempotencyCall{method: "/test.Service/Create", key: "key-1", subject: "user-1", payload: "john"}
+	withPayload := func(c idempotencyCall, payload string) idempotencyCall { c.payload = payload; return c }
+	withSubject := func(c idempotencyCall, subject string) idempotencyCall { c.subject = subject; return c }
+	withKey := func(c idempotencyCall, key string) idempotencyCall { c.key = key; return c }
+	withMethod := func(c idempotencyCall, method string) idempotencyCall { c.method = method; return c }
+
+	tests := []struct {
+		name         string
+		first        idempotencyCall
+		failFirst    bool
+		second       idempotencyCall
+		wantHandled  int
+		wantResponse int64
+		wantCode     common.ErrorCode
+	}{
+		{name: "identical retry is replayed", first: create, second: create, wantHandled: 1, wantResponse: 1},
+		{name: "different payload is rejected", first: create, second: withPayload(create, "jane"), wantHandled: 1, wantCode: common.ErrCodeIdempotencyKeyReused},
+		{name: "failed request is not stored", first: create, failFirst: true, second: create, wantHandled: 2, wantResponse: 2},
+		{name: "keys are scoped by subject", first: create, second: withSubject(create, "user-2"), wantHandled: 2, wantResponse: 2},
+		{name: "keys are scoped by method", first: create, second: withMethod(create, "/test.Service/Update"), wantHandled: 2, wantResponse: 2},
+		{name: "without key", first: withKey(create, ""), second: withKey(create, ""), wantHandled: 2, wantResponse: 2},
+		{name: "method without idempotency", first: withMethod(create, "/test.Service/Get"), second: withMethod(create, "/test.Service/Get"), wantHandled: 2, wantResponse: 2},
+		{name: "key too long", first: withKey(create, ""), second: withKey(create, strings.Repeat("k", 256)), wantHandled: 1, wantCode: common.ErrCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			interceptor := NewIdempotencyInterceptor(repository.NewMemoryIdempotencyRepository(),
+				map[string]bool{"/test.Service/Create": true, "/test.Service/Update": true},
+				time.Hour, time.Minute, common.NewSimpleLogger())
+			handled := 0
+			fail := tt.failFirst
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled++
+				if fail {
+					fail = false
+					return nil, common.ErrUserExists
+				}
+				return wrapperspb.Int64(int64(handled)), nil
+			}
+			call := func(c idempotencyCall) (interface{}, error) {
+				return interceptor.Unary()(c.context(), wrapperspb.String(c.payload), &grpc.UnaryServerInfo{FullMethod: c.method}, handler)
+			}
+			call(tt.first)
+
+			// Act
+			resp, err := call(tt.second)
+
+			// Assert
+			if handled != tt.wantHandled {
+				t.Errorf("handler called %d times, want %d", handled, tt.wantHandled)
+			}
+			if tt.wantCode != "" {
+				var appErr *common.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !proto.Equal(resp.(proto.Message), wrapperspb.Int64(tt.wantResponse)) {
+				t.Errorf("response = %v, want %d", resp, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestIdempotencyInterceptor_RequestInProgress(t *testing.T) {
+	// Arrange: une première requête a réservé la clé sans encore répondre
+	store := repository.NewMemoryIdempotencyRepository()
+	interceptor := NewIdempotencyInterceptor(store, map[string]bool{"/test.Service/Create": true}, time.Hour, time.Minute, common.NewSimpleLogger())
+	call := idempotencyCall{method: "/test.Service/Create", key: "key-1", payload: "john"}
+	hash, _ := hashRequest(wrapperspb.String(call.payload))
+	now := time.Now()
+	store.Reserve(context.Background(), &models.IdempotencyRecord{
+		Key:         scopedIdempotencyKey(call.context(), call.method, call.key),
+		RequestHash: hash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Minute),
+	})
+
+	// Act
+	_, err := interceptor.Unary()(call.context(), wrapperspb.String(call.payload), &grpc.UnaryServerInfo{FullMethod: call.method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Error("handler called while the key is reserved")
+			return nil, nil
+		})
+
+	// Assert
+	if !errors.Is(err, common.ErrIdempotencyKeyInUse) {
+		t.Errorf("error = %v, want %v", err, common.ErrIdempotencyKeyInUse)
+	}
+}
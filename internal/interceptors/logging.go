@@ -0,0 +1,90 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/common"
+)
+
+// healthServicePrefix préfixe des méthodes du service de santé standard
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// LoggingInterceptor corrèle les appels gRPC: il attribue un identifiant de requête
+// (repris de x-request-id, par exemple posé par APISIX, ou généré), le place avec la
+// méthode dans le contexte des logs, le renvoie en en-tête et journalise la fin de l'appel
+type LoggingInterceptor struct {
+	logger common.Logger
+}
+
+// NewLoggingInterceptor crée l'intercepteur de corrélation et de journalisation des appels
+func NewLoggingInterceptor(logger common.Logger) *LoggingInterceptor {
+	return &LoggingInterceptor{logger: logger}
+}
+
+// Unary retourne l'intercepteur pour les appels unaires
+func (i *LoggingInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = i.begin(ctx, info.FullMethod)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		i.end(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// Stream retourne l'intercepteur pour les appels en flux
+func (i *LoggingInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := i.begin(stream.Context(), info.FullMethod)
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		i.end(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// begin enrichit le contexte de l'identifiant de requête, de la méthode et de la trace en cours
+func (i *LoggingInterceptor) begin(ctx context.Context, method string) context.Context {
+	requestID := incomingRequestID(ctx)
+	ctx = common.WithLogFields(ctx, common.LogKeyRequestID, requestID, common.LogKeyMethod, method)
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		ctx = common.WithLogFields(ctx, common.LogKeyTraceID, spanContext.TraceID().String())
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(common.RequestIDHeader, requestID)); err != nil {
+		i.logger.WithContext(ctx).Debug("Impossible de renvoyer l'identifiant de requête", "error", err)
+	}
+	return ctx
+}
+
+// end journalise la fin de l'appel; les erreurs serveur sont journalisées au niveau erreur,
+// les sondes de santé réussies au niveau debug (elles sont appelées en continu)
+func (i *LoggingInterceptor) end(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	logger := i.logger.WithContext(ctx)
+	switch {
+	case code == codes.Internal, code == codes.Unknown, code == codes.DataLoss, code == codes.Unavailable:
+		logger.Error("Appel gRPC terminé", "code", code.String(), "duration", time.Since(start), "error", err)
+	case code == codes.OK && strings.HasPrefix(method, healthServicePrefix):
+		logger.Debug("Appel gRPC terminé", "code", code.String(), "duration", time.Since(start))
+	default:
+		logger.Info("Appel gRPC terminé", "code", code.String(), "duration", time.Since(start))
+	}
+}
+
+// incomingRequestID reprend l'identifiant fourni par le client s'il est valide, sinon en génère un
+func incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var requestID string
+	if values := md.Get(common.RequestIDHeader); len(values) > 0 {
+		requestID = values[0]
+	}
+	return common.RequestIDOrNew(requestID)
+}
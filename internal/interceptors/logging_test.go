@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"ndugu-backend/internal/common"
+)
+
+func TestLoggingInterceptor_RequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		wantID string
+	}{
+		{name: "identifier from the gateway", md: metadata.Pairs(common.RequestIDHeader, "apisix-42"), wantID: "apisix-42"},
+		{name: "generated identifier", md: metadata.MD{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var fields map[string]interface{}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				fields = common.LogFieldsFromContext(ctx)
+				return "ok", nil
+			}
+
+			// Act
+			_, err := NewLoggingInterceptor(common.NewSimpleLogger()).Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}, handler)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unary() error = %v", err)
+			}
+			requestID, _ := fields[common.LogKeyRequestID].(string)
+			if requestID == "" || (tt.wantID != "" && requestID != tt.wantID) {
+				t.Errorf("request_id = %q, want %q", requestID, tt.wantID)
+			}
+			if fields[common.LogKeyMethod] != "/test.Service/Get" {
+				t.Errorf("method = %v, want /test.Service/Get", fields[common.LogKeyMethod])
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_AddsUserToLogFields(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-session-token", "valid-session"))
+	var userID interface{}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		userID = common.LogFieldsFromContext(ctx)[common.LogKeyUserID]
+		return "ok", nil
+	}
+
+	if _, err := newTestAuthInterceptor().Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Authenticated"}, handler); err != nil {
+		t.Fatalf("Unary() error = %v", err)
+	}
+
+	if userID != "user-1" {
+		t.Errorf("user_id = %v, want user-1", userID)
+	}
+}
@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/metrics"
+)
+
+// MetricsInterceptor compte les appels gRPC et mesure leur durée par méthode et code de statut.
+// Placé avant l'intercepteur d'erreurs, il observe le code final renvoyé au client.
+type MetricsInterceptor struct {
+	metrics *metrics.Metrics
+}
+
+// NewMetricsInterceptor crée l'intercepteur de métriques
+func NewMetricsInterceptor(m *metrics.Metrics) *MetricsInterceptor {
+	return &MetricsInterceptor{metrics: m}
+}
+
+// Unary retourne l'intercepteur pour les appels unaires
+func (i *MetricsInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		i.metrics.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// Stream retourne l'intercepteur pour les appels en flux
+func (i *MetricsInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		i.metrics.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,122 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCServer composant servant un serveur gRPC sur l'adresse donnée.
+// L'arrêt attend la fin des RPC en cours (GracefulStop) puis force l'arrêt à l'échéance.
+func GRPCServer(server *grpc.Server, addr string) Component {
+	return &grpcServer{server: server, addr: addr}
+}
+
+type grpcServer struct {
+	server *grpc.Server
+	addr   string
+}
+
+func (s *grpcServer) Start(ctx context.Context, fatal func(error)) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			fatal(err)
+		}
+	}()
+	return nil
+}
+
+func (s *grpcServer) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		// Échéance atteinte: les RPC restantes sont interrompues
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// HTTPServer composant servant un serveur HTTP.
+// L'arrêt refuse les nouvelles connexions et attend la fin des requêtes en cours.
+func HTTPServer(server *http.Server) Component {
+	return &httpServer{server: server}
+}
+
+type httpServer struct {
+	server *http.Server
+}
+
+func (s *httpServer) Start(ctx context.Context, fatal func(error)) error {
+	lis, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(err)
+		}
+	}()
+	return nil
+}
+
+func (s *httpServer) Stop(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.server.Close()
+		return err
+	}
+	return nil
+}
+
+// Worker composant exécutant une tâche de fond jusqu'à l'arrêt.
+// run doit se terminer lorsque son contexte est annulé; une erreur avant l'arrêt déclenche l'arrêt du service.
+func Worker(run func(ctx context.Context) error) Component {
+	return &worker{run: run}
+}
+
+type worker struct {
+	run    func(ctx context.Context) error
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (w *worker) Start(ctx context.Context, fatal func(error)) error {
+	// Le worker n'hérite pas de l'annulation du signal: il est arrêté par Stop, dans l'ordre
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+		if err := w.run(runCtx); err != nil && runCtx.Err() == nil {
+			fatal(err)
+		}
+	}()
+	return nil
+}
+
+func (w *worker) Stop(ctx context.Context) error {
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,163 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ndugu-backend/internal/common"
+)
+
+// Component composant démarré et arrêté par le Manager
+type Component interface {
+	// Start démarre le composant sans bloquer. Une erreur survenant ensuite
+	// (arrêt inattendu d'un serveur) est signalée via fatal et déclenche l'arrêt.
+	Start(ctx context.Context, fatal func(error)) error
+	// Stop arrête le composant avant l'échéance du contexte
+	Stop(ctx context.Context) error
+}
+
+// namedComponent associe un composant à son nom pour les logs
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+// Manager démarre les composants dans l'ordre d'enregistrement et les arrête
+// dans l'ordre inverse, avec une échéance globale d'arrêt
+type Manager struct {
+	components      []namedComponent
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	logger          common.Logger
+
+	ready      atomic.Bool
+	failed     chan error
+	once       sync.Once
+	drainHooks []func()
+}
+
+// NewManager crée un gestionnaire de cycle de vie.
+// drainDelay laisse aux répartiteurs de charge le temps de constater la fin de
+// disponibilité (Ready) avant l'arrêt des composants.
+func NewManager(shutdownTimeout, drainDelay time.Duration, logger common.Logger) *Manager {
+	return &Manager{
+		shutdownTimeout: shutdownTimeout,
+		drainDelay:      drainDelay,
+		logger:          logger,
+		failed:          make(chan error, 1),
+	}
+}
+
+// Add enregistre un composant; l'ordre d'enregistrement est l'ordre de démarrage
+func (m *Manager) Add(name string, component Component) {
+	m.components = append(m.components, namedComponent{name: name, component: component})
+}
+
+// OnDrain enregistre une fonction appelée au début de l'arrêt, avant l'échéance de retrait
+// (ex: passer le service de santé gRPC à NOT_SERVING)
+func (m *Manager) OnDrain(hook func()) {
+	m.drainHooks = append(m.drainHooks, hook)
+}
+
+// Ready indique si tous les composants sont démarrés et que l'arrêt n'a pas commencé
+func (m *Manager) Ready() bool {
+	return m.ready.Load()
+}
+
+// Run démarre les composants puis bloque jusqu'à l'annulation de ctx (signal d'arrêt)
+// ou la défaillance d'un composant, et arrête ensuite tous les composants démarrés.
+// L'erreur retournée est celle du démarrage ou de la défaillance, jointe aux erreurs d'arrêt.
+func (m *Manager) Run(ctx context.Context) error {
+	started, err := m.start(ctx)
+	if err == nil {
+		m.ready.Store(true)
+		m.logger.Info("Tous les composants sont démarrés", "count", len(started))
+
+		select {
+		case <-ctx.Done():
+			m.logger.Info("Signal d'arrêt reçu")
+		case err = <-m.failed:
+			m.logger.Error("Défaillance d'un composant, arrêt du service", "error", err)
+		}
+	}
+
+	m.ready.Store(false)
+	for _, hook := range m.drainHooks {
+		hook()
+	}
+	if len(started) > 0 && m.drainDelay > 0 {
+		m.logger.Info("Retrait du service avant arrêt", "delay", m.drainDelay)
+		time.Sleep(m.drainDelay)
+	}
+	return errors.Join(err, m.stop(started))
+}
+
+// start démarre les composants dans l'ordre et s'interrompt au premier échec
+func (m *Manager) start(ctx context.Context) ([]namedComponent, error) {
+	started := make([]namedComponent, 0, len(m.components))
+	for _, c := range m.components {
+		name := c.name
+		fatal := func(err error) {
+			m.once.Do(func() {
+				m.failed <- fmt.Errorf("%s: %w", name, err)
+			})
+		}
+
+		if err := c.component.Start(ctx, fatal); err != nil {
+			m.logger.Error("Erreur lors du démarrage du composant", "component", name, "error", err)
+			return started, fmt.Errorf("démarrage de %s: %w", name, err)
+		}
+		m.logger.Info("Composant démarré", "component", name)
+		started = append(started, c)
+	}
+	return started, nil
+}
+
+// stop arrête les composants démarrés dans l'ordre inverse avant l'échéance d'arrêt
+func (m *Manager) stop(started []namedComponent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		if err := c.component.Stop(ctx); err != nil {
+			m.logger.Error("Erreur lors de l'arrêt du composant", "component", c.name, "error", err)
+			errs = append(errs, fmt.Errorf("arrêt de %s: %w", c.name, err))
+			continue
+		}
+		m.logger.Info("Composant arrêté", "component", c.name)
+	}
+	return errors.Join(errs...)
+}
+
+// Func composant défini par deux fonctions; nil est accepté pour l'une ou l'autre
+type Func struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Start exécute OnStart
+func (f Func) Start(ctx context.Context, fatal func(error)) error {
+	if f.OnStart == nil {
+		return nil
+	}
+	return f.OnStart(ctx)
+}
+
+// Stop exécute OnStop
+func (f Func) Stop(ctx context.Context) error {
+	if f.OnStop == nil {
+		return nil
+	}
+	return f.OnStop(ctx)
+}
+
+// Closer composant qui libère une ressource (base de données, clients HTTP) à l'arrêt
+func Closer(close func() error) Component {
+	return Func{OnStop: func(ctx context.Context) error { return close() }}
+}
@@ -0,0 +1,155 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/common"
+)
+
+// recorder enregistre l'ordre des démarrages et des arrêts
+type recorder struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) component(name string, startErr error) Component {
+	return Func{
+		OnStart: func(ctx context.Context) error {
+			r.record("start " + name)
+			return startErr
+		},
+		OnStop: func(ctx context.Context) error {
+			r.record("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestManager_StartsInOrderAndStopsInReverse(t *testing.T) {
+	// Arrange
+	rec := &recorder{}
+	manager := NewManager(time.Second, 0, common.NewSimpleLogger())
+	manager.Add("database", rec.component("database", nil))
+	manager.Add("grpc", rec.component("grpc", nil))
+	manager.Add("http", rec.component("http", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	// Act
+	go func() { done <- manager.Run(ctx) }()
+	waitFor(t, manager.Ready)
+	cancel()
+
+	// Assert
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{"start database", "start grpc", "start http", "stop http", "stop grpc", "stop database"}
+	if !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+	if manager.Ready() {
+		t.Error("Ready() = true after shutdown")
+	}
+}
+
+func TestManager_StartFailureStopsStartedComponents(t *testing.T) {
+	rec := &recorder{}
+	manager := NewManager(time.Second, 0, common.NewSimpleLogger())
+	manager.Add("database", rec.component("database", nil))
+	manager.Add("grpc", rec.component("grpc", errors.New("address already in use")))
+	manager.Add("http", rec.component("http", nil))
+
+	err := manager.Run(context.Background())
+
+	if err == nil {
+		t.Fatal("Run() error = nil, want startup error")
+	}
+	want := []string{"start database", "start grpc", "stop database"}
+	if !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestManager_WorkerFailureTriggersShutdown(t *testing.T) {
+	rec := &recorder{}
+	manager := NewManager(time.Second, 0, common.NewSimpleLogger())
+	manager.Add("database", rec.component("database", nil))
+	manager.Add("worker", Worker(func(ctx context.Context) error {
+		return errors.New("reconciliation failed")
+	}))
+
+	err := manager.Run(context.Background())
+
+	if err == nil || err.Error() != "worker: reconciliation failed" {
+		t.Errorf("Run() error = %v, want worker failure", err)
+	}
+	if want := []string{"start database", "stop database"}; !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestManager_DrainHooksRunBeforeStop(t *testing.T) {
+	rec := &recorder{}
+	manager := NewManager(time.Second, 0, common.NewSimpleLogger())
+	manager.Add("grpc", rec.component("grpc", nil))
+	manager.OnDrain(func() { rec.record("drain") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+	waitFor(t, manager.Ready)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := []string{"start grpc", "drain", "stop grpc"}; !reflect.DeepEqual(rec.events, want) {
+		t.Errorf("events = %v, want %v", rec.events, want)
+	}
+}
+
+func TestWorker_StopWaitsForRunToReturn(t *testing.T) {
+	stopped := make(chan struct{})
+	w := Worker(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+		return ctx.Err()
+	})
+
+	if err := w.Start(context.Background(), func(err error) { t.Errorf("fatal(%v)", err) }); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := w.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	select {
+	case <-stopped:
+	default:
+		t.Error("Stop() returned before the worker exited")
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,172 @@
+// Package metrics expose les métriques Prometheus du service: appels gRPC,
+// appels sortants vers les services Ory, opérations des repositories, caches des sessions
+// et des décisions de permission.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"ndugu-backend/internal/common"
+)
+
+// namespace préfixe de toutes les métriques du service
+const namespace = "ndugu"
+
+// Résultats d'une opération de repository. Une ressource absente ou déjà
+// existante est un résultat attendu, distingué des erreurs.
+const (
+	OutcomeSuccess  = "success"
+	OutcomeNotFound = "not_found"
+	OutcomeConflict = "conflict"
+	OutcomeError    = "error"
+)
+
+// Metrics regroupe les collecteurs du service et le registre qui les expose
+type Metrics struct {
+	registry *prometheus.Registry
+
+	grpcHandled  *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+
+	clientRequests *prometheus.CounterVec
+	clientDuration *prometheus.HistogramVec
+
+	repositoryDuration *prometheus.HistogramVec
+
+	sessionCacheRequests    *prometheus.CounterVec
+	permissionCacheRequests *prometheus.CounterVec
+}
+
+// New crée les collecteurs et les enregistre dans un registre dédié,
+// avec les métriques du runtime Go et du processus
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		grpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_server",
+			Name:      "handled_total",
+			Help:      "Nombre d'appels gRPC traités, par méthode et code de statut.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_server",
+			Name:      "handling_seconds",
+			Help:      "Durée de traitement des appels gRPC, par méthode et code de statut.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		clientRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ory_client",
+			Name:      "requests_total",
+			Help:      "Nombre d'appels vers Kratos, Hydra et Keto, par opération et code HTTP (\"error\" si aucune réponse).",
+		}, []string{"service", "operation", "code"}),
+		clientDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "ory_client",
+			Name:      "request_duration_seconds",
+			Help:      "Durée des appels vers Kratos, Hydra et Keto, par opération.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "operation"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "operation_duration_seconds",
+			Help:      "Durée des opérations des repositories, par repository, opération et résultat.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "operation", "outcome"}),
+		sessionCacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "session_cache",
+			Name:      "requests_total",
+			Help:      "Nombre de validations de session servies par le cache (\"hit\") ou par Kratos (\"miss\").",
+		}, []string{"result"}),
+		permissionCacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "permission_cache",
+			Name:      "requests_total",
+			Help:      "Nombre de vérifications de permission servies par le cache (\"hit\") ou par Keto (\"miss\").",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpcHandled,
+		m.grpcDuration,
+		m.clientRequests,
+		m.clientDuration,
+		m.repositoryDuration,
+		m.sessionCacheRequests,
+		m.permissionCacheRequests,
+	)
+	return m
+}
+
+// Handler retourne le handler HTTP servant les métriques au format Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry retourne le registre, pour enregistrer des collecteurs propres à un composant
+func (m *Metrics) Registry() prometheus.Registerer {
+	return m.registry
+}
+
+// RegisterDB expose les statistiques du pool de connexions de la base de données
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveRPC enregistre un appel gRPC terminé
+func (m *Metrics) ObserveRPC(method, code string, duration time.Duration) {
+	m.grpcHandled.WithLabelValues(method, code).Inc()
+	m.grpcDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+// ObserveRepository enregistre une opération de repository démarrée à start
+func (m *Metrics) ObserveRepository(repository, operation string, start time.Time, err error) {
+	m.repositoryDuration.WithLabelValues(repository, operation, outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveSessionCache enregistre une validation de session servie par le cache ou par Kratos
+func (m *Metrics) ObserveSessionCache(hit bool) {
+	m.sessionCacheRequests.WithLabelValues(cacheResult(hit)).Inc()
+}
+
+// ObservePermissionCache enregistre une vérification de permission servie par le cache ou par Keto
+func (m *Metrics) ObservePermissionCache(hit bool) {
+	m.permissionCacheRequests.WithLabelValues(cacheResult(hit)).Inc()
+}
+
+// cacheResult libellé d'une requête servie par un cache
+func cacheResult(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "miss"
+}
+
+// outcome classe le résultat d'une opération
+func outcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	var appErr *common.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.HTTPStatus {
+		case http.StatusNotFound:
+			return OutcomeNotFound
+		case http.StatusConflict:
+			return OutcomeConflict
+		}
+	}
+	return OutcomeError
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"ndugu-backend/internal/common"
+)
+
+func TestTransport_RecordsOperation(t *testing.T) {
+	// Arrange
+	m := New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/clients/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := m.InstrumentClient(&http.Client{Timeout: time.Second})
+
+	// Act
+	for _, path := range []string{"/admin/clients/app", "/admin/clients/missing"} {
+		ctx := WithOperation(context.Background(), ServiceHydra, "GetOAuth2Client")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:1/unreachable", nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("Do() error = nil, want connection error")
+	}
+
+	// Assert
+	tests := []struct {
+		labels []string
+		want   float64
+	}{
+		{labels: []string{ServiceHydra, "GetOAuth2Client", "200"}, want: 1},
+		{labels: []string{ServiceHydra, "GetOAuth2Client", "404"}, want: 1},
+		{labels: []string{unknownLabel, unknownLabel, "error"}, want: 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(m.clientRequests.WithLabelValues(tt.labels...)); got != tt.want {
+			t.Errorf("requests_total%v = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+	if got := testutil.CollectAndCount(m.clientDuration); got != 2 {
+		t.Errorf("request_duration_seconds series = %d, want 2", got)
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: OutcomeSuccess},
+		{err: common.ErrUserNotFound, want: OutcomeNotFound},
+		{err: common.ErrCustomerExists, want: OutcomeConflict},
+		{err: errors.New("connexion refusée"), want: OutcomeError},
+	}
+
+	for _, tt := range tests {
+		if got := outcome(tt.err); got != tt.want {
+			t.Errorf("outcome(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestHandler_ExposesMetrics(t *testing.T) {
+	m := New()
+	m.ObserveRPC("/ndugu.v1.AuthService/GetUser", "OK", 20*time.Millisecond)
+	m.ObserveRepository("user", "GetByID", time.Now(), common.ErrUserNotFound)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		`ndugu_grpc_server_handled_total{code="OK",method="/ndugu.v1.AuthService/GetUser"} 1`,
+		`ndugu_grpc_server_handling_seconds_bucket{code="OK",method="/ndugu.v1.AuthService/GetUser",le="0.025"} 1`,
+		`ndugu_repository_operation_duration_seconds_count{operation="GetByID",outcome="not_found",repository="user"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output does not contain %q", want)
+		}
+	}
+}
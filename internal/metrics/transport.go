@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Services Ory appelés
+const (
+	ServiceKratos = "kratos"
+	ServiceHydra  = "hydra"
+	ServiceKeto   = "keto"
+)
+
+// unknownLabel valeur des labels d'un appel sortant non annoté par WithOperation
+const unknownLabel = "unknown"
+
+// operationKey clé de contexte de l'opération sortante en cours
+type operationKey struct{}
+
+// operation service Ory appelé et opération du client
+type operation struct {
+	service string
+	name    string
+}
+
+// WithOperation annote le contexte d'un appel sortant avec le service appelé
+// (kratos, hydra, keto) et l'opération du client, repris par le Transport
+func WithOperation(ctx context.Context, service, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation{service: service, name: name})
+}
+
+// OperationFromContext retourne le service et l'opération annotés par WithOperation
+func OperationFromContext(ctx context.Context) (service, name string, ok bool) {
+	op, ok := ctx.Value(operationKey{}).(operation)
+	return op.service, op.name, ok
+}
+
+// operationFromContext retourne l'opération annotée, ou "unknown"
+func operationFromContext(ctx context.Context) operation {
+	if op, ok := ctx.Value(operationKey{}).(operation); ok {
+		return op
+	}
+	return operation{service: unknownLabel, name: unknownLabel}
+}
+
+// Transport http.RoundTripper mesurant la durée et le résultat des appels vers les services Ory
+type Transport struct {
+	next    http.RoundTripper
+	metrics *Metrics
+}
+
+// NewTransport enveloppe next (http.DefaultTransport s'il est nil)
+func (m *Metrics) NewTransport(next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{next: next, metrics: m}
+}
+
+// InstrumentClient retourne une copie du client dont les appels sont mesurés
+func (m *Metrics) InstrumentClient(client *http.Client) *http.Client {
+	instrumented := *client
+	instrumented.Transport = m.NewTransport(client.Transport)
+	return &instrumented
+}
+
+// RoundTrip exécute la requête et enregistre sa durée et son code HTTP ("error" sans réponse)
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op := operationFromContext(req.Context())
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.metrics.clientRequests.WithLabelValues(op.service, op.name, code).Inc()
+	t.metrics.clientDuration.WithLabelValues(op.service, op.name).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// CloseIdleConnections ferme les connexions inactives du transport enveloppé
+func (t *Transport) CloseIdleConnections() {
+	if closer, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
@@ -6,44 +6,96 @@ import (
 
 // Session représente une session utilisateur
 type Session struct {
-	ID        string                 `json:"id" db:"id"`
-	UserID    string                 `json:"userId" db:"user_id"`
-	Token     string                 `json:"token" db:"token"`
-	Traits    map[string]interface{} `json:"traits" db:"traits"`
-	ExpiresAt time.Time              `json:"expiresAt" db:"expires_at"`
-	CreatedAt time.Time              `json:"createdAt" db:"created_at"`
+	ID                    string                 `json:"id" db:"id"`
+	UserID                string                 `json:"userId" db:"user_id"`
+	Token                 string                 `json:"token" db:"token"`
+	Traits                map[string]interface{} `json:"traits" db:"traits"`
+	AAL                   string                 `json:"aal" db:"aal"`
+	AuthenticationMethods []string               `json:"authenticationMethods" db:"authentication_methods"`
+	AuthenticatedAt       time.Time              `json:"authenticatedAt" db:"authenticated_at"`
+	ExpiresAt             time.Time              `json:"expiresAt" db:"expires_at"`
+	CreatedAt             time.Time              `json:"createdAt" db:"created_at"`
 }
 
-// ValidateSessionRequest représente la requête de validation de session
+// ValidateSessionRequest représente la requête de validation de session.
+// Le token (clients natifs) ou le cookie ory_kratos_session (navigateurs) doit être fourni.
 type ValidateSessionRequest struct {
-	SessionToken string `json:"sessionToken" validate:"required"`
+	SessionToken  string `json:"sessionToken"`
+	SessionCookie string `json:"sessionCookie"`
 }
 
 // ValidateSessionResponse représente la réponse de validation de session
 type ValidateSessionResponse struct {
-	Valid     bool      `json:"valid"`
-	UserID    string    `json:"userId,omitempty"`
-	Email     string    `json:"email,omitempty"`
+	Valid                 bool      `json:"valid"`
+	UserID                string    `json:"userId,omitempty"`
+	Email                 string    `json:"email,omitempty"`
+	ExpiresAt             time.Time `json:"expiresAt,omitempty"`
+	AuthenticatedAt       time.Time `json:"authenticatedAt,omitempty"`
+	AAL                   string    `json:"aal,omitempty"`
+	AuthenticationMethods []string  `json:"authenticationMethods,omitempty"`
+}
+
+// RevokeSessionRequest représente la requête de révocation d'un token de session
+type RevokeSessionRequest struct {
+	SessionToken string `json:"sessionToken"`
+}
+
+// TokenUseAccessToken type d'un jeton d'accès OAuth2 dans la réponse d'introspection Hydra
+const TokenUseAccessToken = "access_token"
+
+// TokenIntrospection représente le résultat de l'introspection d'un jeton d'accès OAuth2.
+// TokenUse distingue un jeton d'accès ("access_token") d'un jeton de rafraîchissement.
+type TokenIntrospection struct {
+	Active    bool      `json:"active"`
+	Subject   string    `json:"subject,omitempty"`
+	ClientID  string    `json:"clientId,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	TokenUse  string    `json:"tokenUse,omitempty"`
 	ExpiresAt time.Time `json:"expiresAt,omitempty"`
 }
 
 // OAuth2Client représente un client OAuth2
 type OAuth2Client struct {
-	ID           string    `json:"id" db:"id"`
-	Name         string    `json:"name" db:"name"`
-	Secret       string    `json:"secret" db:"secret"`
-	RedirectURIs []string  `json:"redirectUris" db:"redirect_uris"`
-	GrantTypes   []string  `json:"grantTypes" db:"grant_types"`
-	Scopes       []string  `json:"scopes" db:"scopes"`
-	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
-	UpdatedAt    time.Time `json:"updatedAt" db:"updated_at"`
+	ID                      string    `json:"id" db:"id"`
+	Name                    string    `json:"name" db:"name"`
+	Secret                  string    `json:"secret,omitempty" db:"secret"`
+	RedirectURIs            []string  `json:"redirectUris" db:"redirect_uris"`
+	GrantTypes              []string  `json:"grantTypes" db:"grant_types"`
+	ResponseTypes           []string  `json:"responseTypes" db:"response_types"`
+	Scopes                  []string  `json:"scopes" db:"scopes"`
+	TokenEndpointAuthMethod string    `json:"tokenEndpointAuthMethod" db:"token_endpoint_auth_method"`
+	CreatedAt               time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt               time.Time `json:"updatedAt" db:"updated_at"`
 }
 
-// CreateOAuth2ClientRequest représente la requête de création de client OAuth2
+// CreateOAuth2ClientRequest représente la requête de création de client OAuth2.
+// L'ID est optionnel (généré par Hydra); les listes vides prennent les valeurs par défaut.
 type CreateOAuth2ClientRequest struct {
-	ID          string `json:"id" validate:"required,min=3,max=50"`
-	Name        string `json:"name" validate:"required,min=3,max=100"`
-	RedirectURI string `json:"redirectUri" validate:"required,url"`
+	ID                      string   `json:"id" validate:"omitempty,min=3,max=50"`
+	Name                    string   `json:"name" validate:"required,min=3,max=100"`
+	RedirectURIs            []string `json:"redirectUris" validate:"omitempty,dive,url"`
+	GrantTypes              []string `json:"grantTypes"`
+	ResponseTypes           []string `json:"responseTypes"`
+	Scopes                  []string `json:"scopes"`
+	TokenEndpointAuthMethod string   `json:"tokenEndpointAuthMethod"`
+}
+
+// UpdateOAuth2ClientRequest représente la requête de mise à jour de client OAuth2.
+// Les champs vides ne sont pas modifiés.
+type UpdateOAuth2ClientRequest struct {
+	ID                      string   `json:"id" validate:"required"`
+	Name                    string   `json:"name" validate:"omitempty,min=3,max=100"`
+	RedirectURIs            []string `json:"redirectUris" validate:"omitempty,dive,url"`
+	GrantTypes              []string `json:"grantTypes"`
+	ResponseTypes           []string `json:"responseTypes"`
+	Scopes                  []string `json:"scopes"`
+	TokenEndpointAuthMethod string   `json:"tokenEndpointAuthMethod"`
+}
+
+// ListOAuth2ClientsResponse représente une page de clients OAuth2
+type ListOAuth2ClientsResponse struct {
+	Clients       []*OAuth2Client `json:"clients"`
+	NextPageToken string          `json:"nextPageToken,omitempty"`
 }
 
 // Permission représente une permission dans le système
@@ -58,7 +110,15 @@ type Permission struct {
 
 // CreatePermissionRequest représente la requête de création de permission
 type CreatePermissionRequest struct {
-	Namespace string `json:"namespace" validate:"required,min=1,max=50"`
+	Namespace string `json:"namespace" validate:"required,ketoNamespace"`
+	Object    string `json:"object" validate:"required,min=1,max=100"`
+	Relation  string `json:"relation" validate:"required,min=1,max=50"`
+	Subject   string `json:"subject" validate:"required,min=1,max=100"`
+}
+
+// DeletePermissionRequest représente la requête de suppression de permission
+type DeletePermissionRequest struct {
+	Namespace string `json:"namespace" validate:"required,ketoNamespace"`
 	Object    string `json:"object" validate:"required,min=1,max=100"`
 	Relation  string `json:"relation" validate:"required,min=1,max=50"`
 	Subject   string `json:"subject" validate:"required,min=1,max=100"`
@@ -66,12 +126,73 @@ type CreatePermissionRequest struct {
 
 // CheckPermissionRequest représente la requête de vérification de permission
 type CheckPermissionRequest struct {
-	Namespace string `json:"namespace" validate:"required,min=1,max=50"`
+	Namespace string `json:"namespace" validate:"required,ketoNamespace"`
 	Object    string `json:"object" validate:"required,min=1,max=100"`
 	Relation  string `json:"relation" validate:"required,min=1,max=50"`
 	Subject   string `json:"subject" validate:"required,min=1,max=100"`
 }
 
+// BatchCheckPermissionRequest représente la vérification de plusieurs tuples en une requête
+type BatchCheckPermissionRequest struct {
+	Checks []*CheckPermissionRequest `json:"checks"`
+}
+
+// PermissionCheckResult représente le résultat de la vérification d'un tuple.
+// Error est renseignée si le tuple n'a pas pu être vérifié.
+type PermissionCheckResult struct {
+	HasPermission bool  `json:"hasPermission"`
+	Error         error `json:"-"`
+}
+
+// BatchCheckPermissionResponse représente les résultats d'une vérification groupée, dans l'ordre de la requête
+type BatchCheckPermissionResponse struct {
+	Results []PermissionCheckResult `json:"results"`
+}
+
+// ExpandPermissionRequest représente la demande de l'arbre des sujets ayant une relation sur un objet.
+// MaxDepth limite la profondeur de l'arbre; 0 applique la limite de Keto.
+type ExpandPermissionRequest struct {
+	Namespace string `json:"namespace" validate:"required,ketoNamespace"`
+	Object    string `json:"object" validate:"required,min=1,max=100"`
+	Relation  string `json:"relation" validate:"required,min=1,max=50"`
+	MaxDepth  int    `json:"maxDepth"`
+}
+
+// SubjectTree représente un nœud de l'arbre des sujets d'une relation.
+// Type vaut union, exclusion, intersection, leaf, etc.; Subject est un identifiant
+// ou un subject set "namespace:object#relation" développé par Children.
+type SubjectTree struct {
+	Type     string         `json:"type"`
+	Subject  string         `json:"subject,omitempty"`
+	Children []*SubjectTree `json:"children,omitempty"`
+}
+
+// RelationTuple représente un tuple de relation Keto.
+// Le sujet est un identifiant ou un subject set "namespace:object#relation".
+type RelationTuple struct {
+	Namespace string `json:"namespace"`
+	Object    string `json:"object"`
+	Relation  string `json:"relation"`
+	Subject   string `json:"subject"`
+}
+
+// ListRelationsRequest représente une demande de page de tuples de relation.
+// Les filtres sont optionnels et combinables.
+type ListRelationsRequest struct {
+	Namespace string `json:"namespace,omitempty" validate:"omitempty,ketoNamespace"`
+	Object    string `json:"object,omitempty" validate:"max=100"`
+	Relation  string `json:"relation,omitempty" validate:"max=50"`
+	Subject   string `json:"subject,omitempty" validate:"max=100"`
+	PageSize  int    `json:"pageSize"`
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// ListRelationsResponse représente une page de tuples de relation
+type ListRelationsResponse struct {
+	Relations     []*RelationTuple `json:"relations"`
+	NextPageToken string           `json:"nextPageToken,omitempty"`
+}
+
 // PermissionResponse représente la réponse de permission
 type PermissionResponse struct {
 	HasPermission bool   `json:"hasPermission"`
@@ -17,9 +17,17 @@ type Customer struct {
 
 // CreateCustomerRequest représente la requête de création de client
 type CreateCustomerRequest struct {
-	PhoneCode   string `json:"phoneCode" validate:"required,min=1,max=5"`
+	PhoneCode   string `json:"phoneCode" validate:"required,phoneCode"`
 	PhoneNumber string `json:"phoneNumber" validate:"required,min=7,max=15"`
-	Password    string `json:"password" validate:"required,min=8"`
+	Password    string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// UpdateCustomerRequest représente la requête de mise à jour de client
+type UpdateCustomerRequest struct {
+	ID          string `json:"id" validate:"required"`
+	PhoneCode   string `json:"phoneCode" validate:"omitempty,phoneCode"`
+	PhoneNumber string `json:"phoneNumber" validate:"omitempty,min=7,max=15"`
+	Password    string `json:"password" validate:"omitempty,min=8,max=72"`
 }
 
 // CreateCustomerResponse représente la réponse de création de client
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// IdempotencyRecord réponse mémorisée pour une clé d'idempotence.
+// Response est vide tant que la requête est en cours de traitement.
+type IdempotencyRecord struct {
+	Key         string    `json:"key" db:"key"`
+	RequestHash string    `json:"requestHash" db:"request_hash"`
+	Response    []byte    `json:"response,omitempty" db:"response"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+	ExpiresAt   time.Time `json:"expiresAt" db:"expires_at"`
+}
+
+// Completed indique si la réponse de la requête a été mémorisée
+func (r *IdempotencyRecord) Completed() bool {
+	return r.Response != nil
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -22,12 +23,64 @@ type CreateUserRequest struct {
 	LastName  string `json:"lastName" validate:"required,min=2,max=50"`
 }
 
-// UpdateUserRequest représente la requête de mise à jour d'utilisateur
+// UpdateUserRequest représente la requête de mise à jour d'utilisateur.
+// UpdateMask liste les champs à modifier (email, firstName, lastName); s'il est vide,
+// seuls les champs non vides sont modifiés.
 type UpdateUserRequest struct {
-	ID        string `json:"id" validate:"required"`
-	Email     string `json:"email" validate:"omitempty,email"`
-	FirstName string `json:"firstName" validate:"omitempty,min=2,max=50"`
-	LastName  string `json:"lastName" validate:"omitempty,min=2,max=50"`
+	ID         string   `json:"id" validate:"required"`
+	Email      string   `json:"email" validate:"omitempty,email"`
+	FirstName  string   `json:"firstName" validate:"omitempty,min=2,max=50"`
+	LastName   string   `json:"lastName" validate:"omitempty,min=2,max=50"`
+	UpdateMask []string `json:"updateMask,omitempty"`
+}
+
+// ListUsersRequest représente une demande de page d'utilisateurs.
+// Les filtres sont optionnels; CreatedAfter est inclus, CreatedBefore exclu.
+type ListUsersRequest struct {
+	PageSize      int       `json:"pageSize"`
+	PageToken     string    `json:"pageToken,omitempty"`
+	EmailPrefix   string    `json:"emailPrefix,omitempty"`
+	CreatedAfter  time.Time `json:"createdAfter,omitempty"`
+	CreatedBefore time.Time `json:"createdBefore,omitempty"`
+}
+
+// ListUsersResponse représente une page d'utilisateurs
+type ListUsersResponse struct {
+	Users         []*UserResponse `json:"users"`
+	NextPageToken string          `json:"nextPageToken,omitempty"`
+}
+
+// UserFilter critères de recherche des utilisateurs.
+// Les champs vides ne filtrent pas; AfterCreatedAt et AfterID forment le curseur de
+// pagination: seuls les utilisateurs situés strictement après sont retournés.
+type UserFilter struct {
+	EmailPrefix    string
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	AfterCreatedAt time.Time
+	AfterID        string
+}
+
+// Matches indique si l'utilisateur satisfait le filtre (implémentations en mémoire)
+func (f *UserFilter) Matches(user *User) bool {
+	if f.EmailPrefix != "" && !strings.HasPrefix(strings.ToLower(user.Email), strings.ToLower(f.EmailPrefix)) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && user.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !user.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.AfterID != "" {
+		if user.CreatedAt.Before(f.AfterCreatedAt) {
+			return false
+		}
+		if user.CreatedAt.Equal(f.AfterCreatedAt) && user.ID <= f.AfterID {
+			return false
+		}
+	}
+	return true
 }
 
 // UserResponse représente la réponse utilisateur
@@ -51,3 +104,13 @@ func (u *User) ToResponse() *UserResponse {
 		UpdatedAt: u.UpdatedAt,
 	}
 }
+
+// UserReconciliationReport résultat d'une réconciliation des identités Kratos avec la base locale
+type UserReconciliationReport struct {
+	Checked int `json:"checked"` // identités Kratos parcourues
+	Created int `json:"created"` // utilisateurs absents de la base locale, créés
+	Updated int `json:"updated"` // utilisateurs dont l'email ou le nom différait, mis à jour
+	Failed  int `json:"failed"`  // réparations en échec
+	// Orphans utilisateurs locaux sans identité Kratos: signalés, jamais supprimés
+	Orphans []string `json:"orphans,omitempty"`
+}
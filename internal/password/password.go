@@ -0,0 +1,208 @@
+// Package password gère le hachage et la vérification des mots de passe.
+//
+// Les empreintes stockées sont auto-descriptives : elles encodent l'algorithme
+// et ses paramètres, ce qui permet de vérifier d'anciennes empreintes et de
+// détecter celles qui doivent être recalculées après un changement de configuration.
+//
+//	argon2id : $argon2id$v=19$m=65536,t=3,p=2$<sel base64>$<clé base64>
+//	bcrypt   : $2a$12$<sel et clé>
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ndugu-backend/internal/config"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithmes supportés
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// MaxLength longueur maximale d'un mot de passe en octets, imposée par bcrypt
+// et appliquée à tous les algorithmes pour qu'un changement de configuration reste possible
+const MaxLength = 72
+
+var (
+	// ErrInvalidHash est retournée quand une empreinte stockée est illisible
+	ErrInvalidHash = errors.New("format d'empreinte de mot de passe invalide")
+	// ErrUnsupportedAlgorithm est retournée pour un algorithme inconnu
+	ErrUnsupportedAlgorithm = errors.New("algorithme de hachage non supporté")
+	// ErrPasswordTooLong est retournée par Hash pour un mot de passe de plus de MaxLength octets
+	ErrPasswordTooLong = errors.New("mot de passe trop long")
+)
+
+// Hasher interface pour le hachage des mots de passe
+type Hasher interface {
+	// Hash calcule l'empreinte d'un mot de passe avec les paramètres courants
+	Hash(password string) (string, error)
+	// Verify compare un mot de passe à une empreinte en temps constant
+	Verify(password, encodedHash string) (bool, error)
+	// NeedsRehash indique si l'empreinte a été produite avec d'autres paramètres
+	NeedsRehash(encodedHash string) bool
+}
+
+// hasher implémentation de Hasher basée sur argon2id et bcrypt
+type hasher struct {
+	cfg config.PasswordConfig
+}
+
+// NewHasher crée un Hasher à partir de la configuration
+func NewHasher(cfg config.PasswordConfig) (Hasher, error) {
+	switch cfg.Algorithm {
+	case AlgorithmArgon2id:
+		if cfg.Argon2Memory == 0 || cfg.Argon2Iterations == 0 || cfg.Argon2Parallelism == 0 ||
+			cfg.Argon2SaltLength == 0 || cfg.Argon2KeyLength == 0 {
+			return nil, fmt.Errorf("paramètres argon2id invalides: %+v", cfg)
+		}
+	case AlgorithmBcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("coût bcrypt invalide: %d", cfg.BcryptCost)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, cfg.Algorithm)
+	}
+
+	return &hasher{cfg: cfg}, nil
+}
+
+// Hash calcule l'empreinte d'un mot de passe avec l'algorithme configuré
+func (h *hasher) Hash(password string) (string, error) {
+	if len(password) > MaxLength {
+		return "", ErrPasswordTooLong
+	}
+	if h.cfg.Algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("erreur lors du hachage bcrypt: %w", err)
+		}
+		return string(hash), nil
+	}
+
+	salt := make([]byte, h.cfg.Argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("erreur lors de la génération du sel: %w", err)
+	}
+
+	params := argon2Params{
+		memory:      h.cfg.Argon2Memory,
+		iterations:  h.cfg.Argon2Iterations,
+		parallelism: h.cfg.Argon2Parallelism,
+	}
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, h.cfg.Argon2KeyLength)
+
+	return params.encode(salt, key), nil
+}
+
+// Verify compare un mot de passe à une empreinte, quel que soit son algorithme
+func (h *hasher) Verify(password, encodedHash string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encodedHash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2(encodedHash)
+		if err != nil {
+			return false, err
+		}
+		candidate := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+
+	case isBcryptHash(encodedHash):
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+		}
+		return true, nil
+
+	default:
+		return false, ErrInvalidHash
+	}
+}
+
+// NeedsRehash indique si l'empreinte ne correspond plus à la configuration courante
+func (h *hasher) NeedsRehash(encodedHash string) bool {
+	switch h.cfg.Algorithm {
+	case AlgorithmArgon2id:
+		params, salt, key, err := decodeArgon2(encodedHash)
+		if err != nil {
+			return true
+		}
+		return params.memory != h.cfg.Argon2Memory ||
+			params.iterations != h.cfg.Argon2Iterations ||
+			params.parallelism != h.cfg.Argon2Parallelism ||
+			uint32(len(salt)) != h.cfg.Argon2SaltLength ||
+			uint32(len(key)) != h.cfg.Argon2KeyLength
+
+	case AlgorithmBcrypt:
+		if !isBcryptHash(encodedHash) {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encodedHash))
+		return err != nil || cost != h.cfg.BcryptCost
+	}
+
+	return true
+}
+
+// argon2Params regroupe les paramètres encodés dans une empreinte argon2id
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// encode produit une empreinte au format PHC
+func (p argon2Params) encode(salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.memory, p.iterations, p.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// decodeArgon2 extrait les paramètres, le sel et la clé d'une empreinte argon2id
+func decodeArgon2(encodedHash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	return params, salt, key, nil
+}
+
+// isBcryptHash reconnaît les préfixes bcrypt standards
+func isBcryptHash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
@@ -0,0 +1,145 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"ndugu-backend/internal/config"
+)
+
+// testArgon2Config utilise des paramètres réduits pour accélérer les tests
+func testArgon2Config() config.PasswordConfig {
+	return config.PasswordConfig{
+		Algorithm:         AlgorithmArgon2id,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+		BcryptCost:        4,
+	}
+}
+
+func TestHasher_HashAndVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		prefix    string
+	}{
+		{name: "argon2id", algorithm: AlgorithmArgon2id, prefix: "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{name: "bcrypt", algorithm: AlgorithmBcrypt, prefix: "$2a$04$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testArgon2Config()
+			cfg.Algorithm = tt.algorithm
+			hasher, err := NewHasher(cfg)
+			if err != nil {
+				t.Fatalf("NewHasher() error = %v", err)
+			}
+
+			hash, err := hasher.Hash("password123")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if !strings.HasPrefix(hash, tt.prefix) {
+				t.Errorf("Hash() = %v, want prefix %v", hash, tt.prefix)
+			}
+
+			if ok, err := hasher.Verify("password123", hash); err != nil || !ok {
+				t.Errorf("Verify(correct) = %v, %v, want true, nil", ok, err)
+			}
+			if ok, err := hasher.Verify("wrong-password", hash); err != nil || ok {
+				t.Errorf("Verify(wrong) = %v, %v, want false, nil", ok, err)
+			}
+			if hasher.NeedsRehash(hash) {
+				t.Error("NeedsRehash() = true for current parameters")
+			}
+		})
+	}
+}
+
+func TestHasher_HashIsSalted(t *testing.T) {
+	hasher, _ := NewHasher(testArgon2Config())
+
+	first, _ := hasher.Hash("password123")
+	second, _ := hasher.Hash("password123")
+
+	if first == second {
+		t.Error("Hash() produced identical hashes for the same password")
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	oldHasher, _ := NewHasher(testArgon2Config())
+	oldHash, _ := oldHasher.Hash("password123")
+
+	stronger := testArgon2Config()
+	stronger.Argon2Iterations = 2
+	newHasher, _ := NewHasher(stronger)
+
+	if !newHasher.NeedsRehash(oldHash) {
+		t.Error("NeedsRehash() = false after iterations change")
+	}
+	// Les anciennes empreintes restent vérifiables
+	if ok, err := newHasher.Verify("password123", oldHash); err != nil || !ok {
+		t.Errorf("Verify(old hash) = %v, %v, want true, nil", ok, err)
+	}
+
+	bcryptCfg := testArgon2Config()
+	bcryptCfg.Algorithm = AlgorithmBcrypt
+	bcryptHasher, _ := NewHasher(bcryptCfg)
+	if !bcryptHasher.NeedsRehash(oldHash) {
+		t.Error("NeedsRehash() = false after algorithm change")
+	}
+}
+
+func TestHasher_HashTooLong(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt} {
+		cfg := testArgon2Config()
+		cfg.Algorithm = algorithm
+		hasher, _ := NewHasher(cfg)
+
+		if _, err := hasher.Hash(strings.Repeat("a", MaxLength)); err != nil {
+			t.Errorf("%s: Hash(%d bytes) error = %v", algorithm, MaxLength, err)
+		}
+		if _, err := hasher.Hash(strings.Repeat("a", MaxLength+1)); err != ErrPasswordTooLong {
+			t.Errorf("%s: Hash(%d bytes) error = %v, want ErrPasswordTooLong", algorithm, MaxLength+1, err)
+		}
+	}
+}
+
+func TestHasher_VerifyInvalidHash(t *testing.T) {
+	hasher, _ := NewHasher(testArgon2Config())
+
+	for _, hash := range []string{"", "plaintext", "$argon2id$v=19$m=x$salt$key", "$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5"} {
+		if _, err := hasher.Verify("password123", hash); err == nil {
+			t.Errorf("Verify(%q) expected error", hash)
+		}
+	}
+}
+
+func TestNewHasher_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(cfg *config.PasswordConfig)
+	}{
+		{name: "unknown algorithm", mutate: func(cfg *config.PasswordConfig) { cfg.Algorithm = "md5" }},
+		{name: "zero argon2 memory", mutate: func(cfg *config.PasswordConfig) { cfg.Argon2Memory = 0 }},
+		{name: "bcrypt cost too high", mutate: func(cfg *config.PasswordConfig) {
+			cfg.Algorithm = AlgorithmBcrypt
+			cfg.BcryptCost = 40
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testArgon2Config()
+			tt.mutate(&cfg)
+			if _, err := NewHasher(cfg); err == nil {
+				t.Error("NewHasher() expected error")
+			}
+		})
+	}
+}
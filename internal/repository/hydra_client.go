@@ -1,31 +1,433 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/metrics"
 )
 
-// hydraClient implémentation du client Hydra
+// hydraClient implémentation du client Hydra basée sur l'API d'administration REST
 type hydraClient struct {
-	// Temporairement vide - sera implémenté quand Hydra sera corrigé
+	adminURL   string
+	httpClient *http.Client
 }
 
 // NewHydraClient crée une nouvelle instance du client Hydra
-func NewHydraClient() HydraClient {
-	return &hydraClient{}
-}
-
-// CreateOAuth2Client crée un client OAuth2 via Hydra
-func (c *hydraClient) CreateOAuth2Client(ctx context.Context, clientID, clientName, redirectURI string) (*HydraOAuth2Client, error) {
-	// Temporairement désactivé - à implémenter quand Hydra sera corrigé
-	return &HydraOAuth2Client{
-		ID:           clientID,
-		Name:         clientName,
-		Secret:       "temporairement-désactivé",
-		RedirectURIs: []string{redirectURI},
-		GrantTypes:   []string{"authorization_code", "refresh_token"},
-		Scopes:       []string{"openid", "profile", "email"},
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-	}, nil
+func NewHydraClient(cfg config.HydraConfig, httpClient *http.Client) HydraClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &hydraClient{
+		adminURL:   strings.TrimSuffix(cfg.AdminURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// HydraOAuth2Client représente un client OAuth2 tel qu'exposé par l'API Hydra
+type HydraOAuth2Client struct {
+	ClientID                string    `json:"client_id,omitempty"`
+	ClientName              string    `json:"client_name,omitempty"`
+	ClientSecret            string    `json:"client_secret,omitempty"`
+	RedirectURIs            []string  `json:"redirect_uris,omitempty"`
+	GrantTypes              []string  `json:"grant_types,omitempty"`
+	ResponseTypes           []string  `json:"response_types,omitempty"`
+	Scope                   string    `json:"scope,omitempty"`
+	TokenEndpointAuthMethod string    `json:"token_endpoint_auth_method,omitempty"`
+	CreatedAt               time.Time `json:"created_at"`
+	UpdatedAt               time.Time `json:"updated_at"`
+}
+
+// HydraOAuth2ClientSummary représente le client d'une requête de login ou de consentement
+type HydraOAuth2ClientSummary struct {
+	ClientID   string `json:"client_id"`
+	ClientName string `json:"client_name"`
+}
+
+// HydraLoginRequest représente une requête de login en attente dans Hydra
+type HydraLoginRequest struct {
+	Challenge      string                   `json:"challenge"`
+	Skip           bool                     `json:"skip"`
+	Subject        string                   `json:"subject"`
+	Client         HydraOAuth2ClientSummary `json:"client"`
+	RequestedScope []string                 `json:"requested_scope"`
+	RequestURL     string                   `json:"request_url"`
+	SessionID      string                   `json:"session_id"`
+}
+
+// HydraConsentRequest représente une requête de consentement en attente dans Hydra
+type HydraConsentRequest struct {
+	Challenge                    string                   `json:"challenge"`
+	Skip                         bool                     `json:"skip"`
+	Subject                      string                   `json:"subject"`
+	Client                       HydraOAuth2ClientSummary `json:"client"`
+	RequestedScope               []string                 `json:"requested_scope"`
+	RequestedAccessTokenAudience []string                 `json:"requested_access_token_audience"`
+	LoginSessionID               string                   `json:"login_session_id"`
+}
+
+// HydraLogoutRequest représente une requête de déconnexion en attente dans Hydra
+type HydraLogoutRequest struct {
+	Challenge   string `json:"challenge"`
+	Subject     string `json:"subject"`
+	SessionID   string `json:"sid"`
+	RPInitiated bool   `json:"rp_initiated"`
+}
+
+// HydraAcceptLogin représente l'acceptation d'une requête de login
+type HydraAcceptLogin struct {
+	Subject     string   `json:"subject"`
+	Remember    bool     `json:"remember"`
+	RememberFor int64    `json:"remember_for"`
+	AMR         []string `json:"amr,omitempty"`
+}
+
+// HydraConsentSession contient les claims ajoutés aux jetons émis
+type HydraConsentSession struct {
+	IDToken     map[string]interface{} `json:"id_token,omitempty"`
+	AccessToken map[string]interface{} `json:"access_token,omitempty"`
+}
+
+// HydraAcceptConsent représente l'acceptation d'une requête de consentement
+type HydraAcceptConsent struct {
+	GrantScope               []string            `json:"grant_scope"`
+	GrantAccessTokenAudience []string            `json:"grant_access_token_audience,omitempty"`
+	Remember                 bool                `json:"remember"`
+	RememberFor              int64               `json:"remember_for"`
+	Session                  HydraConsentSession `json:"session"`
+}
+
+// HydraRejectRequest représente le refus d'une requête de login ou de consentement
+type HydraRejectRequest struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	StatusCode       int    `json:"status_code,omitempty"`
+}
+
+// HydraIntrospection représente le résultat de l'introspection d'un jeton OAuth2
+type HydraIntrospection struct {
+	Active    bool   `json:"active"`
+	Subject   string `json:"sub"`
+	ClientID  string `json:"client_id"`
+	Scope     string `json:"scope"`
+	ExpiresAt int64  `json:"exp"`
+	TokenUse  string `json:"token_use"`
+}
+
+// hydraRedirect représente la réponse de Hydra après acceptation ou refus d'une requête
+type hydraRedirect struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// hydraErrorResponse représente le corps d'erreur renvoyé par Hydra
+type hydraErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// CreateOAuth2Client crée un client OAuth2 via l'API d'administration Hydra.
+// Hydra génère le secret s'il n'est pas fourni; il n'est renvoyé qu'à la création.
+func (c *hydraClient) CreateOAuth2Client(ctx context.Context, client *HydraOAuth2Client) (*HydraOAuth2Client, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "CreateOAuth2Client")
+	var created HydraOAuth2Client
+	if err := c.doJSON(ctx, http.MethodPost, c.adminURL+"/admin/clients", client, http.StatusCreated, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetOAuth2Client récupère un client OAuth2 par son ID
+func (c *hydraClient) GetOAuth2Client(ctx context.Context, clientID string) (*HydraOAuth2Client, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "GetOAuth2Client")
+	var client HydraOAuth2Client
+	if err := c.doJSON(ctx, http.MethodGet, c.clientURL(clientID), nil, http.StatusOK, &client); err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// ListOAuth2Clients liste les clients OAuth2 et retourne le jeton de la page suivante
+func (c *hydraClient) ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) ([]*HydraOAuth2Client, string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "ListOAuth2Clients")
+	query := url.Values{}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.adminURL+"/admin/clients?"+query.Encode(), nil)
+	if err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeHydraError, "Erreur lors de la récupération des clients OAuth2", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", hydraError(resp, "Erreur lors de la récupération des clients OAuth2")
+	}
+
+	var clients []*HydraOAuth2Client
+	if err := json.NewDecoder(resp.Body).Decode(&clients); err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeHydraError, "Réponse Hydra invalide", err.Error())
+	}
+
+	return clients, nextPageToken(resp.Header.Values("Link")), nil
+}
+
+// UpdateOAuth2Client remplace un client OAuth2; Hydra conserve le secret existant s'il est omis
+func (c *hydraClient) UpdateOAuth2Client(ctx context.Context, client *HydraOAuth2Client) (*HydraOAuth2Client, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "UpdateOAuth2Client")
+	var updated HydraOAuth2Client
+	if err := c.doJSON(ctx, http.MethodPut, c.clientURL(client.ClientID), client, http.StatusOK, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteOAuth2Client supprime un client OAuth2
+func (c *hydraClient) DeleteOAuth2Client(ctx context.Context, clientID string) error {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "DeleteOAuth2Client")
+	return c.doJSON(ctx, http.MethodDelete, c.clientURL(clientID), nil, http.StatusNoContent, nil)
+}
+
+// SetOAuth2ClientSecret remplace le secret d'un client OAuth2 via un JSON Patch
+func (c *hydraClient) SetOAuth2ClientSecret(ctx context.Context, clientID, secret string) (*HydraOAuth2Client, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "SetOAuth2ClientSecret")
+	patch := []map[string]string{
+		{"op": "replace", "path": "/client_secret", "value": secret},
+	}
+
+	var updated HydraOAuth2Client
+	if err := c.doJSON(ctx, http.MethodPatch, c.clientURL(clientID), patch, http.StatusOK, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// GetLoginRequest récupère une requête de login à partir de son challenge
+func (c *hydraClient) GetLoginRequest(ctx context.Context, challenge string) (*HydraLoginRequest, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "GetLoginRequest")
+	var req HydraLoginRequest
+	if err := c.doFlow(ctx, http.MethodGet, "login", "", challenge, nil, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// AcceptLoginRequest accepte une requête de login et retourne l'URL de redirection
+func (c *hydraClient) AcceptLoginRequest(ctx context.Context, challenge string, body *HydraAcceptLogin) (string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "AcceptLoginRequest")
+	var redirect hydraRedirect
+	err := c.doFlow(ctx, http.MethodPut, "login", "/accept", challenge, body, &redirect)
+	return redirect.RedirectTo, err
+}
+
+// RejectLoginRequest refuse une requête de login et retourne l'URL de redirection
+func (c *hydraClient) RejectLoginRequest(ctx context.Context, challenge string, body *HydraRejectRequest) (string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "RejectLoginRequest")
+	var redirect hydraRedirect
+	err := c.doFlow(ctx, http.MethodPut, "login", "/reject", challenge, body, &redirect)
+	return redirect.RedirectTo, err
+}
+
+// GetConsentRequest récupère une requête de consentement à partir de son challenge
+func (c *hydraClient) GetConsentRequest(ctx context.Context, challenge string) (*HydraConsentRequest, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "GetConsentRequest")
+	var req HydraConsentRequest
+	if err := c.doFlow(ctx, http.MethodGet, "consent", "", challenge, nil, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// AcceptConsentRequest accepte une requête de consentement et retourne l'URL de redirection
+func (c *hydraClient) AcceptConsentRequest(ctx context.Context, challenge string, body *HydraAcceptConsent) (string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "AcceptConsentRequest")
+	var redirect hydraRedirect
+	err := c.doFlow(ctx, http.MethodPut, "consent", "/accept", challenge, body, &redirect)
+	return redirect.RedirectTo, err
+}
+
+// RejectConsentRequest refuse une requête de consentement et retourne l'URL de redirection
+func (c *hydraClient) RejectConsentRequest(ctx context.Context, challenge string, body *HydraRejectRequest) (string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "RejectConsentRequest")
+	var redirect hydraRedirect
+	err := c.doFlow(ctx, http.MethodPut, "consent", "/reject", challenge, body, &redirect)
+	return redirect.RedirectTo, err
+}
+
+// GetLogoutRequest récupère une requête de déconnexion à partir de son challenge
+func (c *hydraClient) GetLogoutRequest(ctx context.Context, challenge string) (*HydraLogoutRequest, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "GetLogoutRequest")
+	var req HydraLogoutRequest
+	if err := c.doFlow(ctx, http.MethodGet, "logout", "", challenge, nil, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// AcceptLogoutRequest accepte une requête de déconnexion et retourne l'URL de redirection
+func (c *hydraClient) AcceptLogoutRequest(ctx context.Context, challenge string) (string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "AcceptLogoutRequest")
+	var redirect hydraRedirect
+	err := c.doFlow(ctx, http.MethodPut, "logout", "/accept", challenge, nil, &redirect)
+	return redirect.RedirectTo, err
+}
+
+// IntrospectToken introspecte un jeton OAuth2 via l'API d'administration Hydra.
+// Un jeton inconnu, expiré ou révoqué est renvoyé avec Active à false.
+func (c *hydraClient) IntrospectToken(ctx context.Context, token string) (*HydraIntrospection, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceHydra, "IntrospectToken")
+	form := url.Values{"token": []string{token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.adminURL+"/admin/oauth2/introspect", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, common.NewAppError(common.ErrCodeHydraError, "Erreur lors de l'introspection du jeton", err.Error())
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, common.NewAppError(common.ErrCodeHydraError, "Erreur lors de l'introspection du jeton", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, hydraError(resp, "Erreur lors de l'introspection du jeton")
+	}
+
+	var introspection HydraIntrospection
+	if err := json.NewDecoder(resp.Body).Decode(&introspection); err != nil {
+		return nil, common.NewAppError(common.ErrCodeHydraError, "Réponse Hydra invalide", err.Error())
+	}
+
+	return &introspection, nil
+}
+
+// doFlow appelle l'API des requêtes login/consent/logout de Hydra
+func (c *hydraClient) doFlow(ctx context.Context, method, flow, action, challenge string, in, out interface{}) error {
+	query := url.Values{flow + "_challenge": []string{challenge}}
+	rawURL := c.adminURL + "/admin/oauth2/auth/requests/" + flow + action + "?" + query.Encode()
+
+	err := c.doJSON(ctx, method, rawURL, in, http.StatusOK, out)
+	if appErr, ok := err.(*common.AppError); ok && appErr.Code == common.ErrCodeOAuth2ClientNotFound {
+		return common.NewAppError(common.ErrCodeNotFound, "Challenge OAuth2 introuvable", appErr.Details)
+	}
+	return err
+}
+
+// clientURL retourne l'URL d'administration d'un client OAuth2
+func (c *hydraClient) clientURL(clientID string) string {
+	return c.adminURL + "/admin/clients/" + url.PathEscape(clientID)
+}
+
+// doJSON exécute une requête JSON vers Hydra et décode la réponse attendue
+func (c *hydraClient) doJSON(ctx context.Context, method, rawURL string, in interface{}, wantStatus int, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return common.NewAppError(common.ErrCodeHydraError, "Erreur lors de l'appel à Hydra", err.Error())
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	resp, err := c.do(ctx, method, rawURL, body)
+	if err != nil {
+		return common.NewAppError(common.ErrCodeHydraError, "Erreur lors de l'appel à Hydra", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		return hydraError(resp, "Erreur lors de l'appel à Hydra")
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return common.NewAppError(common.ErrCodeHydraError, "Réponse Hydra invalide", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// do exécute une requête HTTP vers Hydra
+func (c *hydraClient) do(ctx context.Context, method, rawURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la requête: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// hydraError convertit une réponse d'erreur Hydra en AppError
+func hydraError(resp *http.Response, message string) error {
+	details := fmt.Sprintf("status %d", resp.StatusCode)
+
+	var errResp hydraErrorResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&errResp); err == nil && errResp.Error != "" {
+		details = fmt.Sprintf("status %d: %s", resp.StatusCode, errResp.Error)
+		if errResp.ErrorDescription != "" {
+			details += " (" + errResp.ErrorDescription + ")"
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return common.NewAppError(common.ErrCodeOAuth2ClientNotFound, "Client OAuth2 non trouvé", details)
+	case http.StatusConflict:
+		return common.NewAppError(common.ErrCodeOAuth2ClientExists, "Client OAuth2 déjà existant", details)
+	case http.StatusGone:
+		return common.NewAppError(common.ErrCodeConflict, "Requête OAuth2 déjà traitée ou expirée", details)
+	case http.StatusBadRequest:
+		return common.NewAppError(common.ErrCodeInvalidInput, "Client OAuth2 invalide", details)
+	default:
+		return common.NewAppError(common.ErrCodeHydraError, message, details)
+	}
+}
+
+// nextPageToken extrait le page_token du lien rel="next" de l'en-tête Link
+func nextPageToken(links []string) string {
+	for _, header := range links {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			if len(parts) < 2 {
+				continue
+			}
+
+			isNext := false
+			for _, param := range parts[1:] {
+				if strings.TrimSpace(param) == `rel="next"` {
+					isNext = true
+				}
+			}
+			if !isNext {
+				continue
+			}
+
+			target, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+			if err != nil {
+				continue
+			}
+			return target.Query().Get("page_token")
+		}
+	}
+	return ""
 }
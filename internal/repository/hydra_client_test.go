@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+)
+
+// fakeHydra simule l'API d'administration des clients OAuth2 de Hydra
+type fakeHydra struct {
+	mutex   sync.Mutex
+	clients map[string]*HydraOAuth2Client
+	nextID  int
+}
+
+func (f *fakeHydra) writeError(w http.ResponseWriter, status int, code, description string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(hydraErrorResponse{Error: code, ErrorDescription: description})
+}
+
+func (f *fakeHydra) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/admin/clients", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			var client HydraOAuth2Client
+			json.NewDecoder(r.Body).Decode(&client)
+			if client.ClientID == "" {
+				f.nextID++
+				client.ClientID = "client-" + strconv.Itoa(f.nextID)
+			}
+			if _, exists := f.clients[client.ClientID]; exists {
+				f.writeError(w, http.StatusConflict, "conflict", "Unable to insert or update resource because a resource with that value exists already")
+				return
+			}
+			if client.ClientSecret == "" && client.TokenEndpointAuthMethod != "none" {
+				client.ClientSecret = "generated-secret"
+			}
+			stored := client
+			stored.ClientSecret = "hashed:" + client.ClientSecret
+			f.clients[client.ClientID] = &stored
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(client)
+		case http.MethodGet:
+			ids := make([]string, 0, len(f.clients))
+			for id := range f.clients {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("page_token"))
+			end := offset + pageSize
+			if end >= len(ids) {
+				end = len(ids)
+			} else {
+				w.Header().Add("Link", `</admin/clients?page_size=`+strconv.Itoa(pageSize)+`&page_token=`+strconv.Itoa(end)+`>; rel="next"`)
+			}
+
+			clients := make([]HydraOAuth2Client, 0, end-offset)
+			for _, id := range ids[offset:end] {
+				client := *f.clients[id]
+				client.ClientSecret = ""
+				clients = append(clients, client)
+			}
+			json.NewEncoder(w).Encode(clients)
+		}
+	})
+
+	mux.HandleFunc("/admin/clients/", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/admin/clients/")
+		client, exists := f.clients[id]
+		if !exists {
+			f.writeError(w, http.StatusNotFound, "Unable to locate the resource", "")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			result := *client
+			result.ClientSecret = ""
+			json.NewEncoder(w).Encode(result)
+		case http.MethodPut:
+			var updated HydraOAuth2Client
+			json.NewDecoder(r.Body).Decode(&updated)
+			if updated.ClientSecret == "" {
+				updated.ClientSecret = client.ClientSecret
+			}
+			f.clients[id] = &updated
+			result := updated
+			result.ClientSecret = ""
+			json.NewEncoder(w).Encode(result)
+		case http.MethodPatch:
+			var patch []map[string]string
+			json.NewDecoder(r.Body).Decode(&patch)
+			if len(patch) != 1 || patch[0]["op"] != "replace" || patch[0]["path"] != "/client_secret" {
+				f.writeError(w, http.StatusBadRequest, "invalid_request", "unexpected patch")
+				return
+			}
+			client.ClientSecret = "hashed:" + patch[0]["value"]
+			result := *client
+			result.ClientSecret = ""
+			json.NewEncoder(w).Encode(result)
+		case http.MethodDelete:
+			delete(f.clients, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	mux.HandleFunc("POST /admin/oauth2/introspect", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("token") != "valid-access-token" {
+			json.NewEncoder(w).Encode(HydraIntrospection{Active: false})
+			return
+		}
+		json.NewEncoder(w).Encode(HydraIntrospection{Active: true, Subject: "user-1", ClientID: "client-1", Scope: "openid email", ExpiresAt: 1893456000, TokenUse: "access_token"})
+	})
+
+	return mux
+}
+
+func newTestHydraClient(t *testing.T) (HydraClient, *fakeHydra) {
+	fake := &fakeHydra{clients: map[string]*HydraOAuth2Client{}}
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	return NewHydraClient(config.HydraConfig{AdminURL: server.URL + "/"}, server.Client()), fake
+}
+
+func TestHydraClient_Lifecycle(t *testing.T) {
+	client, fake := newTestHydraClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateOAuth2Client(ctx, &HydraOAuth2Client{
+		ClientName:              "Web App",
+		RedirectURIs:            []string{"https://app.example.com/callback", "http://localhost:3000/callback"},
+		GrantTypes:              []string{"authorization_code", "refresh_token"},
+		ResponseTypes:           []string{"code"},
+		Scope:                   "openid profile",
+		TokenEndpointAuthMethod: "client_secret_basic",
+	})
+	if err != nil {
+		t.Fatalf("CreateOAuth2Client() error = %v", err)
+	}
+	if created.ClientID == "" || created.ClientSecret != "generated-secret" {
+		t.Fatalf("CreateOAuth2Client() = %+v, want generated id and secret", created)
+	}
+
+	fetched, err := client.GetOAuth2Client(ctx, created.ClientID)
+	if err != nil {
+		t.Fatalf("GetOAuth2Client() error = %v", err)
+	}
+	if len(fetched.RedirectURIs) != 2 || fetched.Scope != "openid profile" {
+		t.Errorf("GetOAuth2Client() = %+v", fetched)
+	}
+
+	fetched.Scope = "openid"
+	if _, err := client.UpdateOAuth2Client(ctx, fetched); err != nil {
+		t.Fatalf("UpdateOAuth2Client() error = %v", err)
+	}
+	if fake.clients[created.ClientID].ClientSecret != "hashed:generated-secret" {
+		t.Error("UpdateOAuth2Client() lost the existing secret")
+	}
+
+	if _, err := client.SetOAuth2ClientSecret(ctx, created.ClientID, "rotated"); err != nil {
+		t.Fatalf("SetOAuth2ClientSecret() error = %v", err)
+	}
+	if fake.clients[created.ClientID].ClientSecret != "hashed:rotated" {
+		t.Error("SetOAuth2ClientSecret() did not replace the secret")
+	}
+
+	if err := client.DeleteOAuth2Client(ctx, created.ClientID); err != nil {
+		t.Fatalf("DeleteOAuth2Client() error = %v", err)
+	}
+	if _, err := client.GetOAuth2Client(ctx, created.ClientID); err == nil {
+		t.Error("GetOAuth2Client() after delete expected error")
+	}
+}
+
+func TestHydraClient_ListPagination(t *testing.T) {
+	client, _ := newTestHydraClient(t)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.CreateOAuth2Client(ctx, &HydraOAuth2Client{ClientName: "client"}); err != nil {
+			t.Fatalf("CreateOAuth2Client() error = %v", err)
+		}
+	}
+
+	first, next, err := client.ListOAuth2Clients(ctx, 2, "")
+	if err != nil {
+		t.Fatalf("ListOAuth2Clients() error = %v", err)
+	}
+	if len(first) != 2 || next != "2" {
+		t.Fatalf("ListOAuth2Clients() = %d clients, next %q, want 2 clients, next \"2\"", len(first), next)
+	}
+
+	second, next, err := client.ListOAuth2Clients(ctx, 2, next)
+	if err != nil {
+		t.Fatalf("ListOAuth2Clients() error = %v", err)
+	}
+	if len(second) != 1 || next != "" {
+		t.Errorf("ListOAuth2Clients() = %d clients, next %q, want 1 client, no next page", len(second), next)
+	}
+}
+
+func TestHydraClient_ErrorMapping(t *testing.T) {
+	client, _ := newTestHydraClient(t)
+	ctx := context.Background()
+	if _, err := client.CreateOAuth2Client(ctx, &HydraOAuth2Client{ClientID: "dup", ClientName: "client"}); err != nil {
+		t.Fatalf("CreateOAuth2Client() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode common.ErrorCode
+	}{
+		{
+			name: "not found",
+			call: func() error {
+				_, err := client.GetOAuth2Client(ctx, "missing")
+				return err
+			},
+			wantCode: common.ErrCodeOAuth2ClientNotFound,
+		},
+		{
+			name: "conflict",
+			call: func() error {
+				_, err := client.CreateOAuth2Client(ctx, &HydraOAuth2Client{ClientID: "dup", ClientName: "client"})
+				return err
+			},
+			wantCode: common.ErrCodeOAuth2ClientExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr, ok := tt.call().(*common.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Errorf("error = %v, want %v", appErr, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHydraClient_IntrospectToken(t *testing.T) {
+	client, _ := newTestHydraClient(t)
+
+	active, err := client.IntrospectToken(context.Background(), "valid-access-token")
+	if err != nil {
+		t.Fatalf("IntrospectToken() error = %v", err)
+	}
+	if !active.Active || active.Subject != "user-1" || active.Scope != "openid email" || active.TokenUse != "access_token" {
+		t.Errorf("IntrospectToken() = %+v", active)
+	}
+
+	inactive, err := client.IntrospectToken(context.Background(), "revoked")
+	if err != nil || inactive.Active {
+		t.Errorf("IntrospectToken(revoked) = %+v, %v, want inactive", inactive, err)
+	}
+}
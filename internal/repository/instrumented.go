@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"ndugu-backend/internal/metrics"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/tracing"
+)
+
+// tracerName nom de l'instrumentation des repositories
+const tracerName = "ndugu-backend/internal/repository"
+
+// instrument ouvre un span autour d'une opération de repository; la fonction retournée
+// le termine et enregistre la durée de l'opération. err est lu à la sortie de la méthode.
+func instrument(ctx context.Context, m *metrics.Metrics, repository, operation string) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "repository."+repository+"."+operation)
+	return ctx, func(err *error) {
+		m.ObserveRepository(repository, operation, start, *err)
+		tracing.End(span, *err)
+	}
+}
+
+// instrumentedUserRepository trace et mesure les opérations d'un UserRepository
+type instrumentedUserRepository struct {
+	next    UserRepository
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedUserRepository enveloppe repo pour en tracer et mesurer les opérations
+func NewInstrumentedUserRepository(repo UserRepository, m *metrics.Metrics) UserRepository {
+	return &instrumentedUserRepository{next: repo, metrics: m}
+}
+
+func (r *instrumentedUserRepository) Create(ctx context.Context, user *models.User) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "Create")
+	defer done(&err)
+	return r.next.Create(ctx, user)
+}
+
+func (r *instrumentedUserRepository) GetByID(ctx context.Context, id string) (user *models.User, err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "GetByID")
+	defer done(&err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedUserRepository) GetByEmail(ctx context.Context, email string) (user *models.User, err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "GetByEmail")
+	defer done(&err)
+	return r.next.GetByEmail(ctx, email)
+}
+
+func (r *instrumentedUserRepository) Update(ctx context.Context, user *models.User) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "Update")
+	defer done(&err)
+	return r.next.Update(ctx, user)
+}
+
+func (r *instrumentedUserRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "Delete")
+	defer done(&err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedUserRepository) List(ctx context.Context, limit, offset int) (users []*models.User, err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "List")
+	defer done(&err)
+	return r.next.List(ctx, limit, offset)
+}
+
+func (r *instrumentedUserRepository) Search(ctx context.Context, filter *models.UserFilter, limit int) (users []*models.User, err error) {
+	ctx, done := instrument(ctx, r.metrics, "user", "Search")
+	defer done(&err)
+	return r.next.Search(ctx, filter, limit)
+}
+
+// instrumentedCustomerRepository trace et mesure les opérations d'un CustomerRepository
+type instrumentedCustomerRepository struct {
+	next    CustomerRepository
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedCustomerRepository enveloppe repo pour en tracer et mesurer les opérations
+func NewInstrumentedCustomerRepository(repo CustomerRepository, m *metrics.Metrics) CustomerRepository {
+	return &instrumentedCustomerRepository{next: repo, metrics: m}
+}
+
+func (r *instrumentedCustomerRepository) Create(ctx context.Context, customer *models.Customer) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "customer", "Create")
+	defer done(&err)
+	return r.next.Create(ctx, customer)
+}
+
+func (r *instrumentedCustomerRepository) GetByID(ctx context.Context, id string) (customer *models.Customer, err error) {
+	ctx, done := instrument(ctx, r.metrics, "customer", "GetByID")
+	defer done(&err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedCustomerRepository) GetByPhone(ctx context.Context, phoneCode, phoneNumber string) (customer *models.Customer, err error) {
+	ctx, done := instrument(ctx, r.metrics, "customer", "GetByPhone")
+	defer done(&err)
+	return r.next.GetByPhone(ctx, phoneCode, phoneNumber)
+}
+
+func (r *instrumentedCustomerRepository) Update(ctx context.Context, customer *models.Customer) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "customer", "Update")
+	defer done(&err)
+	return r.next.Update(ctx, customer)
+}
+
+func (r *instrumentedCustomerRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "customer", "Delete")
+	defer done(&err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedCustomerRepository) List(ctx context.Context, limit, offset int) (customers []*models.Customer, err error) {
+	ctx, done := instrument(ctx, r.metrics, "customer", "List")
+	defer done(&err)
+	return r.next.List(ctx, limit, offset)
+}
+
+// instrumentedIdempotencyRepository trace et mesure les opérations d'un IdempotencyRepository
+type instrumentedIdempotencyRepository struct {
+	next    IdempotencyRepository
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedIdempotencyRepository enveloppe repo pour en tracer et mesurer les opérations
+func NewInstrumentedIdempotencyRepository(repo IdempotencyRepository, m *metrics.Metrics) IdempotencyRepository {
+	return &instrumentedIdempotencyRepository{next: repo, metrics: m}
+}
+
+func (r *instrumentedIdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord) (existing *models.IdempotencyRecord, reserved bool, err error) {
+	ctx, done := instrument(ctx, r.metrics, "idempotency", "Reserve")
+	defer done(&err)
+	return r.next.Reserve(ctx, record)
+}
+
+func (r *instrumentedIdempotencyRepository) Complete(ctx context.Context, key string, response []byte, expiresAt time.Time) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "idempotency", "Complete")
+	defer done(&err)
+	return r.next.Complete(ctx, key, response, expiresAt)
+}
+
+func (r *instrumentedIdempotencyRepository) Release(ctx context.Context, key string) (err error) {
+	ctx, done := instrument(ctx, r.metrics, "idempotency", "Release")
+	defer done(&err)
+	return r.next.Release(ctx, key)
+}
+
+func (r *instrumentedIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (deleted int64, err error) {
+	ctx, done := instrument(ctx, r.metrics, "idempotency", "DeleteExpired")
+	defer done(&err)
+	return r.next.DeleteExpired(ctx, before)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"ndugu-backend/internal/models"
 )
@@ -14,6 +15,8 @@ type UserRepository interface {
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, limit, offset int) ([]*models.User, error)
+	// Search retourne au plus limit utilisateurs correspondant au filtre, triés par date de création puis ID
+	Search(ctx context.Context, filter *models.UserFilter, limit int) ([]*models.User, error)
 }
 
 // CustomerRepository interface pour la gestion des clients
@@ -26,28 +29,83 @@ type CustomerRepository interface {
 	List(ctx context.Context, limit, offset int) ([]*models.Customer, error)
 }
 
+// IdempotencyRepository mémorise les réponses des requêtes portant une clé d'idempotence
+type IdempotencyRepository interface {
+	// Reserve enregistre une requête en cours si la clé est libre ou expirée à record.CreatedAt.
+	// Sinon, retourne l'enregistrement existant et false.
+	Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, bool, error)
+	// Complete mémorise la réponse d'une requête réservée jusqu'à expiresAt
+	Complete(ctx context.Context, key string, response []byte, expiresAt time.Time) error
+	// Release libère la clé d'une requête réservée qui a échoué
+	Release(ctx context.Context, key string) error
+	// DeleteExpired supprime les enregistrements expirés à la date before et retourne leur nombre
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
 // OryClient interface pour les services Ory
 type OryClient interface {
 	CreateUser(ctx context.Context, email, firstName, lastName string) (*models.User, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*models.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context, pageSize int, pageToken string) ([]*models.User, string, error)
 	ValidateSession(ctx context.Context, sessionToken string) (*models.Session, error)
-	CreateOAuth2Client(ctx context.Context, clientID, clientName, redirectURI string) (*models.OAuth2Client, error)
+	ValidateSessionCookie(ctx context.Context, cookie string) (*models.Session, error)
+	RevokeSession(ctx context.Context, sessionToken string) error
+	IntrospectToken(ctx context.Context, token string) (*models.TokenIntrospection, error)
+	CreateOAuth2Client(ctx context.Context, client *models.OAuth2Client) (*models.OAuth2Client, error)
+	GetOAuth2Client(ctx context.Context, clientID string) (*models.OAuth2Client, error)
+	ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) ([]*models.OAuth2Client, string, error)
+	UpdateOAuth2Client(ctx context.Context, client *models.OAuth2Client) (*models.OAuth2Client, error)
+	DeleteOAuth2Client(ctx context.Context, clientID string) error
+	SetOAuth2ClientSecret(ctx context.Context, clientID, secret string) (*models.OAuth2Client, error)
 	CreatePermission(ctx context.Context, namespace, object, relation, subject string) error
+	DeletePermission(ctx context.Context, namespace, object, relation, subject string) error
 	CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error)
+	DeleteSubjectPermissions(ctx context.Context, subjectID string) error
+	ExpandPermission(ctx context.Context, namespace, object, relation string, maxDepth int) (*models.SubjectTree, error)
+	ListRelations(ctx context.Context, filter models.RelationTuple, pageSize int, pageToken string) ([]*models.RelationTuple, string, error)
 }
 
 // Interfaces pour les clients Ory individuels
 type KratosClient interface {
 	CreateUser(ctx context.Context, email, firstName, lastName string) (*KratosUser, error)
 	GetUser(ctx context.Context, userID string) (*KratosUser, error)
+	UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*KratosUser, error)
+	DeleteUser(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context, pageSize int, pageToken string) ([]*KratosUser, string, error)
 	ValidateSession(ctx context.Context, sessionToken string) (*KratosSession, error)
+	ValidateSessionCookie(ctx context.Context, cookie string) (*KratosSession, error)
+	RevokeSession(ctx context.Context, sessionToken string) error
 }
 
 type HydraClient interface {
-	CreateOAuth2Client(ctx context.Context, clientID, clientName, redirectURI string) (*HydraOAuth2Client, error)
+	CreateOAuth2Client(ctx context.Context, client *HydraOAuth2Client) (*HydraOAuth2Client, error)
+	GetOAuth2Client(ctx context.Context, clientID string) (*HydraOAuth2Client, error)
+	ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) ([]*HydraOAuth2Client, string, error)
+	UpdateOAuth2Client(ctx context.Context, client *HydraOAuth2Client) (*HydraOAuth2Client, error)
+	DeleteOAuth2Client(ctx context.Context, clientID string) error
+	SetOAuth2ClientSecret(ctx context.Context, clientID, secret string) (*HydraOAuth2Client, error)
+
+	// Fournisseur de login/consentement/déconnexion
+	GetLoginRequest(ctx context.Context, challenge string) (*HydraLoginRequest, error)
+	AcceptLoginRequest(ctx context.Context, challenge string, body *HydraAcceptLogin) (string, error)
+	RejectLoginRequest(ctx context.Context, challenge string, body *HydraRejectRequest) (string, error)
+	GetConsentRequest(ctx context.Context, challenge string) (*HydraConsentRequest, error)
+	AcceptConsentRequest(ctx context.Context, challenge string, body *HydraAcceptConsent) (string, error)
+	RejectConsentRequest(ctx context.Context, challenge string, body *HydraRejectRequest) (string, error)
+	GetLogoutRequest(ctx context.Context, challenge string) (*HydraLogoutRequest, error)
+	AcceptLogoutRequest(ctx context.Context, challenge string) (string, error)
+
+	// Introspection des jetons d'accès
+	IntrospectToken(ctx context.Context, token string) (*HydraIntrospection, error)
 }
 
 type KetoClient interface {
 	CreatePermission(ctx context.Context, namespace, object, relation, subject string) error
+	DeletePermission(ctx context.Context, namespace, object, relation, subject string) error
 	CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error)
+	DeleteSubjectPermissions(ctx context.Context, subjectID string) error
+	ExpandPermission(ctx context.Context, namespace, object, relation string, maxDepth int) (*KetoSubjectTree, error)
+	ListRelations(ctx context.Context, filter KetoRelationQuery, pageSize int, pageToken string) ([]*KetoRelationTuple, string, error)
 }
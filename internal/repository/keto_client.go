@@ -1,28 +1,430 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/metrics"
 )
 
-// ketoClient implémentation du client Keto
+// ketoClient implémentation du client Keto basée sur l'API REST
 type ketoClient struct {
-	// Temporairement vide - sera implémenté quand Keto sera corrigé
+	readURL    string
+	writeURL   string
+	httpClient *http.Client
 }
 
 // NewKetoClient crée une nouvelle instance du client Keto
-func NewKetoClient() KetoClient {
-	return &ketoClient{}
+func NewKetoClient(cfg config.KetoConfig, httpClient *http.Client) KetoClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &ketoClient{
+		readURL:    strings.TrimSuffix(cfg.ReadURL, "/"),
+		writeURL:   strings.TrimSuffix(cfg.WriteURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// KetoSubjectSet représente un ensemble de sujets "namespace:object#relation"
+type KetoSubjectSet struct {
+	Namespace string `json:"namespace"`
+	Object    string `json:"object"`
+	Relation  string `json:"relation"`
+}
+
+// String retourne la représentation textuelle du subject set
+func (s KetoSubjectSet) String() string {
+	return s.Namespace + ":" + s.Object + "#" + s.Relation
+}
+
+// KetoRelationTuple représente un tuple de relation Keto
+type KetoRelationTuple struct {
+	Namespace  string          `json:"namespace"`
+	Object     string          `json:"object"`
+	Relation   string          `json:"relation"`
+	SubjectID  string          `json:"subject_id,omitempty"`
+	SubjectSet *KetoSubjectSet `json:"subject_set,omitempty"`
+}
+
+// Subject retourne le sujet du tuple: identifiant ou subject set "namespace:object#relation"
+func (t *KetoRelationTuple) Subject() string {
+	if t.SubjectSet != nil {
+		return t.SubjectSet.String()
+	}
+	return t.SubjectID
 }
 
-// CreatePermission crée une permission via Keto
+// KetoRelationQuery filtre des tuples de relation; les champs vides ne filtrent pas
+type KetoRelationQuery struct {
+	Namespace string
+	Object    string
+	Relation  string
+	Subject   string
+}
+
+// KetoSubjectTree nœud de l'arbre des sujets renvoyé par l'expansion Keto.
+// Type vaut union, exclusion, intersection, leaf, etc.; Tuple désigne le sujet du nœud.
+type KetoSubjectTree struct {
+	Type     string             `json:"type"`
+	Tuple    *KetoRelationTuple `json:"tuple,omitempty"`
+	Children []*KetoSubjectTree `json:"children,omitempty"`
+
+	// Sujet du nœud dans les réponses des versions de Keto antérieures à v0.11
+	SubjectID  string          `json:"subject_id,omitempty"`
+	SubjectSet *KetoSubjectSet `json:"subject_set,omitempty"`
+}
+
+// Subject retourne le sujet du nœud: identifiant ou subject set "namespace:object#relation"
+func (n *KetoSubjectTree) Subject() string {
+	switch {
+	case n.Tuple != nil:
+		return n.Tuple.Subject()
+	case n.SubjectSet != nil:
+		return n.SubjectSet.String()
+	}
+	return n.SubjectID
+}
+
+// newKetoRelationTuple construit un tuple en interprétant le sujet
+func newKetoRelationTuple(namespace, object, relation, subject string) (*KetoRelationTuple, error) {
+	tuple := &KetoRelationTuple{
+		Namespace: namespace,
+		Object:    object,
+		Relation:  relation,
+	}
+
+	subjectSet, err := ParseKetoSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+	if subjectSet != nil {
+		tuple.SubjectSet = subjectSet
+	} else {
+		tuple.SubjectID = subject
+	}
+
+	return tuple, nil
+}
+
+// ParseKetoSubject interprète un sujet au format "namespace:object#relation".
+// Retourne nil si le sujet est un simple identifiant.
+func ParseKetoSubject(subject string) (*KetoSubjectSet, error) {
+	hash := strings.LastIndex(subject, "#")
+	if hash < 0 {
+		return nil, nil
+	}
+
+	colon := strings.Index(subject[:hash], ":")
+	if colon <= 0 || colon == hash-1 {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Sujet invalide, format attendu namespace:object#relation", subject)
+	}
+
+	return &KetoSubjectSet{
+		Namespace: subject[:colon],
+		Object:    subject[colon+1 : hash],
+		Relation:  subject[hash+1:],
+	}, nil
+}
+
+// query retourne les paramètres de requête correspondant au tuple
+func (t *KetoRelationTuple) query() url.Values {
+	values := url.Values{}
+	values.Set("namespace", t.Namespace)
+	values.Set("object", t.Object)
+	values.Set("relation", t.Relation)
+	if t.SubjectSet != nil {
+		values.Set("subject_set.namespace", t.SubjectSet.Namespace)
+		values.Set("subject_set.object", t.SubjectSet.Object)
+		values.Set("subject_set.relation", t.SubjectSet.Relation)
+	} else {
+		values.Set("subject_id", t.SubjectID)
+	}
+	return values
+}
+
+// ketoErrorResponse représente le corps d'erreur renvoyé par Keto
+type ketoErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Reason  string `json:"reason"`
+	} `json:"error"`
+}
+
+// CreatePermission crée un tuple de relation via l'API d'écriture Keto
 func (c *ketoClient) CreatePermission(ctx context.Context, namespace, object, relation, subject string) error {
-	// Temporairement désactivé - à implémenter quand Keto sera corrigé
-	return fmt.Errorf("Keto temporairement désactivé")
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKeto, "CreatePermission")
+	tuple, err := newKetoRelationTuple(namespace, object, relation, subject)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(tuple)
+	if err != nil {
+		return common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la création de la permission", err.Error())
+	}
+
+	resp, err := c.do(ctx, http.MethodPut, c.writeURL+"/admin/relation-tuples", bytes.NewReader(body))
+	if err != nil {
+		return common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la création de la permission", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return ketoError(resp, "Erreur lors de la création de la permission")
+	}
+
+	return nil
 }
 
-// CheckPermission vérifie une permission via Keto
+// DeletePermission supprime un tuple de relation via l'API d'écriture Keto
+func (c *ketoClient) DeletePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKeto, "DeletePermission")
+	tuple, err := newKetoRelationTuple(namespace, object, relation, subject)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodDelete, c.writeURL+"/admin/relation-tuples?"+tuple.query().Encode(), nil)
+	if err != nil {
+		return common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la suppression de la permission", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return ketoError(resp, "Erreur lors de la suppression de la permission")
+	}
+
+	return nil
+}
+
+// DeleteSubjectPermissions supprime, dans tous les namespaces, les tuples de relation qui désignent subjectID:
+// ceux dont il est le sujet, ceux dont il est l'objet et les subject sets "namespace:subjectID#relation"
+// portant sur une relation dont il est l'objet. Un subject set vers une relation de subjectID sans aucun tuple
+// ne confère rien et n'est pas recherché.
+func (c *ketoClient) DeleteSubjectPermissions(ctx context.Context, subjectID string) error {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKeto, "DeleteSubjectPermissions")
+	if subjectID == "" {
+		// Une requête sans filtre supprimerait tous les tuples
+		return common.NewAppError(common.ErrCodeInvalidInput, "Sujet requis")
+	}
+
+	// Les relations dont le sujet est l'objet sont relevées avant la suppression de ses tuples,
+	// pour retrouver ensuite les subject sets qui y renvoient
+	subjectSets, err := c.objectSubjectSets(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+
+	queries := []url.Values{{"subject_id": {subjectID}}, {"object": {subjectID}}}
+	for _, subjectSet := range subjectSets {
+		queries = append(queries, url.Values{
+			"subject_set.namespace": {subjectSet.Namespace},
+			"subject_set.object":    {subjectSet.Object},
+			"subject_set.relation":  {subjectSet.Relation},
+		})
+	}
+
+	for _, query := range queries {
+		if err := c.deleteTuples(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// objectSubjectSets retourne les subject sets distincts "namespace:object#relation" des tuples dont object est l'objet
+func (c *ketoClient) objectSubjectSets(ctx context.Context, object string) ([]KetoSubjectSet, error) {
+	var subjectSets []KetoSubjectSet
+	seen := map[KetoSubjectSet]bool{}
+	pageToken := ""
+	for {
+		tuples, next, err := c.ListRelations(ctx, KetoRelationQuery{Object: object}, 0, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, tuple := range tuples {
+			subjectSet := KetoSubjectSet{Namespace: tuple.Namespace, Object: tuple.Object, Relation: tuple.Relation}
+			if !seen[subjectSet] {
+				seen[subjectSet] = true
+				subjectSets = append(subjectSets, subjectSet)
+			}
+		}
+		if next == "" {
+			return subjectSets, nil
+		}
+		pageToken = next
+	}
+}
+
+// deleteTuples supprime les tuples correspondant à la requête via l'API d'écriture Keto
+func (c *ketoClient) deleteTuples(ctx context.Context, query url.Values) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.writeURL+"/admin/relation-tuples?"+query.Encode(), nil)
+	if err != nil {
+		return common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la suppression des permissions du sujet", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return ketoError(resp, "Erreur lors de la suppression des permissions du sujet")
+	}
+
+	return nil
+}
+
+// CheckPermission vérifie un tuple de relation via l'API de lecture Keto
 func (c *ketoClient) CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error) {
-	// Temporairement désactivé - à implémenter quand Keto sera corrigé
-	return true, nil // Simulation
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKeto, "CheckPermission")
+	tuple, err := newKetoRelationTuple(namespace, object, relation, subject)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.readURL+"/relation-tuples/check/openapi?"+tuple.query().Encode(), nil)
+	if err != nil {
+		return false, common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la vérification de la permission", err.Error())
+	}
+	defer resp.Body.Close()
+
+	// Keto répond 403 avec allowed=false sur certaines versions
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusForbidden {
+		return false, ketoError(resp, "Erreur lors de la vérification de la permission")
+	}
+
+	var result struct {
+		Allowed bool `json:"allowed"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, common.NewAppError(common.ErrCodeKetoError, "Réponse Keto invalide", err.Error())
+	}
+
+	return result.Allowed, nil
+}
+
+// ExpandPermission retourne l'arbre des sujets ayant la relation sur l'objet, via l'API de lecture Keto.
+// maxDepth limite la profondeur de l'arbre (0: limite de Keto). Retourne nil si aucun sujet n'a la relation.
+func (c *ketoClient) ExpandPermission(ctx context.Context, namespace, object, relation string, maxDepth int) (*KetoSubjectTree, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKeto, "ExpandPermission")
+	query := url.Values{}
+	query.Set("namespace", namespace)
+	query.Set("object", object)
+	query.Set("relation", relation)
+	if maxDepth > 0 {
+		query.Set("max-depth", strconv.Itoa(maxDepth))
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.readURL+"/relation-tuples/expand?"+query.Encode(), nil)
+	if err != nil {
+		return nil, common.NewAppError(common.ErrCodeKetoError, "Erreur lors de l'expansion de la permission", err.Error())
+	}
+	defer resp.Body.Close()
+
+	// Keto répond 404 lorsque aucun tuple ne porte la relation
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, ketoError(resp, "Erreur lors de l'expansion de la permission")
+	}
+
+	var tree *KetoSubjectTree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, common.NewAppError(common.ErrCodeKetoError, "Réponse Keto invalide", err.Error())
+	}
+
+	return tree, nil
+}
+
+// ListRelations liste les tuples de relation correspondant au filtre via l'API de lecture Keto.
+// pageToken est le jeton opaque renvoyé par Keto; le jeton de la page suivante est vide en fin de liste.
+func (c *ketoClient) ListRelations(ctx context.Context, filter KetoRelationQuery, pageSize int, pageToken string) ([]*KetoRelationTuple, string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKeto, "ListRelations")
+	query := url.Values{}
+	for key, value := range map[string]string{"namespace": filter.Namespace, "object": filter.Object, "relation": filter.Relation} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if filter.Subject != "" {
+		subjectSet, err := ParseKetoSubject(filter.Subject)
+		if err != nil {
+			return nil, "", err
+		}
+		if subjectSet != nil {
+			query.Set("subject_set.namespace", subjectSet.Namespace)
+			query.Set("subject_set.object", subjectSet.Object)
+			query.Set("subject_set.relation", subjectSet.Relation)
+		} else {
+			query.Set("subject_id", filter.Subject)
+		}
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.readURL+"/relation-tuples?"+query.Encode(), nil)
+	if err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la récupération des relations", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", ketoError(resp, "Erreur lors de la récupération des relations")
+	}
+
+	var result struct {
+		RelationTuples []*KetoRelationTuple `json:"relation_tuples"`
+		NextPageToken  string               `json:"next_page_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeKetoError, "Réponse Keto invalide", err.Error())
+	}
+
+	return result.RelationTuples, result.NextPageToken, nil
+}
+
+// do exécute une requête HTTP vers Keto
+func (c *ketoClient) do(ctx context.Context, method, rawURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la requête: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// ketoError convertit une réponse d'erreur Keto en AppError
+func ketoError(resp *http.Response, message string) error {
+	details := fmt.Sprintf("status %d", resp.StatusCode)
+
+	var errResp ketoErrorResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&errResp); err == nil && errResp.Error.Message != "" {
+		details = fmt.Sprintf("status %d: %s", resp.StatusCode, errResp.Error.Message)
+		if errResp.Error.Reason != "" {
+			details += " (" + errResp.Error.Reason + ")"
+		}
+	}
+
+	return common.NewAppError(common.ErrCodeKetoError, message, details)
 }
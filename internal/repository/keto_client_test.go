@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+)
+
+// fakeKeto simule les API de lecture et d'écriture de Keto
+type fakeKeto struct {
+	mutex  sync.Mutex
+	tuples map[string]map[string]string
+}
+
+func tupleKey(q map[string]string) string {
+	return q["namespace"] + ":" + q["object"] + "#" + q["relation"] + "@" +
+		q["subject_id"] + q["subject_set.namespace"] + ":" + q["subject_set.object"] + "#" + q["subject_set.relation"]
+}
+
+func queryMap(r *http.Request) map[string]string {
+	q := map[string]string{}
+	for key, values := range r.URL.Query() {
+		q[key] = values[0]
+	}
+	return q
+}
+
+// matching retourne les tuples dont chaque champ filtré vaut la valeur demandée
+func (f *fakeKeto) matching(filter map[string]string) []string {
+	var keys []string
+	for key, fields := range f.tuples {
+		matches := true
+		for field, value := range filter {
+			if field != "page_size" && field != "page_token" && fields[field] != value {
+				matches = false
+			}
+		}
+		if matches {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (f *fakeKeto) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/admin/relation-tuples", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			var tuple KetoRelationTuple
+			if err := json.NewDecoder(r.Body).Decode(&tuple); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if tuple.Namespace == "unknown" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":404,"status":"Not Found","message":"Unknown namespace with name \"unknown\"."}}`))
+				return
+			}
+			fields := map[string]string{}
+			for key, values := range tuple.query() {
+				if values[0] != "" {
+					fields[key] = values[0]
+				}
+			}
+			f.tuples[tupleKey(fields)] = fields
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(tuple)
+		case http.MethodDelete:
+			for _, key := range f.matching(queryMap(r)) {
+				delete(f.tuples, key)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("GET /relation-tuples", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		tuples := []*KetoRelationTuple{}
+		for _, key := range f.matching(queryMap(r)) {
+			fields := f.tuples[key]
+			tuple, _ := newKetoRelationTuple(fields["namespace"], fields["object"], fields["relation"], fields["subject_id"])
+			if fields["subject_set.namespace"] != "" {
+				tuple.SubjectSet = &KetoSubjectSet{Namespace: fields["subject_set.namespace"], Object: fields["subject_set.object"], Relation: fields["subject_set.relation"]}
+			}
+			tuples = append(tuples, tuple)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"relation_tuples": tuples, "next_page_token": ""})
+	})
+
+	mux.HandleFunc("/relation-tuples/check/openapi", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		_, allowed := f.tuples[tupleKey(queryMap(r))]
+		json.NewEncoder(w).Encode(map[string]bool{"allowed": allowed})
+	})
+
+	return mux
+}
+
+func newTestKetoClient(t *testing.T) KetoClient {
+	fake := &fakeKeto{tuples: map[string]map[string]string{}}
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	return NewKetoClient(config.KetoConfig{ReadURL: server.URL, WriteURL: server.URL + "/"}, server.Client())
+}
+
+func TestKetoClient_CreateCheckDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+	}{
+		{name: "subject id", subject: "user-1"},
+		{name: "subject set", subject: "groups:admins#member"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestKetoClient(t)
+			ctx := context.Background()
+
+			if allowed, err := client.CheckPermission(ctx, "files", "doc-1", "view", tt.subject); err != nil || allowed {
+				t.Fatalf("CheckPermission() before create = %v, %v, want false, nil", allowed, err)
+			}
+
+			if err := client.CreatePermission(ctx, "files", "doc-1", "view", tt.subject); err != nil {
+				t.Fatalf("CreatePermission() error = %v", err)
+			}
+			if allowed, err := client.CheckPermission(ctx, "files", "doc-1", "view", tt.subject); err != nil || !allowed {
+				t.Fatalf("CheckPermission() after create = %v, %v, want true, nil", allowed, err)
+			}
+
+			if err := client.DeletePermission(ctx, "files", "doc-1", "view", tt.subject); err != nil {
+				t.Fatalf("DeletePermission() error = %v", err)
+			}
+			if allowed, err := client.CheckPermission(ctx, "files", "doc-1", "view", tt.subject); err != nil || allowed {
+				t.Fatalf("CheckPermission() after delete = %v, %v, want false, nil", allowed, err)
+			}
+		})
+	}
+}
+
+func TestKetoClient_DeleteSubjectPermissions(t *testing.T) {
+	// Arrange: user-1 est sujet, objet, et référencé par des subject sets
+	client := newTestKetoClient(t)
+	ctx := context.Background()
+	client.CreatePermission(ctx, "files", "doc-1", "view", "user-1")
+	client.CreatePermission(ctx, "groups", "admins", "member", "user-1")
+	client.CreatePermission(ctx, "groups", "user-1", "member", "user-3")
+	client.CreatePermission(ctx, "files", "doc-2", "view", "groups:user-1#member")
+	client.CreatePermission(ctx, "files", "doc-1", "view", "user-2")
+	client.CreatePermission(ctx, "files", "doc-2", "view", "groups:admins#member")
+
+	// Act
+	err := client.DeleteSubjectPermissions(ctx, "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DeleteSubjectPermissions() error = %v", err)
+	}
+	deleted := [][4]string{
+		{"files", "doc-1", "view", "user-1"},
+		{"groups", "admins", "member", "user-1"},
+		{"groups", "user-1", "member", "user-3"},
+		{"files", "doc-2", "view", "groups:user-1#member"},
+	}
+	for _, tuple := range deleted {
+		if allowed, _ := client.CheckPermission(ctx, tuple[0], tuple[1], tuple[2], tuple[3]); allowed {
+			t.Errorf("CheckPermission(%v) = true after subject deletion", tuple)
+		}
+	}
+	for _, tuple := range [][4]string{{"files", "doc-1", "view", "user-2"}, {"files", "doc-2", "view", "groups:admins#member"}} {
+		if allowed, _ := client.CheckPermission(ctx, tuple[0], tuple[1], tuple[2], tuple[3]); !allowed {
+			t.Errorf("CheckPermission(%v) = false, want other tuples kept", tuple)
+		}
+	}
+	if err := client.DeleteSubjectPermissions(ctx, ""); err == nil {
+		t.Error("DeleteSubjectPermissions(\"\") error = nil, want INVALID_INPUT")
+	}
+}
+
+func TestKetoClient_ErrorMapping(t *testing.T) {
+	client := newTestKetoClient(t)
+
+	err := client.CreatePermission(context.Background(), "unknown", "doc-1", "view", "user-1")
+
+	appErr, ok := err.(*common.AppError)
+	if !ok {
+		t.Fatalf("CreatePermission() error = %T, want *common.AppError", err)
+	}
+	if appErr.Code != common.ErrCodeKetoError {
+		t.Errorf("CreatePermission() code = %v, want %v", appErr.Code, common.ErrCodeKetoError)
+	}
+	if appErr.Details != `status 404: Unknown namespace with name "unknown".` {
+		t.Errorf("CreatePermission() details = %q", appErr.Details)
+	}
+}
+
+func TestKetoClient_Unreachable(t *testing.T) {
+	client := NewKetoClient(config.KetoConfig{ReadURL: "http://127.0.0.1:1", WriteURL: "http://127.0.0.1:1"}, nil)
+
+	_, err := client.CheckPermission(context.Background(), "files", "doc-1", "view", "user-1")
+
+	if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeKetoError {
+		t.Errorf("CheckPermission() error = %v, want KETO_ERROR", err)
+	}
+}
+
+func TestParseKetoSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		want    *KetoSubjectSet
+		wantErr bool
+	}{
+		{name: "subject id", subject: "user-1"},
+		{name: "subject set", subject: "groups:admins#member", want: &KetoSubjectSet{Namespace: "groups", Object: "admins", Relation: "member"}},
+		{name: "subject set without relation", subject: "groups:admins#", want: &KetoSubjectSet{Namespace: "groups", Object: "admins"}},
+		{name: "missing namespace", subject: ":admins#member", wantErr: true},
+		{name: "missing object", subject: "groups:#member", wantErr: true},
+		{name: "missing colon", subject: "groups#member", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKetoSubject(tt.subject)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKetoSubject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ParseKetoSubject() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKetoClient_ExpandPermission(t *testing.T) {
+	// Arrange: arbre au format de Keto v0.11 pour doc-1, aucun tuple pour les autres objets
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/relation-tuples/expand" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.Query()
+		if query.Get("object") != "doc-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"type":"union","tuple":{"namespace":"files","object":"doc-1","relation":"view","subject_set":{"namespace":"files","object":"doc-1","relation":"view"}},"children":[
+			{"type":"leaf","tuple":{"namespace":"files","object":"doc-1","relation":"view","subject_id":"user-1"}},
+			{"type":"union","tuple":{"namespace":"files","object":"doc-1","relation":"view","subject_set":{"namespace":"groups","object":"admins","relation":"member"}},"children":[
+				{"type":"leaf","tuple":{"namespace":"groups","object":"admins","relation":"member","subject_id":"user-2"}}
+			]}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+	client := NewKetoClient(config.KetoConfig{ReadURL: server.URL, WriteURL: server.URL}, server.Client())
+
+	// Act
+	tree, err := client.ExpandPermission(context.Background(), "files", "doc-1", "view", 3)
+	missing, missingErr := client.ExpandPermission(context.Background(), "files", "doc-2", "view", 0)
+
+	// Assert
+	if err != nil || missingErr != nil {
+		t.Fatalf("ExpandPermission() errors = %v, %v", err, missingErr)
+	}
+	if got := query.Get("max-depth"); got != "" {
+		t.Errorf("max-depth = %q for maxDepth 0, want unset", got)
+	}
+	if missing != nil {
+		t.Errorf("ExpandPermission(doc-2) = %+v, want nil", missing)
+	}
+	got := []string{tree.Type + " " + tree.Subject()}
+	for _, child := range tree.Children {
+		got = append(got, child.Type+" "+child.Subject())
+	}
+	got = append(got, tree.Children[1].Children[0].Subject())
+	want := []string{"union files:doc-1#view", "leaf user-1", "union groups:admins#member", "user-2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandPermission() nodes = %v, want %v", got, want)
+	}
+}
+
+func TestKetoClient_ListRelations(t *testing.T) {
+	// Arrange
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"relation_tuples":[
+			{"namespace":"files","object":"doc-1","relation":"view","subject_set":{"namespace":"groups","object":"admins","relation":"member"}},
+			{"namespace":"files","object":"doc-2","relation":"view","subject_set":{"namespace":"groups","object":"admins","relation":"member"}}
+		],"next_page_token":"next-token"}`))
+	}))
+	t.Cleanup(server.Close)
+	client := NewKetoClient(config.KetoConfig{ReadURL: server.URL, WriteURL: server.URL}, server.Client())
+
+	// Act
+	tuples, next, err := client.ListRelations(context.Background(), KetoRelationQuery{Namespace: "files", Subject: "groups:admins#member"}, 2, "token-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListRelations() error = %v", err)
+	}
+	wantQuery := url.Values{
+		"namespace": {"files"}, "subject_set.namespace": {"groups"}, "subject_set.object": {"admins"},
+		"subject_set.relation": {"member"}, "page_size": {"2"}, "page_token": {"token-1"},
+	}
+	if !reflect.DeepEqual(query, wantQuery) {
+		t.Errorf("query = %v, want %v", query, wantQuery)
+	}
+	if len(tuples) != 2 || tuples[1].Object != "doc-2" || tuples[1].Subject() != "groups:admins#member" || next != "next-token" {
+		t.Errorf("ListRelations() = %+v, %q", tuples, next)
+	}
+}
@@ -2,50 +2,146 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	kratos "github.com/ory/kratos-client-go"
+
 	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/metrics"
 )
 
+// KratosUser représente une identité Kratos
+type KratosUser struct {
+	ID        string                 `json:"id"`
+	Email     string                 `json:"email"`
+	Name      map[string]interface{} `json:"name"`
+	Traits    map[string]interface{} `json:"traits"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// KratosSession représente une session Kratos telle que renvoyée par /sessions/whoami
+type KratosSession struct {
+	Id                    string     `json:"id"`
+	Active                bool       `json:"active"`
+	Identity              KratosUser `json:"identity"`
+	ExpiresAt             time.Time  `json:"expires_at"`
+	AuthenticatedAt       time.Time  `json:"authenticated_at"`
+	IssuedAt              time.Time  `json:"issued_at"`
+	AAL                   string     `json:"authenticator_assurance_level"`
+	AuthenticationMethods []string   `json:"authentication_methods"`
+}
+
 // kratosClient implémentation du client Kratos
 type kratosClient struct {
-	client *auth.OryClient
+	client     *auth.OryClient
+	adminURL   string
+	httpClient *http.Client
 }
 
-// NewKratosClient crée une nouvelle instance du client Kratos
-func NewKratosClient() KratosClient {
+// NewKratosClient crée une nouvelle instance du client Kratos.
+// Les identités passent par l'API admin, les sessions par l'API publique.
+func NewKratosClient(cfg config.KratosConfig, httpClient *http.Client) KratosClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
 	return &kratosClient{
-		client: auth.NewOryClient(),
+		client:     auth.NewOryClientWithConfig(config.OryConfig{Kratos: cfg}, httpClient),
+		adminURL:   strings.TrimSuffix(cfg.AdminURL, "/"),
+		httpClient: httpClient,
 	}
 }
 
 // CreateUser crée un utilisateur via Kratos
 func (c *kratosClient) CreateUser(ctx context.Context, email, firstName, lastName string) (*KratosUser, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "CreateUser")
 	user, err := c.client.CreateUser(ctx, email, firstName, lastName)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de l'utilisateur: %w", err)
+		return nil, kratosIdentityError(err, "Erreur lors de la création de l'utilisateur")
 	}
-
-	// Convertir en KratosUser
-	return &KratosUser{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Traits:    user.Traits,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toKratosUser(user), nil
 }
 
 // GetUser récupère un utilisateur via Kratos
 func (c *kratosClient) GetUser(ctx context.Context, userID string) (*KratosUser, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "GetUser")
 	user, err := c.client.GetUser(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
+		return nil, kratosIdentityError(err, "Erreur lors de la récupération de l'utilisateur")
+	}
+	return toKratosUser(user), nil
+}
+
+// UpdateUser remplace l'email et le nom d'un utilisateur via Kratos
+func (c *kratosClient) UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*KratosUser, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "UpdateUser")
+	user, err := c.client.UpdateUser(ctx, userID, email, firstName, lastName)
+	if err != nil {
+		return nil, kratosIdentityError(err, "Erreur lors de la mise à jour de l'utilisateur")
+	}
+	return toKratosUser(user), nil
+}
+
+// DeleteUser supprime un utilisateur via Kratos
+func (c *kratosClient) DeleteUser(ctx context.Context, userID string) error {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "DeleteUser")
+	if err := c.client.DeleteUser(ctx, userID); err != nil {
+		return kratosIdentityError(err, "Erreur lors de la suppression de l'utilisateur")
+	}
+	return nil
+}
+
+// ListUsers liste les identités Kratos et retourne le jeton de la page suivante.
+// La pagination par jeton (page_size, page_token) n'est pas exposée par le SDK: l'API admin est appelée directement.
+func (c *kratosClient) ListUsers(ctx context.Context, pageSize int, pageToken string) ([]*KratosUser, string, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "ListUsers")
+	query := url.Values{}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.adminURL+"/admin/identities?"+query.Encode(), nil)
+	if err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeKratosError, "Erreur lors de la récupération des utilisateurs", err.Error())
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeKratosError, "Erreur lors de la récupération des utilisateurs", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", common.NewAppError(common.ErrCodeKratosError, "Erreur lors de la récupération des utilisateurs", fmt.Sprintf("status %d", resp.StatusCode))
+	}
+
+	var identities []kratos.Identity
+	if err := json.NewDecoder(resp.Body).Decode(&identities); err != nil {
+		return nil, "", common.NewAppError(common.ErrCodeKratosError, "Réponse Kratos invalide", err.Error())
+	}
+
+	users := make([]*KratosUser, 0, len(identities))
+	for i := range identities {
+		users = append(users, fromKratosIdentity(&identities[i]))
 	}
+	return users, nextPageToken(resp.Header.Values("Link")), nil
+}
 
-	// Convertir en KratosUser
+// toKratosUser convertit un utilisateur du client Ory en KratosUser
+func toKratosUser(user *auth.User) *KratosUser {
 	return &KratosUser{
 		ID:        user.ID,
 		Email:     user.Email,
@@ -53,27 +149,89 @@ func (c *kratosClient) GetUser(ctx context.Context, userID string) (*KratosUser,
 		Traits:    user.Traits,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
-	}, nil
+	}
+}
+
+// kratosIdentityError convertit une erreur de l'API admin des identités en AppError
+func kratosIdentityError(err error, message string) error {
+	switch {
+	case errors.Is(err, auth.ErrIdentityNotFound):
+		return common.ErrUserNotFound
+	case errors.Is(err, auth.ErrIdentityConflict):
+		return common.ErrUserExists
+	}
+	return common.NewAppError(common.ErrCodeKratosError, message, err.Error())
 }
 
-// ValidateSession valide une session via Kratos
+// ValidateSession valide un token de session (en-tête X-Session-Token) via Kratos
 func (c *kratosClient) ValidateSession(ctx context.Context, sessionToken string) (*KratosSession, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "ValidateSession")
 	session, err := c.client.ValidateSession(ctx, sessionToken)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la validation de la session: %w", err)
-	}
-
-	// Convertir en KratosSession
-	return &KratosSession{
-		Id: session.Id,
-		Identity: KratosUser{
-			ID:        session.Identity.Id,
-			Email:     "", // Sera extrait depuis les traits
-			Name:      make(map[string]interface{}),
-			Traits:    session.Identity.Traits.(map[string]interface{}),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
-		ExpiresAt: time.Now().Add(24 * time.Hour), // Exemple
-	}, nil
+		return nil, kratosSessionError(err)
+	}
+	return fromKratosSession(session), nil
+}
+
+// ValidateSessionCookie valide un cookie de session navigateur via Kratos
+func (c *kratosClient) ValidateSessionCookie(ctx context.Context, cookie string) (*KratosSession, error) {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "ValidateSessionCookie")
+	session, err := c.client.ValidateSessionCookie(ctx, cookie)
+	if err != nil {
+		return nil, kratosSessionError(err)
+	}
+	return fromKratosSession(session), nil
+}
+
+// RevokeSession révoque un token de session via Kratos
+func (c *kratosClient) RevokeSession(ctx context.Context, sessionToken string) error {
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "RevokeSession")
+	if err := c.client.RevokeSession(ctx, sessionToken); err != nil {
+		return kratosSessionError(err)
+	}
+	return nil
+}
+
+// kratosSessionError distingue une session refusée d'une indisponibilité de Kratos
+func kratosSessionError(err error) error {
+	if errors.Is(err, auth.ErrInvalidSession) {
+		return common.NewAppError(common.ErrCodeInvalidSession, "Session invalide", err.Error())
+	}
+	return common.NewAppError(common.ErrCodeKratosError, "Erreur lors de la validation de la session", err.Error())
+}
+
+// fromKratosSession convertit une session du SDK Kratos en KratosSession
+func fromKratosSession(session *kratos.Session) *KratosSession {
+	result := &KratosSession{
+		Id:              session.Id,
+		Active:          session.GetActive(),
+		Identity:        *fromKratosIdentity(&session.Identity),
+		ExpiresAt:       session.GetExpiresAt(),
+		AuthenticatedAt: session.GetAuthenticatedAt(),
+		IssuedAt:        session.GetIssuedAt(),
+	}
+	if session.AuthenticatorAssuranceLevel != nil {
+		result.AAL = string(*session.AuthenticatorAssuranceLevel)
+	}
+	for _, method := range session.AuthenticationMethods {
+		result.AuthenticationMethods = append(result.AuthenticationMethods, method.GetMethod())
+	}
+
+	return result
+}
+
+// fromKratosIdentity convertit une identité du SDK Kratos en KratosUser
+func fromKratosIdentity(identity *kratos.Identity) *KratosUser {
+	traits, _ := identity.Traits.(map[string]interface{})
+	email, _ := traits["email"].(string)
+	name, _ := traits["name"].(map[string]interface{})
+
+	return &KratosUser{
+		ID:        identity.Id,
+		Email:     email,
+		Name:      name,
+		Traits:    traits,
+		CreatedAt: identity.GetCreatedAt(),
+		UpdatedAt: identity.GetUpdatedAt(),
+	}
 }
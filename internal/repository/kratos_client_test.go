@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+)
+
+const whoamiSession = `{
+	"id": "session-1",
+	"active": true,
+	"expires_at": "2030-01-02T15:04:05Z",
+	"authenticated_at": "2030-01-01T15:04:05Z",
+	"issued_at": "2030-01-01T15:00:00Z",
+	"authenticator_assurance_level": "aal2",
+	"authentication_methods": [
+		{"method": "password", "aal": "aal1", "completed_at": "2030-01-01T15:00:00Z"},
+		{"method": "totp", "aal": "aal2", "completed_at": "2030-01-01T15:04:05Z"}
+	],
+	"identity": {
+		"id": "user-1",
+		"schema_id": "default",
+		"schema_url": "http://kratos/schemas/default",
+		"traits": {"email": "jane@example.com", "name": {"first": "Jane", "last": "Doe"}}
+	}
+}`
+
+// newTestKratosClient démarre un faux /sessions/whoami acceptant le token
+// "valid-token" en en-tête ou le cookie "valid-cookie"; le token peut être révoqué
+// par /self-service/logout/api
+func newTestKratosClient(t *testing.T) KratosClient {
+	var revoked atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sessions/whoami", func(w http.ResponseWriter, r *http.Request) {
+		cookie, _ := r.Cookie("ory_kratos_session")
+		switch {
+		case r.Header.Get("X-Session-Token") == "valid-token" && !revoked.Load(), cookie != nil && cookie.Value == "valid-cookie":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(whoamiSession))
+		case r.Header.Get("X-Session-Token") == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+	mux.HandleFunc("DELETE /self-service/logout/api", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SessionToken string `json:"session_token"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.SessionToken != "valid-token" || revoked.Swap(true) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewKratosClient(config.KratosConfig{PublicURL: server.URL + "/", AdminURL: server.URL}, server.Client())
+}
+
+func TestKratosClient_ValidateSession(t *testing.T) {
+	client := newTestKratosClient(t)
+	ctx := context.Background()
+
+	for name, validate := range map[string]func() (*KratosSession, error){
+		"header token": func() (*KratosSession, error) { return client.ValidateSession(ctx, "valid-token") },
+		"cookie":       func() (*KratosSession, error) { return client.ValidateSessionCookie(ctx, "valid-cookie") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			session, err := validate()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if session.Identity.ID != "user-1" || session.Identity.Email != "jane@example.com" || !session.Active {
+				t.Errorf("session = %+v", session)
+			}
+			if want := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC); !session.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", session.ExpiresAt, want)
+			}
+			if want := time.Date(2030, 1, 1, 15, 4, 5, 0, time.UTC); !session.AuthenticatedAt.Equal(want) {
+				t.Errorf("AuthenticatedAt = %v, want %v", session.AuthenticatedAt, want)
+			}
+			if session.AAL != "aal2" || !reflect.DeepEqual(session.AuthenticationMethods, []string{"password", "totp"}) {
+				t.Errorf("AAL = %v, methods = %v", session.AAL, session.AuthenticationMethods)
+			}
+		})
+	}
+}
+
+func TestKratosClient_ValidateSession_Errors(t *testing.T) {
+	client := newTestKratosClient(t)
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode common.ErrorCode
+	}{
+		{name: "rejected", token: "expired-token", wantCode: common.ErrCodeInvalidSession},
+		{name: "kratos failure", token: "broken", wantCode: common.ErrCodeKratosError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ValidateSession(context.Background(), tt.token)
+
+			if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Errorf("ValidateSession() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+const adminIdentity = `{
+	"id": "user-1",
+	"schema_id": "default",
+	"schema_url": "http://kratos/schemas/default",
+	"state": "inactive",
+	"traits": {"email": "jane@example.com", "name": {"first": "Jane", "last": "Doe", "middle": "M"}, "phone": "+243812345678"},
+	"metadata_public": {"plan": "pro"}
+}`
+
+// newTestKratosAdmin démarre une fausse API admin des identités: "user-1" existe,
+// l'email "taken@example.com" est déjà utilisé. updates reçoit le corps des PUT.
+func newTestKratosAdmin(t *testing.T) (KratosClient, *[]map[string]interface{}) {
+	var updates []map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/identities/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "user-1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":404,"message":"Unable to locate the resource"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(adminIdentity))
+		case http.MethodPut:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if traits, _ := body["traits"].(map[string]interface{}); traits["email"] == "taken@example.com" {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"error":{"code":409,"message":"identity already exists"}}`))
+				return
+			}
+			updates = append(updates, body)
+			body["id"] = "user-1"
+			json.NewEncoder(w).Encode(body)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("POST /admin/identities", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":{"code":409,"message":"identity already exists"}}`))
+	})
+	// La liste est servie en deux pages d'une identité, chaînées par l'en-tête Link
+	mux.HandleFunc("GET /admin/identities", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page_token") == "" {
+			w.Header().Add("Link", `</admin/identities?page_size=1&page_token=tok-2>; rel="next"`)
+			w.Write([]byte("[" + adminIdentity + "]"))
+			return
+		}
+		w.Write([]byte(`[{"id":"user-2","schema_id":"default","schema_url":"","traits":{"email":"john@example.com","name":{"first":"John","last":"Doe"}}}]`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewKratosClient(config.KratosConfig{PublicURL: server.URL, AdminURL: server.URL}, server.Client()), &updates
+}
+
+func TestKratosClient_UpdateUser(t *testing.T) {
+	// Arrange
+	client, updates := newTestKratosAdmin(t)
+
+	// Act
+	user, err := client.UpdateUser(context.Background(), "user-1", "janet@example.com", "Janet", "Smith")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	if user.Email != "janet@example.com" || user.Name["first"] != "Janet" {
+		t.Errorf("UpdateUser() = %+v", user)
+	}
+	if len(*updates) != 1 {
+		t.Fatalf("got %d PUT requests, want 1", len(*updates))
+	}
+	body := (*updates)[0]
+	traits := body["traits"].(map[string]interface{})
+	name := traits["name"].(map[string]interface{})
+	if traits["phone"] != "+243812345678" || name["middle"] != "M" || name["last"] != "Smith" {
+		t.Errorf("traits = %v, want other traits kept", traits)
+	}
+	if body["schema_id"] != "default" || body["state"] != "inactive" {
+		t.Errorf("schema_id = %v, state = %v, want kept", body["schema_id"], body["state"])
+	}
+	if metadata, _ := body["metadata_public"].(map[string]interface{}); metadata["plan"] != "pro" {
+		t.Errorf("metadata_public = %v, want kept", body["metadata_public"])
+	}
+}
+
+func TestKratosClient_IdentityErrors(t *testing.T) {
+	client, _ := newTestKratosAdmin(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want *common.AppError
+	}{
+		{name: "create existing identity", call: func() error { _, err := client.CreateUser(ctx, "taken@example.com", "A", "B"); return err }, want: common.ErrUserExists},
+		{name: "get unknown identity", call: func() error { _, err := client.GetUser(ctx, "missing"); return err }, want: common.ErrUserNotFound},
+		{name: "update unknown identity", call: func() error { _, err := client.UpdateUser(ctx, "missing", "a@example.com", "A", "B"); return err }, want: common.ErrUserNotFound},
+		{name: "email already used", call: func() error { _, err := client.UpdateUser(ctx, "user-1", "taken@example.com", "A", "B"); return err }, want: common.ErrUserExists},
+		{name: "delete unknown identity", call: func() error { return client.DeleteUser(ctx, "missing") }, want: common.ErrUserNotFound},
+		{name: "delete identity", call: func() error { return client.DeleteUser(ctx, "user-1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("error = %v", err)
+				}
+				return
+			}
+			if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.want.Code {
+				t.Errorf("error = %v, want %v", err, tt.want.Code)
+			}
+		})
+	}
+}
+
+func TestKratosClient_ListUsers(t *testing.T) {
+	// Arrange
+	client, _ := newTestKratosAdmin(t)
+	ctx := context.Background()
+
+	// Act
+	first, next, err := client.ListUsers(ctx, 1, "")
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	second, last, err := client.ListUsers(ctx, 1, next)
+	if err != nil {
+		t.Fatalf("ListUsers(%q) error = %v", next, err)
+	}
+
+	// Assert
+	if next != "tok-2" || last != "" {
+		t.Errorf("page tokens = %q, %q, want %q, \"\"", next, last, "tok-2")
+	}
+	if len(first) != 1 || first[0].ID != "user-1" {
+		t.Errorf("first page = %+v, want user-1", first)
+	}
+	if len(second) != 1 || second[0].ID != "user-2" || second[0].Email != "john@example.com" || second[0].Name["first"] != "John" {
+		t.Errorf("second page = %+v, want user-2", second)
+	}
+}
+
+func TestToSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  KratosSession
+		wantCode common.ErrorCode
+	}{
+		{name: "active", session: KratosSession{Active: true, ExpiresAt: time.Now().Add(time.Hour)}},
+		{name: "inactive", session: KratosSession{Active: false, ExpiresAt: time.Now().Add(time.Hour)}, wantCode: common.ErrCodeInvalidSession},
+		{name: "expired", session: KratosSession{Active: true, ExpiresAt: time.Now().Add(-time.Minute)}, wantCode: common.ErrCodeSessionExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := toSession(&tt.session, "token")
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("toSession() error = %v", err)
+				}
+				return
+			}
+			if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Errorf("toSession() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryCustomerRepository implémentation en mémoire du repository client
+type MemoryCustomerRepository struct {
+	customers map[string]*models.Customer
+	mutex     sync.RWMutex
+}
+
+// NewMemoryCustomerRepository crée une nouvelle instance du repository en mémoire
+func NewMemoryCustomerRepository() CustomerRepository {
+	return &MemoryCustomerRepository{
+		customers: make(map[string]*models.Customer),
+	}
+}
+
+// Create crée un client
+func (m *MemoryCustomerRepository) Create(ctx context.Context, customer *models.Customer) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Vérifier si le numéro de téléphone est déjà utilisé
+	if m.findByPhone(customer.PhoneCode, customer.PhoneNumber) != nil {
+		return common.ErrCustomerExists
+	}
+
+	// Générer un ID si non fourni
+	if customer.ID == "" {
+		customer.ID = uuid.NewString()
+	}
+
+	// Définir les timestamps
+	now := time.Now()
+	customer.CreatedAt = now
+	customer.UpdatedAt = now
+
+	customerCopy := *customer
+	m.customers[customer.ID] = &customerCopy
+	return nil
+}
+
+// GetByID récupère un client par son ID
+func (m *MemoryCustomerRepository) GetByID(ctx context.Context, id string) (*models.Customer, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	customer, exists := m.customers[id]
+	if !exists {
+		return nil, common.ErrCustomerNotFound
+	}
+
+	// Retourner une copie pour éviter les modifications accidentelles
+	customerCopy := *customer
+	return &customerCopy, nil
+}
+
+// GetByPhone récupère un client par son numéro de téléphone
+func (m *MemoryCustomerRepository) GetByPhone(ctx context.Context, phoneCode, phoneNumber string) (*models.Customer, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	customer := m.findByPhone(phoneCode, phoneNumber)
+	if customer == nil {
+		return nil, common.ErrCustomerNotFound
+	}
+
+	customerCopy := *customer
+	return &customerCopy, nil
+}
+
+// Update met à jour un client
+func (m *MemoryCustomerRepository) Update(ctx context.Context, customer *models.Customer) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Vérifier si le client existe
+	if _, exists := m.customers[customer.ID]; !exists {
+		return common.ErrCustomerNotFound
+	}
+
+	// Le nouveau numéro ne doit pas appartenir à un autre client
+	if other := m.findByPhone(customer.PhoneCode, customer.PhoneNumber); other != nil && other.ID != customer.ID {
+		return common.ErrCustomerExists
+	}
+
+	// Mettre à jour le timestamp
+	customer.UpdatedAt = time.Now()
+
+	customerCopy := *customer
+	m.customers[customer.ID] = &customerCopy
+	return nil
+}
+
+// Delete supprime un client
+func (m *MemoryCustomerRepository) Delete(ctx context.Context, id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.customers[id]; !exists {
+		return common.ErrCustomerNotFound
+	}
+
+	delete(m.customers, id)
+	return nil
+}
+
+// List liste les clients avec pagination, triés par date de création
+func (m *MemoryCustomerRepository) List(ctx context.Context, limit, offset int) ([]*models.Customer, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	all := make([]*models.Customer, 0, len(m.customers))
+	for _, customer := range m.customers {
+		all = append(all, customer)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].CreatedAt.Equal(all[j].CreatedAt) {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].CreatedAt.Before(all[j].CreatedAt)
+	})
+
+	if offset >= len(all) {
+		return []*models.Customer{}, nil
+	}
+	end := len(all)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	customers := make([]*models.Customer, 0, end-offset)
+	for _, customer := range all[offset:end] {
+		// Retourner une copie pour éviter les modifications accidentelles
+		customerCopy := *customer
+		customers = append(customers, &customerCopy)
+	}
+
+	return customers, nil
+}
+
+// findByPhone recherche un client par numéro (le verrou doit être détenu)
+func (m *MemoryCustomerRepository) findByPhone(phoneCode, phoneNumber string) *models.Customer {
+	for _, customer := range m.customers {
+		if customer.PhoneCode == phoneCode && customer.PhoneNumber == phoneNumber {
+			return customer
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ndugu-backend/internal/models"
+)
+
+// MemoryIdempotencyRepository implémentation en mémoire du repository d'idempotence.
+// Les clés ne sont pas partagées entre instances: réservée au développement et aux déploiements à une instance.
+type MemoryIdempotencyRepository struct {
+	records map[string]*models.IdempotencyRecord
+	mutex   sync.Mutex
+}
+
+// NewMemoryIdempotencyRepository crée une nouvelle instance du repository en mémoire
+func NewMemoryIdempotencyRepository() IdempotencyRepository {
+	return &MemoryIdempotencyRepository{
+		records: make(map[string]*models.IdempotencyRecord),
+	}
+}
+
+// Reserve enregistre une requête en cours si la clé est libre ou expirée
+func (m *MemoryIdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if existing, exists := m.records[record.Key]; exists && existing.ExpiresAt.After(record.CreatedAt) {
+		existingCopy := *existing
+		return &existingCopy, false, nil
+	}
+
+	recordCopy := *record
+	recordCopy.Response = nil
+	m.records[record.Key] = &recordCopy
+	return nil, true, nil
+}
+
+// Complete mémorise la réponse d'une requête réservée
+func (m *MemoryIdempotencyRepository) Complete(ctx context.Context, key string, response []byte, expiresAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if record, exists := m.records[key]; exists && !record.Completed() {
+		record.Response = append([]byte{}, response...)
+		record.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+// Release libère la clé d'une requête réservée
+func (m *MemoryIdempotencyRepository) Release(ctx context.Context, key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if record, exists := m.records[key]; exists && !record.Completed() {
+		delete(m.records, key)
+	}
+	return nil
+}
+
+// DeleteExpired supprime les enregistrements expirés
+func (m *MemoryIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var deleted int64
+	for key, record := range m.records {
+		if !record.ExpiresAt.After(before) {
+			delete(m.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -136,6 +137,32 @@ func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*mo
 	return users, nil
 }
 
+// Search recherche les utilisateurs correspondant au filtre, triés par date de création puis ID
+func (m *MockUserRepository) Search(ctx context.Context, filter *models.UserFilter, limit int) ([]*models.User, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	users := make([]*models.User, 0, limit)
+	for _, user := range m.users {
+		if filter.Matches(user) {
+			// Retourner une copie pour éviter les modifications accidentelles
+			userCopy := *user
+			users = append(users, &userCopy)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].ID < users[j].ID
+		}
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+	if len(users) > limit {
+		users = users[:limit]
+	}
+
+	return users, nil
+}
+
 // GetStats retourne des statistiques sur les utilisateurs
 func (m *MockUserRepository) GetStats(ctx context.Context) (map[string]int, error) {
 	m.mutex.RLock()
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
-	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
 	"ndugu-backend/internal/models"
 )
 
@@ -17,12 +19,27 @@ type oryClient struct {
 	logger       common.Logger
 }
 
-// NewOryClient crée une nouvelle instance du client Ory
-func NewOryClient(logger common.Logger) OryClient {
+// NewOryClient crée une nouvelle instance du client Ory.
+// httpClient est partagé par les appels REST vers les services Ory; s'il est nil un client par défaut est créé.
+// Les sessions validées sont mises en cache dans sessions et les décisions Keto dans permissions, sauf s'ils sont nil.
+func NewOryClient(cfg config.OryConfig, httpClient *http.Client, sessions *SessionCache, permissions *PermissionCache, logger common.Logger) OryClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	kratosClient := NewKratosClient(cfg.Kratos, httpClient)
+	if sessions != nil {
+		kratosClient = NewCachedKratosClient(kratosClient, sessions)
+	}
+	ketoClient := NewKetoClient(cfg.Keto, httpClient)
+	if permissions != nil {
+		ketoClient = NewCachedKetoClient(ketoClient, permissions)
+	}
+
 	return &oryClient{
-		kratosClient: NewKratosClient(),
-		hydraClient:  NewHydraClient(),
-		ketoClient:   NewKetoClient(),
+		kratosClient: kratosClient,
+		hydraClient:  NewHydraClient(cfg.Hydra, httpClient),
+		ketoClient:   ketoClient,
 		logger:       logger,
 	}
 }
@@ -31,7 +48,7 @@ func NewOryClient(logger common.Logger) OryClient {
 func (c *oryClient) CreateUser(ctx context.Context, email, firstName, lastName string) (*models.User, error) {
 	user, err := c.kratosClient.CreateUser(ctx, email, firstName, lastName)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de l'utilisateur: %w", err)
+		return nil, err
 	}
 
 	// Convertir en modèle User
@@ -50,69 +67,135 @@ func (c *oryClient) CreateUser(ctx context.Context, email, firstName, lastName s
 func (c *oryClient) GetUser(ctx context.Context, userID string) (*models.User, error) {
 	user, err := c.kratosClient.GetUser(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
+		return nil, err
 	}
+	return fromKratosUser(user), nil
+}
 
-	// Extraire le nom depuis les traits
-	firstName := ""
-	lastName := ""
-	if name, ok := user.Name["first"].(string); ok {
-		firstName = name
+// UpdateUser remplace l'email et le nom d'un utilisateur via Kratos
+func (c *oryClient) UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*models.User, error) {
+	user, err := c.kratosClient.UpdateUser(ctx, userID, email, firstName, lastName)
+	if err != nil {
+		return nil, err
 	}
-	if name, ok := user.Name["last"].(string); ok {
-		lastName = name
+	return fromKratosUser(user), nil
+}
+
+// DeleteUser supprime un utilisateur via Kratos
+func (c *oryClient) DeleteUser(ctx context.Context, userID string) error {
+	return c.kratosClient.DeleteUser(ctx, userID)
+}
+
+// ListUsers liste les utilisateurs Kratos et retourne le jeton de la page suivante
+func (c *oryClient) ListUsers(ctx context.Context, pageSize int, pageToken string) ([]*models.User, string, error) {
+	users, next, err := c.kratosClient.ListUsers(ctx, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return &models.User{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: firstName,
-		LastName:  lastName,
-		Traits:    user.Traits,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	result := make([]*models.User, 0, len(users))
+	for _, user := range users {
+		result = append(result, fromKratosUser(user))
+	}
+	return result, next, nil
 }
 
-// ValidateSession valide une session via Kratos
+// ValidateSession valide un token de session via Kratos
 func (c *oryClient) ValidateSession(ctx context.Context, sessionToken string) (*models.Session, error) {
 	session, err := c.kratosClient.ValidateSession(ctx, sessionToken)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la validation de la session: %w", err)
+		return nil, err
+	}
+	return toSession(session, sessionToken)
+}
+
+// RevokeSession révoque un token de session via Kratos
+func (c *oryClient) RevokeSession(ctx context.Context, sessionToken string) error {
+	return c.kratosClient.RevokeSession(ctx, sessionToken)
+}
+
+// ValidateSessionCookie valide un cookie de session navigateur via Kratos
+func (c *oryClient) ValidateSessionCookie(ctx context.Context, cookie string) (*models.Session, error) {
+	session, err := c.kratosClient.ValidateSessionCookie(ctx, cookie)
+	if err != nil {
+		return nil, err
 	}
+	return toSession(session, "")
+}
 
-	// Extraire l'email depuis l'identité
-	if emailVal, ok := session.Identity.Traits["email"].(string); ok {
-		_ = emailVal // Utilisé pour l'extraction future
+// IntrospectToken introspecte un jeton d'accès OAuth2 via Hydra
+func (c *oryClient) IntrospectToken(ctx context.Context, token string) (*models.TokenIntrospection, error) {
+	introspection, err := c.hydraClient.IntrospectToken(ctx, token)
+	if err != nil {
+		return nil, err
 	}
 
-	return &models.Session{
-		ID:        session.Id,
-		UserID:    session.Identity.ID,
-		Token:     sessionToken,
-		Traits:    session.Identity.Traits,
-		ExpiresAt: time.Now().Add(24 * time.Hour), // Exemple
-		CreatedAt: time.Now(),
-	}, nil
+	result := &models.TokenIntrospection{
+		Active:   introspection.Active,
+		Subject:  introspection.Subject,
+		ClientID: introspection.ClientID,
+		Scopes:   strings.Fields(introspection.Scope),
+		TokenUse: introspection.TokenUse,
+	}
+	if introspection.ExpiresAt > 0 {
+		result.ExpiresAt = time.Unix(introspection.ExpiresAt, 0)
+	}
+	return result, nil
 }
 
 // CreateOAuth2Client crée un client OAuth2 via Hydra
-func (c *oryClient) CreateOAuth2Client(ctx context.Context, clientID, clientName, redirectURI string) (*models.OAuth2Client, error) {
-	client, err := c.hydraClient.CreateOAuth2Client(ctx, clientID, clientName, redirectURI)
+func (c *oryClient) CreateOAuth2Client(ctx context.Context, client *models.OAuth2Client) (*models.OAuth2Client, error) {
+	created, err := c.hydraClient.CreateOAuth2Client(ctx, toHydraOAuth2Client(client))
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création du client OAuth2: %w", err)
+		return nil, err
 	}
+	return fromHydraOAuth2Client(created), nil
+}
 
-	return &models.OAuth2Client{
-		ID:           client.ID,
-		Name:         client.Name,
-		Secret:       client.Secret,
-		RedirectURIs: client.RedirectURIs,
-		GrantTypes:   client.GrantTypes,
-		Scopes:       client.Scopes,
-		CreatedAt:    client.CreatedAt,
-		UpdatedAt:    client.UpdatedAt,
-	}, nil
+// GetOAuth2Client récupère un client OAuth2 via Hydra
+func (c *oryClient) GetOAuth2Client(ctx context.Context, clientID string) (*models.OAuth2Client, error) {
+	client, err := c.hydraClient.GetOAuth2Client(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return fromHydraOAuth2Client(client), nil
+}
+
+// ListOAuth2Clients liste les clients OAuth2 via Hydra
+func (c *oryClient) ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) ([]*models.OAuth2Client, string, error) {
+	clients, next, err := c.hydraClient.ListOAuth2Clients(ctx, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]*models.OAuth2Client, 0, len(clients))
+	for _, client := range clients {
+		result = append(result, fromHydraOAuth2Client(client))
+	}
+	return result, next, nil
+}
+
+// UpdateOAuth2Client met à jour un client OAuth2 via Hydra
+func (c *oryClient) UpdateOAuth2Client(ctx context.Context, client *models.OAuth2Client) (*models.OAuth2Client, error) {
+	updated, err := c.hydraClient.UpdateOAuth2Client(ctx, toHydraOAuth2Client(client))
+	if err != nil {
+		return nil, err
+	}
+	return fromHydraOAuth2Client(updated), nil
+}
+
+// DeleteOAuth2Client supprime un client OAuth2 via Hydra
+func (c *oryClient) DeleteOAuth2Client(ctx context.Context, clientID string) error {
+	return c.hydraClient.DeleteOAuth2Client(ctx, clientID)
+}
+
+// SetOAuth2ClientSecret remplace le secret d'un client OAuth2 via Hydra
+func (c *oryClient) SetOAuth2ClientSecret(ctx context.Context, clientID, secret string) (*models.OAuth2Client, error) {
+	client, err := c.hydraClient.SetOAuth2ClientSecret(ctx, clientID, secret)
+	if err != nil {
+		return nil, err
+	}
+	return fromHydraOAuth2Client(client), nil
 }
 
 // CreatePermission crée une permission via Keto
@@ -120,34 +203,126 @@ func (c *oryClient) CreatePermission(ctx context.Context, namespace, object, rel
 	return c.ketoClient.CreatePermission(ctx, namespace, object, relation, subject)
 }
 
+// DeletePermission supprime une permission via Keto
+func (c *oryClient) DeletePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	return c.ketoClient.DeletePermission(ctx, namespace, object, relation, subject)
+}
+
 // CheckPermission vérifie une permission via Keto
 func (c *oryClient) CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error) {
 	return c.ketoClient.CheckPermission(ctx, namespace, object, relation, subject)
 }
 
-// Types temporaires pour les clients Ory
-type KratosUser struct {
-	ID        string                 `json:"id"`
-	Email     string                 `json:"email"`
-	Name      map[string]interface{} `json:"name"`
-	Traits    map[string]interface{} `json:"traits"`
-	CreatedAt time.Time              `json:"created_at"`
-	UpdatedAt time.Time              `json:"updated_at"`
+// DeleteSubjectPermissions supprime toutes les permissions d'un sujet via Keto
+func (c *oryClient) DeleteSubjectPermissions(ctx context.Context, subjectID string) error {
+	return c.ketoClient.DeleteSubjectPermissions(ctx, subjectID)
+}
+
+// ExpandPermission retourne l'arbre des sujets d'une relation via Keto; nil si aucun sujet n'a la relation
+func (c *oryClient) ExpandPermission(ctx context.Context, namespace, object, relation string, maxDepth int) (*models.SubjectTree, error) {
+	tree, err := c.ketoClient.ExpandPermission(ctx, namespace, object, relation, maxDepth)
+	if err != nil || tree == nil {
+		return nil, err
+	}
+	return fromKetoSubjectTree(tree), nil
+}
+
+// ListRelations liste les tuples de relation via Keto; les champs vides du filtre ne filtrent pas
+func (c *oryClient) ListRelations(ctx context.Context, filter models.RelationTuple, pageSize int, pageToken string) ([]*models.RelationTuple, string, error) {
+	tuples, next, err := c.ketoClient.ListRelations(ctx, KetoRelationQuery(filter), pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]*models.RelationTuple, 0, len(tuples))
+	for _, tuple := range tuples {
+		result = append(result, &models.RelationTuple{
+			Namespace: tuple.Namespace,
+			Object:    tuple.Object,
+			Relation:  tuple.Relation,
+			Subject:   tuple.Subject(),
+		})
+	}
+	return result, next, nil
+}
+
+// fromKetoSubjectTree convertit un arbre de sujets Keto en modèle SubjectTree
+func fromKetoSubjectTree(node *KetoSubjectTree) *models.SubjectTree {
+	tree := &models.SubjectTree{
+		Type:    node.Type,
+		Subject: node.Subject(),
+	}
+	for _, child := range node.Children {
+		tree.Children = append(tree.Children, fromKetoSubjectTree(child))
+	}
+	return tree
+}
+
+// toSession convertit une session Kratos en modèle Session.
+// Une session inactive ou expirée est refusée même si Kratos l'a renvoyée.
+func toSession(session *KratosSession, token string) (*models.Session, error) {
+	if !session.Active {
+		return nil, common.ErrInvalidSession
+	}
+	if !session.ExpiresAt.IsZero() && session.ExpiresAt.Before(time.Now()) {
+		return nil, common.ErrSessionExpired
+	}
+
+	return &models.Session{
+		ID:                    session.Id,
+		UserID:                session.Identity.ID,
+		Token:                 token,
+		Traits:                session.Identity.Traits,
+		AAL:                   session.AAL,
+		AuthenticationMethods: session.AuthenticationMethods,
+		AuthenticatedAt:       session.AuthenticatedAt,
+		ExpiresAt:             session.ExpiresAt,
+		CreatedAt:             session.IssuedAt,
+	}, nil
 }
 
-type KratosSession struct {
-	Id        string     `json:"id"`
-	Identity  KratosUser `json:"identity"`
-	ExpiresAt time.Time  `json:"expires_at"`
+// fromKratosUser convertit un utilisateur Kratos en modèle User; le nom est extrait des traits
+func fromKratosUser(user *KratosUser) *models.User {
+	firstName, _ := user.Name["first"].(string)
+	lastName, _ := user.Name["last"].(string)
+
+	return &models.User{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: firstName,
+		LastName:  lastName,
+		Traits:    user.Traits,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
 }
 
-type HydraOAuth2Client struct {
-	ID           string    `json:"id"`
-	Name         string    `json:"name"`
-	Secret       string    `json:"secret"`
-	RedirectURIs []string  `json:"redirect_uris"`
-	GrantTypes   []string  `json:"grant_types"`
-	Scopes       []string  `json:"scopes"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+// toHydraOAuth2Client convertit un client OAuth2 vers le format Hydra
+func toHydraOAuth2Client(client *models.OAuth2Client) *HydraOAuth2Client {
+	return &HydraOAuth2Client{
+		ClientID:                client.ID,
+		ClientName:              client.Name,
+		ClientSecret:            client.Secret,
+		RedirectURIs:            client.RedirectURIs,
+		GrantTypes:              client.GrantTypes,
+		ResponseTypes:           client.ResponseTypes,
+		Scope:                   strings.Join(client.Scopes, " "),
+		TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+	}
+}
+
+// fromHydraOAuth2Client convertit un client Hydra en modèle OAuth2Client
+func fromHydraOAuth2Client(client *HydraOAuth2Client) *models.OAuth2Client {
+	return &models.OAuth2Client{
+		ID:                      client.ClientID,
+		Name:                    client.ClientName,
+		Secret:                  client.ClientSecret,
+		RedirectURIs:            client.RedirectURIs,
+		GrantTypes:              client.GrantTypes,
+		ResponseTypes:           client.ResponseTypes,
+		Scopes:                  strings.Fields(client.Scope),
+		TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+		CreatedAt:               client.CreatedAt,
+		UpdatedAt:               client.UpdatedAt,
+	}
 }
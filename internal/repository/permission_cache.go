@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"ndugu-backend/internal/metrics"
+)
+
+// PermissionCache cache LRU borné des décisions Keto (autorisé ou refusé), conservées au plus ttl.
+// Keto résout les permissions par transitivité (subject sets): l'écriture d'un tuple peut changer
+// des décisions portant sur d'autres tuples, toute écriture vide donc le cache. Le TTL, court,
+// borne la durée pendant laquelle une écriture faite par une autre instance ou directement
+// dans Keto est ignorée.
+type PermissionCache struct {
+	capacity int
+	ttl      time.Duration
+	metrics  *metrics.Metrics
+	now      func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List // du plus récemment au moins récemment utilisé
+	generation uint64     // incrémentée à chaque vidage
+}
+
+// permissionCacheEntry décision en cache
+type permissionCacheEntry struct {
+	key       string
+	allowed   bool
+	expiresAt time.Time
+}
+
+// NewPermissionCache crée un cache d'au plus capacity décisions, conservées au plus ttl
+func NewPermissionCache(capacity int, ttl time.Duration, m *metrics.Metrics) *PermissionCache {
+	return &PermissionCache{
+		capacity: capacity,
+		ttl:      ttl,
+		metrics:  m,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// permissionCacheKey clé d'un tuple; "\x00" ne peut pas apparaître dans un champ Keto
+func permissionCacheKey(namespace, object, relation, subject string) string {
+	return namespace + "\x00" + object + "\x00" + relation + "\x00" + subject
+}
+
+// Load retourne la décision en cache ou l'obtient avec check, puis la met en cache.
+// Les erreurs ne sont pas mises en cache.
+func (c *PermissionCache) Load(ctx context.Context, key string, check func(ctx context.Context) (bool, error)) (bool, error) {
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*permissionCacheEntry)
+		if c.now().Before(entry.expiresAt) {
+			c.order.MoveToFront(element)
+			c.mu.Unlock()
+			c.metrics.ObservePermissionCache(true)
+			return entry.allowed, nil
+		}
+		c.remove(element)
+	}
+	generation := c.generation
+	c.mu.Unlock()
+	c.metrics.ObservePermissionCache(false)
+
+	allowed, err := check(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.add(key, allowed, generation)
+	return allowed, nil
+}
+
+// Purge vide le cache, y compris les décisions en cours d'obtention
+func (c *PermissionCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// Len retourne le nombre de décisions en cache
+func (c *PermissionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// add met en cache une décision, sauf si le cache a été vidé depuis generation:
+// la décision a pu être obtenue avant une écriture
+func (c *PermissionCache) add(key string, allowed bool, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&permissionCacheEntry{key: key, allowed: allowed, expiresAt: c.now().Add(c.ttl)})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove retire une entrée du cache; c.mu doit être verrouillé
+func (c *PermissionCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*permissionCacheEntry).key)
+}
+
+// cachedKetoClient met en cache les décisions d'un KetoClient et vide le cache à chaque écriture
+type cachedKetoClient struct {
+	KetoClient
+	cache *PermissionCache
+}
+
+// NewCachedKetoClient enveloppe client pour mettre en cache les décisions
+func NewCachedKetoClient(client KetoClient, cache *PermissionCache) KetoClient {
+	return &cachedKetoClient{KetoClient: client, cache: cache}
+}
+
+// CheckPermission vérifie un tuple de relation, depuis le cache si possible
+func (c *cachedKetoClient) CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error) {
+	return c.cache.Load(ctx, permissionCacheKey(namespace, object, relation, subject), func(ctx context.Context) (bool, error) {
+		return c.KetoClient.CheckPermission(ctx, namespace, object, relation, subject)
+	})
+}
+
+// CreatePermission crée un tuple de relation et vide le cache, même si Keto échoue
+func (c *cachedKetoClient) CreatePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	defer c.cache.Purge()
+	return c.KetoClient.CreatePermission(ctx, namespace, object, relation, subject)
+}
+
+// DeletePermission supprime un tuple de relation et vide le cache, même si Keto échoue
+func (c *cachedKetoClient) DeletePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	defer c.cache.Purge()
+	return c.KetoClient.DeletePermission(ctx, namespace, object, relation, subject)
+}
+
+// DeleteSubjectPermissions supprime les tuples d'un sujet et vide le cache, même si Keto échoue
+func (c *cachedKetoClient) DeleteSubjectPermissions(ctx context.Context, subjectID string) error {
+	defer c.cache.Purge()
+	return c.KetoClient.DeleteSubjectPermissions(ctx, subjectID)
+}
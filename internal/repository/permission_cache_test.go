@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/metrics"
+)
+
+// countingKetoClient simule Keto et compte les vérifications
+type countingKetoClient struct {
+	KetoClient
+	checks  int
+	allowed bool
+	err     error
+	// during appelé pendant la vérification, pour simuler une écriture concurrente
+	during func()
+}
+
+func (k *countingKetoClient) CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error) {
+	k.checks++
+	if k.during != nil {
+		k.during()
+	}
+	return k.allowed, k.err
+}
+
+func (k *countingKetoClient) CreatePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	return nil
+}
+
+func (k *countingKetoClient) DeletePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	return errors.New("keto indisponible")
+}
+
+func TestCachedKetoClient_CheckPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkErr   error
+		act        func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(subject string))
+		wantChecks int
+	}{
+		{
+			name: "second check is a hit",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				check("user-1")
+			},
+			wantChecks: 1,
+		},
+		{
+			name: "tuples are cached separately",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				check("user-2")
+			},
+			wantChecks: 2,
+		},
+		{
+			name: "decision expires after the ttl",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				*clock = clock.Add(5 * time.Second)
+				check("user-1")
+			},
+			wantChecks: 2,
+		},
+		{
+			name:     "errors are not cached",
+			checkErr: errors.New("keto indisponible"),
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				check("user-1")
+			},
+			wantChecks: 2,
+		},
+		{
+			name: "least recently used decision is evicted",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				check("user-2")
+				check("user-1")
+				check("user-3")
+				check("user-1")
+				check("user-2")
+			},
+			wantChecks: 4,
+		},
+		{
+			name: "created permission purges the cache",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				client.CreatePermission(context.Background(), "files", "file-1", "viewer", "user-2")
+				check("user-1")
+			},
+			wantChecks: 2,
+		},
+		{
+			name: "failed deletion purges the cache",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				check("user-1")
+				client.DeletePermission(context.Background(), "files", "file-1", "viewer", "user-1")
+				check("user-1")
+			},
+			wantChecks: 2,
+		},
+		{
+			name: "decision obtained during a write is not cached",
+			act: func(client KetoClient, k *countingKetoClient, clock *time.Time, check func(string)) {
+				k.during = func() { client.CreatePermission(context.Background(), "files", "file-1", "viewer", "user-1") }
+				check("user-1")
+				k.during = nil
+				check("user-1")
+				check("user-1")
+			},
+			wantChecks: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			clock := time.Now()
+			cache := NewPermissionCache(2, 5*time.Second, metrics.New())
+			cache.now = func() time.Time { return clock }
+			keto := &countingKetoClient{allowed: true, err: tt.checkErr}
+			client := NewCachedKetoClient(keto, cache)
+			check := func(subject string) {
+				client.CheckPermission(context.Background(), "files", "file-1", "viewer", subject)
+			}
+
+			// Act
+			tt.act(client, keto, &clock, check)
+
+			// Assert
+			if keto.checks != tt.wantChecks {
+				t.Errorf("Keto called %d times, want %d", keto.checks, tt.wantChecks)
+			}
+			if cache.Len() > 2 {
+				t.Errorf("Len() = %d, want at most 2", cache.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+)
+
+// PostgresIdempotencyRepository implémentation PostgreSQL du repository d'idempotence,
+// partagée par toutes les instances du service
+type PostgresIdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIdempotencyRepository crée une nouvelle instance du repository PostgreSQL
+func NewPostgresIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+	return &PostgresIdempotencyRepository{
+		db: db,
+	}
+}
+
+// Reserve enregistre une requête en cours si la clé est libre ou expirée.
+// Une clé libérée entre l'insertion et la lecture de l'enregistrement existant est réservée à nouveau.
+func (r *PostgresIdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, bool, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		// La mise à jour ne porte que sur un enregistrement expiré: sinon aucune ligne n'est retournée
+		var key string
+		err := r.db.QueryRowContext(ctx, `
+			INSERT INTO idempotency_keys (key, request_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash, response = NULL,
+			    created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			RETURNING key`,
+			record.Key, record.RequestHash, record.CreatedAt, record.ExpiresAt,
+		).Scan(&key)
+		if err == nil {
+			return nil, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la réservation de la clé d'idempotence", err.Error())
+		}
+
+		existing := &models.IdempotencyRecord{}
+		err = r.db.QueryRowContext(ctx, `
+			SELECT key, request_hash, response, created_at, expires_at
+			FROM idempotency_keys WHERE key = $1`, record.Key,
+		).Scan(&existing.Key, &existing.RequestHash, &existing.Response, &existing.CreatedAt, &existing.ExpiresAt)
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la lecture de la clé d'idempotence", err.Error())
+		}
+	}
+	return nil, false, common.ErrIdempotencyKeyInUse
+}
+
+// Complete mémorise la réponse d'une requête réservée
+func (r *PostgresIdempotencyRepository) Complete(ctx context.Context, key string, response []byte, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE idempotency_keys SET response = $2, expires_at = $3
+		WHERE key = $1 AND response IS NULL`,
+		key, response, expiresAt,
+	)
+	if err != nil {
+		return common.NewAppError(common.ErrCodeInternal, "Erreur lors de la sauvegarde de la réponse idempotente", err.Error())
+	}
+	return nil
+}
+
+// Release libère la clé d'une requête réservée
+func (r *PostgresIdempotencyRepository) Release(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE key = $1 AND response IS NULL`, key)
+	if err != nil {
+		return common.NewAppError(common.ErrCodeInternal, "Erreur lors de la libération de la clé d'idempotence", err.Error())
+	}
+	return nil
+}
+
+// DeleteExpired supprime les enregistrements expirés
+func (r *PostgresIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la purge des clés d'idempotence", err.Error())
+	}
+	return result.RowsAffected()
+}
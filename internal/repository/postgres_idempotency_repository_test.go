@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func newTestPostgresIdempotencyRepository(t *testing.T) (IdempotencyRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresIdempotencyRepository(db), mock
+}
+
+func TestPostgresIdempotencyRepository_Reserve(t *testing.T) {
+	now := time.Now()
+	record := &models.IdempotencyRecord{Key: "key-1", RequestHash: "hash-1", CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+	existingColumns := []string{"key", "request_hash", "response", "created_at", "expires_at"}
+
+	tests := []struct {
+		name         string
+		arrange      func(mock sqlmock.Sqlmock)
+		wantReserved bool
+		wantResponse string
+	}{
+		{
+			name: "free key",
+			arrange: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO idempotency_keys .* ON CONFLICT \(key\) DO UPDATE`).
+					WithArgs("key-1", "hash-1", now, now.Add(time.Minute)).
+					WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("key-1"))
+			},
+			wantReserved: true,
+		},
+		{
+			name: "completed key",
+			arrange: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO idempotency_keys`).WillReturnRows(sqlmock.NewRows([]string{"key"}))
+				mock.ExpectQuery(`SELECT .* FROM idempotency_keys WHERE key = \$1`).
+					WithArgs("key-1").
+					WillReturnRows(sqlmock.NewRows(existingColumns).AddRow("key-1", "hash-1", []byte("response"), now, now.Add(time.Hour)))
+			},
+			wantResponse: "response",
+		},
+		{
+			name: "key released before read",
+			arrange: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO idempotency_keys`).WillReturnRows(sqlmock.NewRows([]string{"key"}))
+				mock.ExpectQuery(`SELECT .* FROM idempotency_keys`).WillReturnRows(sqlmock.NewRows(existingColumns))
+				mock.ExpectQuery(`INSERT INTO idempotency_keys`).WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("key-1"))
+			},
+			wantReserved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo, mock := newTestPostgresIdempotencyRepository(t)
+			tt.arrange(mock)
+
+			// Act
+			existing, reserved, err := repo.Reserve(context.Background(), record)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Reserve() error = %v", err)
+			}
+			if reserved != tt.wantReserved {
+				t.Errorf("Reserve() reserved = %v, want %v", reserved, tt.wantReserved)
+			}
+			if !tt.wantReserved && (existing == nil || string(existing.Response) != tt.wantResponse) {
+				t.Errorf("Reserve() existing = %+v, want response %q", existing, tt.wantResponse)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPostgresIdempotencyRepository_CompleteAndRelease(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresIdempotencyRepository(t)
+	expiresAt := time.Now().Add(24 * time.Hour)
+	mock.ExpectExec(`UPDATE idempotency_keys SET response = \$2, expires_at = \$3\s+WHERE key = \$1 AND response IS NULL`).
+		WithArgs("key-1", []byte("response"), expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE key = \$1 AND response IS NULL`).
+		WithArgs("key-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE expires_at <= \$1`).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// Act
+	completeErr := repo.Complete(context.Background(), "key-1", []byte("response"), expiresAt)
+	releaseErr := repo.Release(context.Background(), "key-2")
+	deleted, deleteErr := repo.DeleteExpired(context.Background(), time.Now())
+
+	// Assert
+	if completeErr != nil || releaseErr != nil || deleteErr != nil {
+		t.Fatalf("errors = %v, %v, %v", completeErr, releaseErr, deleteErr)
+	}
+	if deleted != 3 {
+		t.Errorf("DeleteExpired() = %d, want 3", deleted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
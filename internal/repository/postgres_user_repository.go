@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Codes d'erreur PostgreSQL utilisés par les repositories
+const (
+	pgUniqueViolation           = "23505"
+	pgInvalidTextRepresentation = "22P02"
+)
+
+// PostgresUserRepository implémentation PostgreSQL du repository utilisateur
+type PostgresUserRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserRepository crée une nouvelle instance du repository PostgreSQL
+func NewPostgresUserRepository(db *sql.DB) UserRepository {
+	return &PostgresUserRepository{
+		db: db,
+	}
+}
+
+const userColumns = `id, email, first_name, last_name, traits, created_at, updated_at`
+
+// Create crée un utilisateur
+func (r *PostgresUserRepository) Create(ctx context.Context, user *models.User) error {
+	traits, err := marshalTraits(user.Traits)
+	if err != nil {
+		return err
+	}
+
+	// L'ID Kratos est réutilisé s'il est fourni, sinon PostgreSQL en génère un
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO users (id, email, first_name, last_name, traits)
+		VALUES (COALESCE(NULLIF($1, '')::uuid, gen_random_uuid()), $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`,
+		user.ID, user.Email, user.FirstName, user.LastName, traits,
+	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return common.ErrUserExists
+		}
+		return common.NewAppError(common.ErrCodeInternal, "Erreur lors de la création de l'utilisateur", err.Error())
+	}
+
+	return nil
+}
+
+// GetByID récupère un utilisateur par son ID
+func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+	return scanUser(row)
+}
+
+// GetByEmail récupère un utilisateur par son email
+func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE lower(email) = lower($1)`, email)
+	return scanUser(row)
+}
+
+// Update met à jour un utilisateur
+func (r *PostgresUserRepository) Update(ctx context.Context, user *models.User) error {
+	traits, err := marshalTraits(user.Traits)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		UPDATE users
+		SET email = $2, first_name = $3, last_name = $4, traits = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at`,
+		user.ID, user.Email, user.FirstName, user.LastName, traits,
+	).Scan(&user.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows), isPgError(err, pgInvalidTextRepresentation):
+			return common.ErrUserNotFound
+		case isPgError(err, pgUniqueViolation):
+			return common.ErrUserExists
+		}
+		return common.NewAppError(common.ErrCodeInternal, "Erreur lors de la mise à jour de l'utilisateur", err.Error())
+	}
+
+	return nil
+}
+
+// Delete supprime un utilisateur
+func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		if isPgError(err, pgInvalidTextRepresentation) {
+			return common.ErrUserNotFound
+		}
+		return common.NewAppError(common.ErrCodeInternal, "Erreur lors de la suppression de l'utilisateur", err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return common.NewAppError(common.ErrCodeInternal, "Erreur lors de la suppression de l'utilisateur", err.Error())
+	}
+	if affected == 0 {
+		return common.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// List liste les utilisateurs avec pagination
+func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+userColumns+` FROM users ORDER BY created_at, id LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la récupération des utilisateurs", err.Error())
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0, limit)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la récupération des utilisateurs", err.Error())
+	}
+
+	return users, nil
+}
+
+// Search recherche les utilisateurs correspondant au filtre, par pagination keyset sur
+// (created_at, id): l'index users_created_at_idx sert le tri et le curseur, l'index
+// users_email_lower_pattern_idx le préfixe d'email
+func (r *PostgresUserRepository) Search(ctx context.Context, filter *models.UserFilter, limit int) ([]*models.User, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.EmailPrefix != "" {
+		conditions = append(conditions, `lower(email) LIKE `+arg(escapeLike(strings.ToLower(filter.EmailPrefix))+"%"))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conditions = append(conditions, `created_at >= `+arg(filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conditions = append(conditions, `created_at < `+arg(filter.CreatedBefore))
+	}
+	if filter.AfterID != "" {
+		conditions = append(conditions, `(created_at, id) > (`+arg(filter.AfterCreatedAt)+`, `+arg(filter.AfterID)+`::uuid)`)
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+	query += ` ORDER BY created_at, id LIMIT ` + arg(limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, pgInvalidTextRepresentation) {
+			return nil, common.NewAppError(common.ErrCodeInvalidInput, "Curseur de pagination invalide")
+		}
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la recherche des utilisateurs", err.Error())
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0, limit)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la recherche des utilisateurs", err.Error())
+	}
+
+	return users, nil
+}
+
+// likeEscaper échappe les caractères spéciaux d'un motif LIKE (échappement par défaut: \)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike échappe une valeur pour la rechercher littéralement dans un motif LIKE
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// rowScanner est satisfait par *sql.Row et *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser convertit une ligne de la table users en models.User
+func scanUser(row rowScanner) (*models.User, error) {
+	var (
+		user   models.User
+		traits []byte
+	)
+
+	err := row.Scan(&user.ID, &user.Email, &user.FirstName, &user.LastName, &traits, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isPgError(err, pgInvalidTextRepresentation) {
+			return nil, common.ErrUserNotFound
+		}
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la lecture de l'utilisateur", err.Error())
+	}
+
+	if len(traits) > 0 {
+		if err := json.Unmarshal(traits, &user.Traits); err != nil {
+			return nil, common.NewAppError(common.ErrCodeInternal, "Traits utilisateur invalides", err.Error())
+		}
+	}
+
+	return &user, nil
+}
+
+// marshalTraits sérialise les traits en JSON pour la colonne JSONB
+func marshalTraits(traits map[string]interface{}) (string, error) {
+	if traits == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(traits)
+	if err != nil {
+		return "", common.NewAppError(common.ErrCodeInvalidInput, "Traits utilisateur invalides", err.Error())
+	}
+	return string(data), nil
+}
+
+// isPgError vérifie si une erreur correspond au code PostgreSQL donné
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func newTestPostgresUserRepository(t *testing.T) (UserRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresUserRepository(db), mock
+}
+
+func TestPostgresUserRepository_Create(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	now := time.Now()
+	user := &models.User{
+		ID:        "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		Email:     "test@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Traits:    map[string]interface{}{"email": "test@example.com"},
+	}
+
+	mock.ExpectQuery(`INSERT INTO users`).
+		WithArgs(user.ID, user.Email, user.FirstName, user.LastName, `{"email":"test@example.com"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(user.ID, now, now))
+
+	// Act
+	err := repo.Create(context.Background(), user)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !user.CreatedAt.Equal(now) {
+		t.Errorf("Create() createdAt = %v, want %v", user.CreatedAt, now)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresUserRepository_Create_DuplicateEmail(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	mock.ExpectQuery(`INSERT INTO users`).WillReturnError(&pgconn.PgError{Code: pgUniqueViolation})
+
+	// Act
+	err := repo.Create(context.Background(), &models.User{Email: "test@example.com"})
+
+	// Assert
+	if err != common.ErrUserExists {
+		t.Errorf("Create() error = %v, want %v", err, common.ErrUserExists)
+	}
+}
+
+func TestPostgresUserRepository_GetByEmail(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM users WHERE lower\(email\) = lower\(\$1\)`).
+		WithArgs("Test@Example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name", "last_name", "traits", "created_at", "updated_at"}).
+			AddRow("user-1", "test@example.com", "John", "Doe", []byte(`{"role":"admin"}`), now, now))
+
+	// Act
+	user, err := repo.GetByEmail(context.Background(), "Test@Example.com")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if user.ID != "user-1" {
+		t.Errorf("GetByEmail() id = %v, want user-1", user.ID)
+	}
+	if user.Traits["role"] != "admin" {
+		t.Errorf("GetByEmail() traits = %v, want role=admin", user.Traits)
+	}
+}
+
+func TestPostgresUserRepository_GetByID_NotFound(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	mock.ExpectQuery(`SELECT .* FROM users WHERE id = \$1`).WillReturnError(sql.ErrNoRows)
+
+	// Act
+	_, err := repo.GetByID(context.Background(), "missing")
+
+	// Assert
+	if err != common.ErrUserNotFound {
+		t.Errorf("GetByID() error = %v, want %v", err, common.ErrUserNotFound)
+	}
+}
+
+func TestPostgresUserRepository_Delete_NotFound(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	mock.ExpectExec(`DELETE FROM users`).WithArgs("missing").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Act
+	err := repo.Delete(context.Background(), "missing")
+
+	// Assert
+	if err != common.ErrUserNotFound {
+		t.Errorf("Delete() error = %v, want %v", err, common.ErrUserNotFound)
+	}
+}
+
+func TestPostgresUserRepository_List(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM users ORDER BY created_at, id LIMIT \$1 OFFSET \$2`).
+		WithArgs(2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name", "last_name", "traits", "created_at", "updated_at"}).
+			AddRow("user-1", "a@example.com", "A", "A", []byte(`{}`), now, now).
+			AddRow("user-2", "b@example.com", "B", "B", []byte(`{}`), now, now))
+
+	// Act
+	users, err := repo.List(context.Background(), 2, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(users) != 2 {
+		t.Errorf("List() len = %d, want 2", len(users))
+	}
+}
+
+func TestPostgresUserRepository_Search(t *testing.T) {
+	// Arrange
+	repo, mock := newTestPostgresUserRepository(t)
+	now := time.Now()
+	after := now.Add(-time.Hour)
+	mock.ExpectQuery(`SELECT .* FROM users WHERE lower\(email\) LIKE \$1 AND created_at >= \$2 AND \(created_at, id\) > \(\$3, \$4::uuid\) ORDER BY created_at, id LIMIT \$5`).
+		WithArgs(`jo\_hn%`, after, now, "user-1", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name", "last_name", "traits", "created_at", "updated_at"}).
+			AddRow("user-2", "jo_hn@example.com", "John", "Doe", []byte(`{}`), now, now))
+
+	// Act
+	users, err := repo.Search(context.Background(), &models.UserFilter{
+		EmailPrefix:    "Jo_hn",
+		CreatedAfter:   after,
+		AfterCreatedAt: now,
+		AfterID:        "user-1",
+	}, 3)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "user-2" {
+		t.Errorf("Search() = %v, want [user-2]", users)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"john":      "john",
+		"50%_off":   `50\%\_off`,
+		`back\path`: `back\\path`,
+	}
+	for value, want := range tests {
+		if got := escapeLike(value); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
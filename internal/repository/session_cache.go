@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"ndugu-backend/internal/metrics"
+)
+
+// SessionCache cache LRU borné des sessions Kratos validées, indexé par l'empreinte du token
+// (le token lui-même n'est pas conservé). Une entrée expire à la première des deux échéances:
+// expiration de la session ou TTL du cache; le TTL borne la durée pendant laquelle une session
+// révoquée hors de ce service (déconnexion via Kratos) reste acceptée.
+// Les validations simultanées d'un même token ne font qu'un appel à Kratos.
+type SessionCache struct {
+	capacity int
+	ttl      time.Duration
+	metrics  *metrics.Metrics
+	now      func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List                 // du plus récemment au moins récemment utilisé
+	identities map[string]map[string]bool // clés des sessions de chaque identité
+	generation uint64                     // incrémentée à chaque invalidation
+	group      singleflight.Group
+}
+
+// sessionCacheEntry session en cache
+type sessionCacheEntry struct {
+	key       string
+	session   *KratosSession
+	expiresAt time.Time
+}
+
+// NewSessionCache crée un cache d'au plus capacity sessions, conservées au plus ttl
+func NewSessionCache(capacity int, ttl time.Duration, m *metrics.Metrics) *SessionCache {
+	return &SessionCache{
+		capacity:   capacity,
+		ttl:        ttl,
+		metrics:    m,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		identities: make(map[string]map[string]bool),
+	}
+}
+
+// sessionCacheKey empreinte d'un identifiant de session; kind distingue les tokens des cookies
+func sessionCacheKey(kind, credential string) string {
+	sum := sha256.Sum256([]byte(kind + ":" + credential))
+	return hex.EncodeToString(sum[:])
+}
+
+// Load retourne la session en cache ou la valide avec fetch, puis la met en cache si elle est active.
+// Les erreurs ne sont pas mises en cache. La session retournée est partagée: elle ne doit pas être modifiée.
+func (c *SessionCache) Load(ctx context.Context, key string, fetch func(ctx context.Context) (*KratosSession, error)) (*KratosSession, error) {
+	if session, ok := c.get(key); ok {
+		c.metrics.ObserveSessionCache(true)
+		return session, nil
+	}
+	c.metrics.ObserveSessionCache(false)
+
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
+		c.mu.Lock()
+		generation := c.generation
+		c.mu.Unlock()
+
+		// L'appel est partagé: l'annulation de la requête qui l'a lancé ne doit pas faire échouer les autres
+		session, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		c.add(key, session, generation)
+		return session, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*KratosSession), nil
+}
+
+// Invalidate retire la session d'une clé du cache
+func (c *SessionCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.group.Forget(key)
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// InvalidateIdentity retire du cache toutes les sessions d'une identité
+func (c *SessionCache) InvalidateIdentity(identityID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key := range c.identities[identityID] {
+		c.group.Forget(key)
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+// identityOf retourne l'identité de la session en cache sous une clé, même expirée
+func (c *SessionCache) identityOf(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	return element.Value.(*sessionCacheEntry).session.Identity.ID, true
+}
+
+// Len retourne le nombre de sessions en cache
+func (c *SessionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// get retourne la session non expirée d'une clé et la marque comme la plus récemment utilisée
+func (c *SessionCache) get(key string) (*KratosSession, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*sessionCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.session, true
+}
+
+// add met en cache une session active, sauf si une invalidation a eu lieu depuis generation:
+// la session a pu être révoquée pendant sa validation
+func (c *SessionCache) add(key string, session *KratosSession, generation uint64) {
+	expiresAt := c.now().Add(c.ttl)
+	if !session.ExpiresAt.IsZero() && session.ExpiresAt.Before(expiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+	if !session.Active || !c.now().Before(expiresAt) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&sessionCacheEntry{key: key, session: session, expiresAt: expiresAt})
+	if c.identities[session.Identity.ID] == nil {
+		c.identities[session.Identity.ID] = make(map[string]bool)
+	}
+	c.identities[session.Identity.ID][key] = true
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove retire une entrée du cache et de l'index des identités; c.mu doit être verrouillé
+func (c *SessionCache) remove(element *list.Element) {
+	entry := element.Value.(*sessionCacheEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+
+	identityID := entry.session.Identity.ID
+	delete(c.identities[identityID], entry.key)
+	if len(c.identities[identityID]) == 0 {
+		delete(c.identities, identityID)
+	}
+}
+
+// cachedKratosClient met en cache les sessions validées par un KratosClient.
+// Les sessions sont retirées du cache lorsqu'elles sont révoquées ou que leur identité est modifiée ou supprimée.
+type cachedKratosClient struct {
+	KratosClient
+	cache *SessionCache
+}
+
+// NewCachedKratosClient enveloppe client pour mettre en cache les sessions validées
+func NewCachedKratosClient(client KratosClient, cache *SessionCache) KratosClient {
+	return &cachedKratosClient{KratosClient: client, cache: cache}
+}
+
+// ValidateSession valide un token de session, depuis le cache si possible
+func (c *cachedKratosClient) ValidateSession(ctx context.Context, sessionToken string) (*KratosSession, error) {
+	return c.cache.Load(ctx, sessionCacheKey("token", sessionToken), func(ctx context.Context) (*KratosSession, error) {
+		return c.KratosClient.ValidateSession(ctx, sessionToken)
+	})
+}
+
+// ValidateSessionCookie valide un cookie de session, depuis le cache si possible
+func (c *cachedKratosClient) ValidateSessionCookie(ctx context.Context, cookie string) (*KratosSession, error) {
+	return c.cache.Load(ctx, sessionCacheKey("cookie", cookie), func(ctx context.Context) (*KratosSession, error) {
+		return c.KratosClient.ValidateSessionCookie(ctx, cookie)
+	})
+}
+
+// RevokeSession révoque un token de session et retire du cache, même si Kratos échoue, le token et toutes
+// les sessions de son identité: la session révoquée a pu être mise en cache aussi sous son cookie.
+// Si le token n'est pas en cache, son identité est demandée à Kratos avant la révocation.
+func (c *cachedKratosClient) RevokeSession(ctx context.Context, sessionToken string) error {
+	key := sessionCacheKey("token", sessionToken)
+	identityID, ok := c.cache.identityOf(key)
+	if !ok {
+		if session, err := c.KratosClient.ValidateSession(ctx, sessionToken); err == nil {
+			identityID = session.Identity.ID
+		}
+	}
+	defer func() {
+		c.cache.Invalidate(key)
+		if identityID != "" {
+			c.cache.InvalidateIdentity(identityID)
+		}
+	}()
+	return c.KratosClient.RevokeSession(ctx, sessionToken)
+}
+
+// UpdateUser met à jour une identité et retire ses sessions du cache, dont les traits sont devenus obsolètes
+func (c *cachedKratosClient) UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*KratosUser, error) {
+	defer c.cache.InvalidateIdentity(userID)
+	return c.KratosClient.UpdateUser(ctx, userID, email, firstName, lastName)
+}
+
+// DeleteUser supprime une identité et retire ses sessions du cache: Kratos les révoque avec l'identité
+func (c *cachedKratosClient) DeleteUser(ctx context.Context, userID string) error {
+	defer c.cache.InvalidateIdentity(userID)
+	return c.KratosClient.DeleteUser(ctx, userID)
+}
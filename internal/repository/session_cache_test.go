@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/metrics"
+)
+
+// sessionFetcher simule Kratos et compte les validations
+type sessionFetcher struct {
+	mu      sync.Mutex
+	calls   int
+	session KratosSession
+	err     error
+}
+
+func (f *sessionFetcher) fetch(ctx context.Context) (*KratosSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	session := f.session
+	return &session, nil
+}
+
+func TestSessionCache_Load(t *testing.T) {
+	start := time.Now()
+	active := KratosSession{Id: "session-1", Active: true, Identity: KratosUser{ID: "user-1"}, ExpiresAt: start.Add(time.Hour)}
+
+	tests := []struct {
+		name      string
+		session   KratosSession
+		fetchErr  error
+		act       func(c *SessionCache, clock *time.Time, load func(key string))
+		wantCalls int
+	}{
+		{
+			name:      "second lookup is a hit",
+			session:   active,
+			act:       func(c *SessionCache, clock *time.Time, load func(string)) { load("a"); load("a") },
+			wantCalls: 1,
+		},
+		{
+			name:    "entry expires after the cache ttl",
+			session: active,
+			act: func(c *SessionCache, clock *time.Time, load func(string)) {
+				load("a")
+				*clock = clock.Add(time.Minute)
+				load("a")
+			},
+			wantCalls: 2,
+		},
+		{
+			name:    "entry expires with the session",
+			session: KratosSession{Id: "session-1", Active: true, Identity: KratosUser{ID: "user-1"}, ExpiresAt: start.Add(10 * time.Second)},
+			act: func(c *SessionCache, clock *time.Time, load func(string)) {
+				load("a")
+				*clock = clock.Add(10 * time.Second)
+				load("a")
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "inactive session is not cached",
+			session:   KratosSession{Id: "session-1", Identity: KratosUser{ID: "user-1"}, ExpiresAt: start.Add(time.Hour)},
+			act:       func(c *SessionCache, clock *time.Time, load func(string)) { load("a"); load("a") },
+			wantCalls: 2,
+		},
+		{
+			name:      "errors are not cached",
+			fetchErr:  errors.New("kratos indisponible"),
+			act:       func(c *SessionCache, clock *time.Time, load func(string)) { load("a"); load("a") },
+			wantCalls: 2,
+		},
+		{
+			name:    "least recently used entry is evicted",
+			session: active,
+			act: func(c *SessionCache, clock *time.Time, load func(string)) {
+				load("a")
+				load("b")
+				load("a")
+				load("c")
+				load("a")
+				load("b")
+			},
+			wantCalls: 4,
+		},
+		{
+			name:    "revoked session is invalidated",
+			session: active,
+			act: func(c *SessionCache, clock *time.Time, load func(string)) {
+				load("a")
+				c.Invalidate("a")
+				load("a")
+			},
+			wantCalls: 2,
+		},
+		{
+			name:    "deleted identity sessions are invalidated",
+			session: active,
+			act: func(c *SessionCache, clock *time.Time, load func(string)) {
+				load("a")
+				load("b")
+				c.InvalidateIdentity("user-1")
+				load("a")
+				load("b")
+			},
+			wantCalls: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			clock := start
+			cache := NewSessionCache(2, time.Minute, metrics.New())
+			cache.now = func() time.Time { return clock }
+			fetcher := &sessionFetcher{session: tt.session, err: tt.fetchErr}
+			load := func(key string) {
+				cache.Load(context.Background(), key, fetcher.fetch)
+			}
+
+			// Act
+			tt.act(cache, &clock, load)
+
+			// Assert
+			if fetcher.calls != tt.wantCalls {
+				t.Errorf("Kratos called %d times, want %d", fetcher.calls, tt.wantCalls)
+			}
+			if cache.Len() > 2 {
+				t.Errorf("Len() = %d, want at most 2", cache.Len())
+			}
+		})
+	}
+}
+
+func TestSessionCache_CollapsesConcurrentLookups(t *testing.T) {
+	// Arrange: Kratos ne répond qu'une fois toutes les validations lancées
+	m := metrics.New()
+	cache := NewSessionCache(10, time.Minute, m)
+	release := make(chan struct{})
+	fetcher := &sessionFetcher{session: KratosSession{Id: "session-1", Active: true, Identity: KratosUser{ID: "user-1"}}}
+	fetch := func(ctx context.Context) (*KratosSession, error) {
+		<-release
+		return fetcher.fetch(ctx)
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Load(context.Background(), "a", fetch); err != nil {
+				t.Errorf("Load() error = %v", err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	cache.Load(context.Background(), "a", fetch)
+
+	// Assert
+	if fetcher.calls != 1 {
+		t.Errorf("Kratos called %d times, want 1", fetcher.calls)
+	}
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{`ndugu_session_cache_requests_total{result="hit"} 1`, `ndugu_session_cache_requests_total{result="miss"} 10`} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
+
+func TestCachedKratosClient_RevokeSession(t *testing.T) {
+	// Arrange: la session est en cache avant sa révocation
+	client := NewCachedKratosClient(newTestKratosClient(t), NewSessionCache(10, time.Minute, metrics.New()))
+	ctx := context.Background()
+	if _, err := client.ValidateSession(ctx, "valid-token"); err != nil {
+		t.Fatalf("ValidateSession() error = %v", err)
+	}
+
+	// Act
+	revokeErr := client.RevokeSession(ctx, "valid-token")
+	_, validateErr := client.ValidateSession(ctx, "valid-token")
+
+	// Assert
+	if revokeErr != nil {
+		t.Fatalf("RevokeSession() error = %v", revokeErr)
+	}
+	if !isInvalidSession(validateErr) {
+		t.Errorf("ValidateSession() after revocation error = %v, want invalid session", validateErr)
+	}
+	if err := client.RevokeSession(ctx, "valid-token"); !isInvalidSession(err) {
+		t.Errorf("RevokeSession() twice error = %v, want invalid session", err)
+	}
+}
+
+func isInvalidSession(err error) bool {
+	var appErr *common.AppError
+	return errors.As(err, &appErr) && appErr.Code == common.ErrCodeInvalidSession
+}
+
+// countingKratos simule Kratos: tout token ou cookie désigne une session de user-1
+type countingKratos struct {
+	KratosClient
+	validations atomic.Int32
+}
+
+func (k *countingKratos) ValidateSession(ctx context.Context, sessionToken string) (*KratosSession, error) {
+	k.validations.Add(1)
+	return &KratosSession{Id: "session-1", Active: true, Identity: KratosUser{ID: "user-1"}}, nil
+}
+
+func (k *countingKratos) ValidateSessionCookie(ctx context.Context, cookie string) (*KratosSession, error) {
+	return k.ValidateSession(ctx, cookie)
+}
+
+func (k *countingKratos) RevokeSession(ctx context.Context, sessionToken string) error {
+	return nil
+}
+
+func (k *countingKratos) UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*KratosUser, error) {
+	return &KratosUser{ID: userID, Email: email}, nil
+}
+
+func TestCachedKratosClient_InvalidatesIdentitySessions(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, client KratosClient) error
+	}{
+		{name: "revoked token evicts the cookie", change: func(ctx context.Context, client KratosClient) error {
+			return client.RevokeSession(ctx, "token")
+		}},
+		{name: "revoking an uncached token evicts the cookie", change: func(ctx context.Context, client KratosClient) error {
+			return client.RevokeSession(ctx, "other-token")
+		}},
+		{name: "updated identity", change: func(ctx context.Context, client KratosClient) error {
+			_, err := client.UpdateUser(ctx, "user-1", "jane@example.com", "Jane", "Doe")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: la session est en cache sous son token et sous son cookie
+			kratos := &countingKratos{}
+			client := NewCachedKratosClient(kratos, NewSessionCache(10, time.Minute, metrics.New()))
+			ctx := context.Background()
+			client.ValidateSession(ctx, "token")
+			client.ValidateSessionCookie(ctx, "cookie")
+
+			// Act
+			if err := tt.change(ctx, client); err != nil {
+				t.Fatalf("change error = %v", err)
+			}
+			before := kratos.validations.Load()
+			client.ValidateSessionCookie(ctx, "cookie")
+
+			// Assert
+			if kratos.validations.Load() != before+1 {
+				t.Error("ValidateSessionCookie() served a stale session from the cache")
+			}
+		})
+	}
+}
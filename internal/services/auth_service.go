@@ -2,22 +2,102 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"ndugu-backend/internal/common"
 	"ndugu-backend/internal/models"
 	"ndugu-backend/internal/repository"
+	"ndugu-backend/internal/tracing"
+	"ndugu-backend/internal/validation"
 )
 
 // AuthService interface pour le service d'authentification
 type AuthService interface {
 	CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error)
 	GetUser(ctx context.Context, userID string) (*models.UserResponse, error)
+	UpdateUser(ctx context.Context, req *models.UpdateUserRequest) (*models.UserResponse, error)
+	DeleteUser(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context, req *models.ListUsersRequest) (*models.ListUsersResponse, error)
 	ValidateSession(ctx context.Context, req *models.ValidateSessionRequest) (*models.ValidateSessionResponse, error)
+	RevokeSession(ctx context.Context, req *models.RevokeSessionRequest) error
+	IntrospectAccessToken(ctx context.Context, token string) (*models.TokenIntrospection, error)
 	CreateOAuth2Client(ctx context.Context, req *models.CreateOAuth2ClientRequest) (*models.OAuth2Client, error)
+	GetOAuth2Client(ctx context.Context, clientID string) (*models.OAuth2Client, error)
+	ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) (*models.ListOAuth2ClientsResponse, error)
+	UpdateOAuth2Client(ctx context.Context, req *models.UpdateOAuth2ClientRequest) (*models.OAuth2Client, error)
+	DeleteOAuth2Client(ctx context.Context, clientID string) error
+	RotateOAuth2ClientSecret(ctx context.Context, clientID string) (*models.OAuth2Client, error)
 	CreatePermission(ctx context.Context, req *models.CreatePermissionRequest) (*models.PermissionResponse, error)
+	DeletePermission(ctx context.Context, req *models.DeletePermissionRequest) (*models.PermissionResponse, error)
 	CheckPermission(ctx context.Context, req *models.CheckPermissionRequest) (*models.PermissionResponse, error)
+	BatchCheckPermission(ctx context.Context, req *models.BatchCheckPermissionRequest) (*models.BatchCheckPermissionResponse, error)
+	ExpandPermission(ctx context.Context, req *models.ExpandPermissionRequest) (*models.SubjectTree, error)
+	ListRelations(ctx context.Context, req *models.ListRelationsRequest) (*models.ListRelationsResponse, error)
 }
 
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+
+	// userCompensationTimeout échéance de la suppression compensatoire d'une identité Kratos
+	userCompensationTimeout = 10 * time.Second
+
+	defaultOAuth2ClientPageSize = 20
+	maxOAuth2ClientPageSize     = 100
+
+	// tokenEndpointAuthMethodNone désigne un client public (SPA, mobile) sans secret
+	tokenEndpointAuthMethodNone = "none"
+	oauth2ClientSecretLength    = 32
+
+	maxPermissionBatchSize = 100
+	// permissionBatchConcurrency nombre maximal de vérifications Keto simultanées pour un lot
+	permissionBatchConcurrency = 8
+
+	// Tailles de page des tuples de relation, alignées sur celles de Keto
+	defaultRelationPageSize = 100
+	maxRelationPageSize     = 1000
+)
+
+var (
+	// userUpdateFields champs modifiables par UpdateUser, désignés par leur nom normalisé
+	// (minuscules, sans "_"): "first_name" et "firstName" désignent le même champ
+	userUpdateFields = map[string]bool{"email": true, "firstname": true, "lastname": true}
+
+	defaultOAuth2GrantTypes    = []string{"authorization_code", "refresh_token"}
+	defaultOAuth2ResponseTypes = []string{"code"}
+	defaultOAuth2Scopes        = []string{"openid", "profile", "email"}
+	defaultOAuth2AuthMethod    = "client_secret_basic"
+
+	allowedOAuth2GrantTypes = map[string]bool{
+		"authorization_code": true,
+		"refresh_token":      true,
+		"client_credentials": true,
+		"implicit":           true,
+		"urn:ietf:params:oauth:grant-type:jwt-bearer":  true,
+		"urn:ietf:params:oauth:grant-type:device_code": true,
+	}
+	allowedOAuth2ResponseTypes = map[string]bool{
+		"code":     true,
+		"token":    true,
+		"id_token": true,
+	}
+	allowedOAuth2AuthMethods = map[string]bool{
+		"client_secret_basic":       true,
+		"client_secret_post":        true,
+		"private_key_jwt":           true,
+		tokenEndpointAuthMethodNone: true,
+	}
+)
+
 // authService implémentation du service d'authentification
 type authService struct {
 	userRepo  repository.UserRepository
@@ -39,32 +119,36 @@ func NewAuthService(
 }
 
 // CreateUser crée un nouvel utilisateur
-func (s *authService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error) {
-	s.logger.Info("Début de création d'utilisateur", "email", req.Email, "firstName", req.FirstName, "lastName", req.LastName)
+func (s *authService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (_ *models.UserResponse, err error) {
+	ctx, span := startSpan(ctx, "auth", "CreateUser")
+	defer func() { tracing.End(span, err) }()
+
+	s.logger.WithContext(ctx).Info("Début de création d'utilisateur", "email", req.Email, "firstName", req.FirstName, "lastName", req.LastName)
 
 	// Validation
-	if err := s.validateCreateUserRequest(req); err != nil {
-		s.logger.Error("Validation échouée pour la création d'utilisateur", "email", req.Email, "error", err)
+	if err := validation.Struct(req); err != nil {
+		s.logger.WithContext(ctx).Error("Validation échouée pour la création d'utilisateur", "email", req.Email, "error", err)
 		return nil, err
 	}
 
 	// Vérifier si l'utilisateur existe déjà
 	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err == nil && existingUser != nil {
-		s.logger.Warn("Tentative de création d'un utilisateur existant", "email", req.Email)
+		s.logger.WithContext(ctx).Warn("Tentative de création d'un utilisateur existant", "email", req.Email)
 		return nil, common.ErrUserExists
 	}
 
-	s.logger.Debug("Utilisateur n'existe pas, création via Kratos", "email", req.Email)
+	s.logger.WithContext(ctx).Debug("Utilisateur n'existe pas, création via Kratos", "email", req.Email)
 
 	// Créer l'utilisateur via Kratos
 	user, err := s.oryClient.CreateUser(ctx, req.Email, req.FirstName, req.LastName)
 	if err != nil {
-		s.logger.Error("Erreur lors de la création de l'utilisateur via Kratos", "email", req.Email, "error", err)
-		return nil, common.NewAppError(common.ErrCodeKratosError, "Erreur lors de la création de l'utilisateur", err.Error())
+		s.logger.WithContext(ctx).Error("Erreur lors de la création de l'utilisateur via Kratos", "email", req.Email, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la création de l'utilisateur")
 	}
 
-	s.logger.Info("Utilisateur créé avec succès via Kratos", "userId", user.ID, "email", user.Email)
+	s.logger.WithContext(ctx).Info("Utilisateur créé avec succès via Kratos", "userId", user.ID, "email", user.Email)
+	span.SetAttributes(attribute.String(attrUserID, user.ID))
 
 	// Sauvegarder en base de données locale
 	dbUser := &models.User{
@@ -78,36 +162,76 @@ func (s *authService) CreateUser(ctx context.Context, req *models.CreateUserRequ
 	}
 
 	if err := s.userRepo.Create(ctx, dbUser); err != nil {
-		s.logger.Error("Erreur lors de la sauvegarde de l'utilisateur en base locale", "userId", user.ID, "error", err)
-		// Ne pas retourner d'erreur car l'utilisateur a été créé dans Kratos
-	} else {
-		s.logger.Debug("Utilisateur sauvegardé avec succès en base locale", "userId", user.ID)
+		s.logger.WithContext(ctx).Error("Erreur lors de la sauvegarde de l'utilisateur en base locale", "userId", user.ID, "error", err)
+		if local := s.compensateUserCreation(ctx, user.ID); local != nil {
+			return local.ToResponse(), nil
+		}
+		return nil, err
 	}
 
+	s.logger.WithContext(ctx).Debug("Utilisateur sauvegardé avec succès en base locale", "userId", user.ID)
 	return dbUser.ToResponse(), nil
 }
 
+// compensateUserCreation supprime l'identité Kratos d'un utilisateur que la base locale n'a pas enregistré,
+// pour que les deux stockages ne divergent pas. La réconciliation (UserReconciler) a pu créer la ligne locale
+// de userID entre-temps: l'identité n'est alors pas supprimée et la ligne est retournée. Si la base locale ne
+// peut être lue, l'identité est conservée et la réconciliation créera la ligne manquante. La compensation
+// n'est pas interrompue par l'annulation de la requête; si la suppression échoue, l'écart est aussi réparé
+// par la réconciliation.
+func (s *authService) compensateUserCreation(ctx context.Context, userID string) *models.User {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userCompensationTimeout)
+	defer cancel()
+	ctx, span := startSpan(ctx, "auth", "CompensateUserCreation", attribute.String(attrUserID, userID))
+	var err error
+	defer func() { tracing.End(span, err) }()
+
+	local, err := s.userRepo.GetByID(ctx, userID)
+	switch {
+	case err == nil:
+		span.SetAttributes(attribute.String("compensation.outcome", "local_user_exists"))
+		s.logger.WithContext(ctx).Warn("Utilisateur local créé entre-temps par la réconciliation, identité Kratos conservée", "userId", userID)
+		return local
+	case !isUserNotFound(err):
+		span.SetAttributes(attribute.String("compensation.outcome", "skipped"))
+		s.logger.WithContext(ctx).Error("Base locale illisible, identité Kratos conservée pour la réconciliation", "userId", userID, "error", err)
+		return nil
+	}
+
+	err = s.oryClient.DeleteUser(ctx, userID)
+	if isUserNotFound(err) {
+		err = nil
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Échec de la suppression compensatoire de l'identité Kratos", "userId", userID, "error", err)
+		return nil
+	}
+	span.SetAttributes(attribute.String("compensation.outcome", "identity_deleted"))
+	s.logger.WithContext(ctx).Warn("Identité Kratos supprimée après l'échec de la sauvegarde locale", "userId", userID)
+	return nil
+}
+
 // GetUser récupère un utilisateur par son ID
 func (s *authService) GetUser(ctx context.Context, userID string) (*models.UserResponse, error) {
-	s.logger.Info("Début de récupération d'utilisateur", "userId", userID)
+	s.logger.WithContext(ctx).Info("Début de récupération d'utilisateur", "userId", userID)
 
 	if userID == "" {
-		s.logger.Error("ID utilisateur vide fourni")
+		s.logger.WithContext(ctx).Error("ID utilisateur vide fourni")
 		return nil, common.ErrInvalidInput
 	}
 
 	// Récupérer depuis la base de données locale
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Debug("Utilisateur non trouvé en base locale, tentative via Kratos", "userId", userID)
+		s.logger.WithContext(ctx).Debug("Utilisateur non trouvé en base locale, tentative via Kratos", "userId", userID)
 		// Essayer de récupérer depuis Kratos
 		kratosUser, kratosErr := s.oryClient.GetUser(ctx, userID)
 		if kratosErr != nil {
-			s.logger.Error("Erreur lors de la récupération de l'utilisateur", "userId", userID, "localError", err, "kratosError", kratosErr)
+			s.logger.WithContext(ctx).Error("Erreur lors de la récupération de l'utilisateur", "userId", userID, "localError", err, "kratosError", kratosErr)
 			return nil, common.ErrUserNotFound
 		}
 
-		s.logger.Info("Utilisateur trouvé via Kratos", "userId", userID)
+		s.logger.WithContext(ctx).Info("Utilisateur trouvé via Kratos", "userId", userID)
 
 		// Convertir l'utilisateur Kratos
 		user = &models.User{
@@ -124,24 +248,181 @@ func (s *authService) GetUser(ctx context.Context, userID string) (*models.UserR
 	return user.ToResponse(), nil
 }
 
-// ValidateSession valide une session
-func (s *authService) ValidateSession(ctx context.Context, req *models.ValidateSessionRequest) (*models.ValidateSessionResponse, error) {
-	s.logger.Info("Début de validation de session", "sessionToken", req.SessionToken[:10]+"...")
+// UpdateUser met à jour l'email et le nom d'un utilisateur.
+// Les traits de l'identité Kratos sont modifiés en premier (source de vérité), puis
+// l'utilisateur local; une nouvelle tentative après un échec local est sans effet sur Kratos.
+func (s *authService) UpdateUser(ctx context.Context, req *models.UpdateUserRequest) (*models.UserResponse, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	mask, err := normalizeUserUpdateMask(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
 
-	if req.SessionToken == "" {
-		s.logger.Error("Token de session vide fourni")
-		return nil, common.ErrInvalidInput
+	current, err := s.oryClient.GetUser(ctx, req.ID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de l'utilisateur via Kratos", "userId", req.ID, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la récupération de l'utilisateur")
+	}
+
+	// Les valeurs résultantes respectent les mêmes règles qu'à la création
+	update := &models.CreateUserRequest{
+		Email:     userUpdateValue(mask, "email", req.Email, current.Email),
+		FirstName: userUpdateValue(mask, "firstname", req.FirstName, current.FirstName),
+		LastName:  userUpdateValue(mask, "lastname", req.LastName, current.LastName),
+	}
+	if err := validation.Struct(update); err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(update.Email, current.Email) {
+		if existing, err := s.userRepo.GetByEmail(ctx, update.Email); err == nil && existing.ID != req.ID {
+			s.logger.WithContext(ctx).Warn("Email déjà utilisé par un autre utilisateur", "userId", req.ID)
+			return nil, common.ErrUserExists
+		}
+	}
+
+	updated, err := s.oryClient.UpdateUser(ctx, req.ID, update.Email, update.FirstName, update.LastName)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour de l'utilisateur via Kratos", "userId", req.ID, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la mise à jour de l'utilisateur")
+	}
+
+	// Synchroniser l'utilisateur local; il est créé s'il n'avait pas été enregistré
+	if err := s.userRepo.Update(ctx, updated); err != nil {
+		if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeUserNotFound {
+			s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour de l'utilisateur en base locale", "userId", req.ID, "error", err)
+			return nil, err
+		}
+		if err := s.userRepo.Create(ctx, updated); err != nil {
+			s.logger.WithContext(ctx).Error("Erreur lors de la sauvegarde de l'utilisateur en base locale", "userId", req.ID, "error", err)
+			return nil, err
+		}
+	}
+
+	s.logger.WithContext(ctx).Info("Utilisateur mis à jour", "userId", req.ID)
+
+	return updated.ToResponse(), nil
+}
+
+// DeleteUser supprime un utilisateur: ses permissions Keto, son identité Kratos puis
+// l'utilisateur local. Chaque étape est idempotente: après un échec partiel, une nouvelle
+// tentative termine la suppression. ErrUserNotFound n'est renvoyée que si l'utilisateur
+// n'existait ni dans Kratos ni en base locale.
+func (s *authService) DeleteUser(ctx context.Context, userID string) error {
+	if err := common.ValidateRequired(userID, "ID utilisateur"); err != nil {
+		return err
+	}
+
+	// Les permissions sont retirées d'abord: un utilisateur supprimé ne doit en conserver aucune
+	if err := s.oryClient.DeleteSubjectPermissions(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression des permissions via Keto", "userId", userID, "error", err)
+		return wrapOryError(err, common.ErrCodeKetoError, "Erreur lors de la suppression des permissions de l'utilisateur")
+	}
+
+	found := false
+	if err := s.oryClient.DeleteUser(ctx, userID); err == nil {
+		found = true
+	} else if !isUserNotFound(err) {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression de l'utilisateur via Kratos", "userId", userID, "error", err)
+		return wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la suppression de l'utilisateur")
+	}
+
+	if err := s.userRepo.Delete(ctx, userID); err == nil {
+		found = true
+	} else if !isUserNotFound(err) {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression de l'utilisateur en base locale", "userId", userID, "error", err)
+		return err
+	}
+
+	if !found {
+		return common.ErrUserNotFound
+	}
+
+	s.logger.WithContext(ctx).Info("Utilisateur supprimé", "userId", userID)
+
+	return nil
+}
+
+// ListUsers liste les utilisateurs locaux par ordre de création.
+// Le jeton de page est opaque: il encode la position du dernier utilisateur renvoyé.
+func (s *authService) ListUsers(ctx context.Context, req *models.ListUsersRequest) (*models.ListUsersResponse, error) {
+	pageSize := req.PageSize
+	if pageSize < 0 {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Taille de page invalide")
+	}
+	if pageSize == 0 {
+		pageSize = defaultUserPageSize
+	}
+	if pageSize > maxUserPageSize {
+		pageSize = maxUserPageSize
+	}
+
+	filter := &models.UserFilter{
+		EmailPrefix:   strings.TrimSpace(req.EmailPrefix),
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+	if req.PageToken != "" {
+		cursor, err := decodeUserPageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		filter.AfterCreatedAt = cursor.CreatedAt
+		filter.AfterID = cursor.ID
+	}
+
+	// Un utilisateur de plus indique l'existence d'une page suivante
+	users, err := s.userRepo.Search(ctx, filter, pageSize+1)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la recherche des utilisateurs", "error", err)
+		return nil, err
+	}
+
+	response := &models.ListUsersResponse{Users: make([]*models.UserResponse, 0, pageSize)}
+	if len(users) > pageSize {
+		users = users[:pageSize]
+		last := users[len(users)-1]
+		response.NextPageToken = encodeUserPageToken(userPageCursor{CreatedAt: last.CreatedAt, ID: last.ID})
 	}
+	for _, user := range users {
+		response.Users = append(response.Users, user.ToResponse())
+	}
+
+	return response, nil
+}
 
-	session, err := s.oryClient.ValidateSession(ctx, req.SessionToken)
+// ValidateSession valide une session
+func (s *authService) ValidateSession(ctx context.Context, req *models.ValidateSessionRequest) (*models.ValidateSessionResponse, error) {
+	s.logger.WithContext(ctx).Info("Début de validation de session")
+
+	var (
+		session *models.Session
+		err     error
+	)
+	switch {
+	case req.SessionToken != "":
+		session, err = s.oryClient.ValidateSession(ctx, req.SessionToken)
+	case req.SessionCookie != "":
+		session, err = s.oryClient.ValidateSessionCookie(ctx, req.SessionCookie)
+	default:
+		s.logger.WithContext(ctx).Error("Aucun token ni cookie de session fourni")
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Token ou cookie de session requis")
+	}
 	if err != nil {
-		s.logger.Error("Erreur lors de la validation de session", "error", err)
-		return &models.ValidateSessionResponse{
-			Valid: false,
-		}, nil
+		// Une session refusée n'est pas une erreur: seule l'indisponibilité de Kratos en est une
+		if appErr, ok := err.(*common.AppError); ok && (appErr.Code == common.ErrCodeInvalidSession || appErr.Code == common.ErrCodeSessionExpired) {
+			s.logger.WithContext(ctx).Info("Session refusée par Kratos", "reason", appErr.Code)
+			return &models.ValidateSessionResponse{
+				Valid: false,
+			}, nil
+		}
+		s.logger.WithContext(ctx).Error("Erreur lors de la validation de session", "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la validation de la session")
 	}
 
-	s.logger.Info("Session validée avec succès", "userId", session.UserID)
+	s.logger.WithContext(ctx).Info("Session validée avec succès", "userId", session.UserID, "aal", session.AAL)
 
 	// Extraire l'email depuis l'identité
 	email := ""
@@ -150,125 +431,531 @@ func (s *authService) ValidateSession(ctx context.Context, req *models.ValidateS
 	}
 
 	return &models.ValidateSessionResponse{
-		Valid:     true,
-		UserID:    session.UserID,
-		Email:     email,
-		ExpiresAt: session.ExpiresAt,
+		Valid:                 true,
+		UserID:                session.UserID,
+		Email:                 email,
+		ExpiresAt:             session.ExpiresAt,
+		AuthenticatedAt:       session.AuthenticatedAt,
+		AAL:                   session.AAL,
+		AuthenticationMethods: session.AuthenticationMethods,
 	}, nil
 }
 
-// CreateOAuth2Client crée un client OAuth2
+// RevokeSession révoque un token de session (déconnexion d'un client natif).
+// Une session déjà révoquée ou expirée n'est pas une erreur.
+func (s *authService) RevokeSession(ctx context.Context, req *models.RevokeSessionRequest) error {
+	if req.SessionToken == "" {
+		return common.NewFieldError("sessionToken", "required")
+	}
+
+	if err := s.oryClient.RevokeSession(ctx, req.SessionToken); err != nil {
+		if appErr, ok := err.(*common.AppError); ok && appErr.Code == common.ErrCodeInvalidSession {
+			s.logger.WithContext(ctx).Info("Session déjà révoquée ou expirée")
+			return nil
+		}
+		s.logger.WithContext(ctx).Error("Erreur lors de la révocation de session", "error", err)
+		return wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la révocation de la session")
+	}
+
+	s.logger.WithContext(ctx).Info("Session révoquée avec succès")
+	return nil
+}
+
+// IntrospectAccessToken vérifie un jeton d'accès OAuth2 émis par Hydra.
+// Un jeton inactif n'est pas une erreur: Active vaut alors false.
+func (s *authService) IntrospectAccessToken(ctx context.Context, token string) (*models.TokenIntrospection, error) {
+	if token == "" {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Jeton d'accès requis")
+	}
+
+	introspection, err := s.oryClient.IntrospectToken(ctx, token)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de l'introspection du jeton d'accès", "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de l'introspection du jeton d'accès")
+	}
+
+	if !introspection.Active {
+		s.logger.WithContext(ctx).Info("Jeton d'accès inactif")
+	}
+
+	return introspection, nil
+}
+
+// CreateOAuth2Client crée un client OAuth2.
+// Le secret généré par Hydra n'est renvoyé qu'ici.
 func (s *authService) CreateOAuth2Client(ctx context.Context, req *models.CreateOAuth2ClientRequest) (*models.OAuth2Client, error) {
+	client := &models.OAuth2Client{
+		ID:                      strings.TrimSpace(req.ID),
+		Name:                    strings.TrimSpace(req.Name),
+		RedirectURIs:            req.RedirectURIs,
+		GrantTypes:              withDefault(req.GrantTypes, defaultOAuth2GrantTypes),
+		ResponseTypes:           withDefault(req.ResponseTypes, defaultOAuth2ResponseTypes),
+		Scopes:                  withDefault(req.Scopes, defaultOAuth2Scopes),
+		TokenEndpointAuthMethod: req.TokenEndpointAuthMethod,
+	}
+	if client.TokenEndpointAuthMethod == "" {
+		client.TokenEndpointAuthMethod = defaultOAuth2AuthMethod
+	}
+
 	// Validation
-	if err := s.validateCreateOAuth2ClientRequest(req); err != nil {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	if err := s.validateOAuth2Client(client); err != nil {
+		return nil, err
+	}
+
+	// Créer le client via Hydra
+	created, err := s.oryClient.CreateOAuth2Client(ctx, client)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la création du client OAuth2 via Hydra", "clientName", client.Name, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la création du client OAuth2")
+	}
+
+	s.logger.WithContext(ctx).Info("Client OAuth2 créé", "clientId", created.ID, "grantTypes", created.GrantTypes)
+
+	return created, nil
+}
+
+// GetOAuth2Client récupère un client OAuth2 (sans son secret)
+func (s *authService) GetOAuth2Client(ctx context.Context, clientID string) (*models.OAuth2Client, error) {
+	if err := common.ValidateRequired(clientID, "ID du client"); err != nil {
 		return nil, err
 	}
 
-	// Créer le client via Hydra (temporairement désactivé)
-	// client, err := s.oryClient.CreateOAuth2Client(ctx, req.ID, req.Name, req.RedirectURI)
-	// if err != nil {
-	// 	return nil, common.NewAppError(common.ErrCodeHydraError, "Erreur lors de la création du client OAuth2", err.Error())
-	// }
-
-	// Simulation temporaire
-	return &models.OAuth2Client{
-		ID:           req.ID,
-		Name:         req.Name,
-		Secret:       "temporairement-désactivé",
-		RedirectURIs: []string{req.RedirectURI},
-		GrantTypes:   []string{"authorization_code", "refresh_token"},
-		Scopes:       []string{"openid", "profile", "email"},
+	client, err := s.oryClient.GetOAuth2Client(ctx, clientID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération du client OAuth2 via Hydra", "clientId", clientID, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération du client OAuth2")
+	}
+
+	client.Secret = ""
+	return client, nil
+}
+
+// ListOAuth2Clients liste les clients OAuth2 (sans leurs secrets)
+func (s *authService) ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) (*models.ListOAuth2ClientsResponse, error) {
+	if pageSize < 0 {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Taille de page invalide")
+	}
+	if pageSize == 0 {
+		pageSize = defaultOAuth2ClientPageSize
+	}
+	if pageSize > maxOAuth2ClientPageSize {
+		pageSize = maxOAuth2ClientPageSize
+	}
+
+	clients, next, err := s.oryClient.ListOAuth2Clients(ctx, pageSize, pageToken)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération des clients OAuth2 via Hydra", "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération des clients OAuth2")
+	}
+
+	for _, client := range clients {
+		client.Secret = ""
+	}
+
+	return &models.ListOAuth2ClientsResponse{
+		Clients:       clients,
+		NextPageToken: next,
 	}, nil
 }
 
+// UpdateOAuth2Client met à jour un client OAuth2; les champs vides ne sont pas modifiés
+func (s *authService) UpdateOAuth2Client(ctx context.Context, req *models.UpdateOAuth2ClientRequest) (*models.OAuth2Client, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	client, err := s.GetOAuth2Client(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		client.Name = name
+	}
+	if len(req.RedirectURIs) > 0 {
+		client.RedirectURIs = req.RedirectURIs
+	}
+	if len(req.GrantTypes) > 0 {
+		client.GrantTypes = req.GrantTypes
+	}
+	if len(req.ResponseTypes) > 0 {
+		client.ResponseTypes = req.ResponseTypes
+	}
+	if len(req.Scopes) > 0 {
+		client.Scopes = req.Scopes
+	}
+	if req.TokenEndpointAuthMethod != "" {
+		client.TokenEndpointAuthMethod = req.TokenEndpointAuthMethod
+	}
+
+	if err := s.validateOAuth2Client(client); err != nil {
+		return nil, err
+	}
+
+	// Le secret est omis: Hydra conserve le secret existant
+	updated, err := s.oryClient.UpdateOAuth2Client(ctx, client)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour du client OAuth2 via Hydra", "clientId", req.ID, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la mise à jour du client OAuth2")
+	}
+
+	s.logger.WithContext(ctx).Info("Client OAuth2 mis à jour", "clientId", updated.ID)
+
+	updated.Secret = ""
+	return updated, nil
+}
+
+// DeleteOAuth2Client supprime un client OAuth2
+func (s *authService) DeleteOAuth2Client(ctx context.Context, clientID string) error {
+	if err := common.ValidateRequired(clientID, "ID du client"); err != nil {
+		return err
+	}
+
+	if err := s.oryClient.DeleteOAuth2Client(ctx, clientID); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression du client OAuth2 via Hydra", "clientId", clientID, "error", err)
+		return wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la suppression du client OAuth2")
+	}
+
+	s.logger.WithContext(ctx).Info("Client OAuth2 supprimé", "clientId", clientID)
+
+	return nil
+}
+
+// RotateOAuth2ClientSecret génère un nouveau secret pour un client confidentiel.
+// Le nouveau secret n'est renvoyé qu'une seule fois.
+func (s *authService) RotateOAuth2ClientSecret(ctx context.Context, clientID string) (*models.OAuth2Client, error) {
+	client, err := s.GetOAuth2Client(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client.TokenEndpointAuthMethod == tokenEndpointAuthMethodNone {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Un client public n'a pas de secret")
+	}
+
+	secret, err := generateClientSecret()
+	if err != nil {
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la génération du secret", err.Error())
+	}
+
+	updated, err := s.oryClient.SetOAuth2ClientSecret(ctx, clientID, secret)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la rotation du secret via Hydra", "clientId", clientID, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la rotation du secret")
+	}
+
+	s.logger.WithContext(ctx).Info("Secret du client OAuth2 renouvelé", "clientId", clientID)
+
+	updated.Secret = secret
+	return updated, nil
+}
+
 // CreatePermission crée une permission
 func (s *authService) CreatePermission(ctx context.Context, req *models.CreatePermissionRequest) (*models.PermissionResponse, error) {
 	// Validation
-	if err := s.validateCreatePermissionRequest(req); err != nil {
+	if err := validation.Struct(req); err != nil {
 		return nil, err
 	}
 
-	// Créer la permission via Keto (temporairement désactivé)
-	// err := s.oryClient.CreatePermission(ctx, req.Namespace, req.Object, req.Relation, req.Subject)
-	// if err != nil {
-	// 	return nil, common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la création de la permission", err.Error())
-	// }
+	// Créer le tuple de relation via Keto
+	if err := s.oryClient.CreatePermission(ctx, req.Namespace, req.Object, req.Relation, req.Subject); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la création de la permission via Keto", "namespace", req.Namespace, "relation", req.Relation, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKetoError, "Erreur lors de la création de la permission")
+	}
+
+	s.logger.WithContext(ctx).Info("Permission créée", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation)
 
-	// Simulation temporaire
 	return &models.PermissionResponse{
 		HasPermission: true,
-		Message:       "Permission créée (simulation - Keto temporairement désactivé)",
+		Message:       "Permission créée",
+	}, nil
+}
+
+// DeletePermission supprime une permission
+func (s *authService) DeletePermission(ctx context.Context, req *models.DeletePermissionRequest) (*models.PermissionResponse, error) {
+	// Validation
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	// Supprimer le tuple de relation via Keto
+	if err := s.oryClient.DeletePermission(ctx, req.Namespace, req.Object, req.Relation, req.Subject); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression de la permission via Keto", "namespace", req.Namespace, "relation", req.Relation, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKetoError, "Erreur lors de la suppression de la permission")
+	}
+
+	s.logger.WithContext(ctx).Info("Permission supprimée", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation)
+
+	return &models.PermissionResponse{
+		HasPermission: false,
+		Message:       "Permission supprimée",
 	}, nil
 }
 
 // CheckPermission vérifie une permission
 func (s *authService) CheckPermission(ctx context.Context, req *models.CheckPermissionRequest) (*models.PermissionResponse, error) {
-	// Validation
-	if err := s.validateCheckPermissionRequest(req); err != nil {
+	hasPermission, err := s.checkPermission(ctx, req)
+	if err != nil {
 		return nil, err
 	}
 
-	// Vérifier la permission via Keto (temporairement désactivé)
-	// hasPermission, err := s.oryClient.CheckPermission(ctx, req.Namespace, req.Object, req.Relation, req.Subject)
-	// if err != nil {
-	// 	return nil, common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la vérification de la permission", err.Error())
-	// }
+	message := "Permission refusée"
+	if hasPermission {
+		message = "Permission accordée"
+	}
 
-	// Simulation temporaire
 	return &models.PermissionResponse{
-		HasPermission: true,
-		Message:       "Permission accordée (simulation - Keto temporairement désactivé)",
+		HasPermission: hasPermission,
+		Message:       message,
 	}, nil
 }
 
-// Méthodes de validation privées
-func (s *authService) validateCreateUserRequest(req *models.CreateUserRequest) error {
-	if err := common.ValidateEmail(req.Email); err != nil {
-		return err
+// BatchCheckPermission vérifie plusieurs tuples, au plus permissionBatchConcurrency à la fois.
+// Un tuple invalide ou une erreur Keto n'est signalé que dans le résultat du tuple concerné.
+func (s *authService) BatchCheckPermission(ctx context.Context, req *models.BatchCheckPermissionRequest) (*models.BatchCheckPermissionResponse, error) {
+	if len(req.Checks) == 0 {
+		return nil, common.NewFieldError("checks", "required")
 	}
-	if err := common.ValidateRequired(req.FirstName, "Prénom"); err != nil {
-		return err
+	if len(req.Checks) > maxPermissionBatchSize {
+		return nil, common.NewFieldError("checks", "maxItems", strconv.Itoa(maxPermissionBatchSize))
 	}
-	if err := common.ValidateRequired(req.LastName, "Nom"); err != nil {
-		return err
+
+	results := make([]models.PermissionCheckResult, len(req.Checks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(permissionBatchConcurrency, len(req.Checks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].HasPermission, results[i].Error = s.checkPermission(ctx, req.Checks[i])
+			}
+		}()
 	}
-	return nil
+	for i := range req.Checks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+		}
+	}
+	s.logger.WithContext(ctx).Info("Permissions vérifiées", "checks", len(results), "failed", failed)
+
+	return &models.BatchCheckPermissionResponse{Results: results}, nil
 }
 
-func (s *authService) validateCreateOAuth2ClientRequest(req *models.CreateOAuth2ClientRequest) error {
-	if err := common.ValidateRequired(req.ID, "ID du client"); err != nil {
-		return err
+// ExpandPermission retourne l'arbre des sujets ayant la relation sur l'objet; nil si aucun sujet ne l'a
+func (s *authService) ExpandPermission(ctx context.Context, req *models.ExpandPermissionRequest) (*models.SubjectTree, error) {
+	// Validation
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
-	if err := common.ValidateRequired(req.Name, "Nom du client"); err != nil {
-		return err
+	if req.MaxDepth < 0 {
+		return nil, common.NewFieldError("maxDepth", "min", "0")
 	}
-	if err := common.ValidateRequired(req.RedirectURI, "URI de redirection"); err != nil {
-		return err
+
+	tree, err := s.oryClient.ExpandPermission(ctx, req.Namespace, req.Object, req.Relation, req.MaxDepth)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de l'expansion de la permission via Keto", "namespace", req.Namespace, "relation", req.Relation, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKetoError, "Erreur lors de l'expansion de la permission")
 	}
-	return nil
+
+	return tree, nil
 }
 
-func (s *authService) validateCreatePermissionRequest(req *models.CreatePermissionRequest) error {
-	if err := common.ValidateRequired(req.Namespace, "Namespace"); err != nil {
-		return err
+// ListRelations liste les tuples de relation correspondant aux filtres, avec pagination par jeton Keto
+func (s *authService) ListRelations(ctx context.Context, req *models.ListRelationsRequest) (*models.ListRelationsResponse, error) {
+	// Validation
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
-	if err := common.ValidateRequired(req.Object, "Objet"); err != nil {
-		return err
+	pageSize := req.PageSize
+	if pageSize < 0 {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Taille de page invalide")
 	}
-	if err := common.ValidateRequired(req.Relation, "Relation"); err != nil {
-		return err
+	if pageSize == 0 {
+		pageSize = defaultRelationPageSize
 	}
-	if err := common.ValidateRequired(req.Subject, "Sujet"); err != nil {
-		return err
+	if pageSize > maxRelationPageSize {
+		pageSize = maxRelationPageSize
 	}
-	return nil
-}
 
-func (s *authService) validateCheckPermissionRequest(req *models.CheckPermissionRequest) error {
-	return s.validateCreatePermissionRequest(&models.CreatePermissionRequest{
+	filter := models.RelationTuple{
 		Namespace: req.Namespace,
 		Object:    req.Object,
 		Relation:  req.Relation,
 		Subject:   req.Subject,
-	})
+	}
+	relations, next, err := s.oryClient.ListRelations(ctx, filter, pageSize, req.PageToken)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération des relations via Keto", "namespace", req.Namespace, "error", err)
+		return nil, wrapOryError(err, common.ErrCodeKetoError, "Erreur lors de la récupération des relations")
+	}
+
+	return &models.ListRelationsResponse{
+		Relations:     relations,
+		NextPageToken: next,
+	}, nil
+}
+
+// checkPermission valide un tuple et le vérifie via Keto
+func (s *authService) checkPermission(ctx context.Context, req *models.CheckPermissionRequest) (bool, error) {
+	// Validation
+	if req == nil {
+		return false, common.NewFieldError("namespace", "required")
+	}
+	if err := validation.Struct(req); err != nil {
+		return false, err
+	}
+
+	// Vérifier la permission via Keto
+	hasPermission, err := s.oryClient.CheckPermission(ctx, req.Namespace, req.Object, req.Relation, req.Subject)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la vérification de la permission via Keto", "namespace", req.Namespace, "relation", req.Relation, "error", err)
+		return false, wrapOryError(err, common.ErrCodeKetoError, "Erreur lors de la vérification de la permission")
+	}
+
+	return hasPermission, nil
+}
+
+// validateOAuth2Client vérifie la cohérence des types de flux, URIs et méthode d'authentification
+func (s *authService) validateOAuth2Client(client *models.OAuth2Client) error {
+	needsRedirect := false
+	for _, grantType := range client.GrantTypes {
+		if !allowedOAuth2GrantTypes[grantType] {
+			return common.NewAppError(common.ErrCodeInvalidInput, "Type de flux non supporté", grantType)
+		}
+		if grantType == "authorization_code" || grantType == "implicit" {
+			needsRedirect = true
+		}
+		if grantType == "client_credentials" && client.TokenEndpointAuthMethod == tokenEndpointAuthMethodNone {
+			return common.NewAppError(common.ErrCodeInvalidInput, "Le flux client_credentials requiert un client confidentiel")
+		}
+	}
+
+	for _, responseType := range client.ResponseTypes {
+		// Les types combinés ("code id_token") sont séparés par des espaces
+		for _, part := range strings.Fields(responseType) {
+			if !allowedOAuth2ResponseTypes[part] {
+				return common.NewAppError(common.ErrCodeInvalidInput, "Type de réponse non supporté", responseType)
+			}
+		}
+	}
+
+	if !allowedOAuth2AuthMethods[client.TokenEndpointAuthMethod] {
+		return common.NewAppError(common.ErrCodeInvalidInput, "Méthode d'authentification non supportée", client.TokenEndpointAuthMethod)
+	}
+
+	for _, scope := range client.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\n") {
+			return common.NewAppError(common.ErrCodeInvalidInput, "Scope invalide", scope)
+		}
+	}
+
+	if needsRedirect && len(client.RedirectURIs) == 0 {
+		return common.NewAppError(common.ErrCodeInvalidInput, "Au moins une URI de redirection est requise")
+	}
+	for _, redirectURI := range client.RedirectURIs {
+		parsed, err := url.Parse(redirectURI)
+		if err != nil || parsed.Scheme == "" || parsed.Fragment != "" {
+			return common.NewAppError(common.ErrCodeInvalidInput, "URI de redirection invalide", redirectURI)
+		}
+	}
+
+	return nil
+}
+
+// wrapOryError conserve les AppError existantes et encapsule les autres erreurs Ory
+func wrapOryError(err error, code common.ErrorCode, message string) error {
+	if appErr, ok := err.(*common.AppError); ok {
+		return appErr
+	}
+	return common.NewAppError(code, message, err.Error())
+}
+
+// isUserNotFound indique si l'erreur signale un utilisateur inexistant
+func isUserNotFound(err error) bool {
+	appErr, ok := err.(*common.AppError)
+	return ok && appErr.Code == common.ErrCodeUserNotFound
+}
+
+// normalizeUserUpdateMask normalise les chemins du masque de mise à jour et rejette les champs inconnus
+func normalizeUserUpdateMask(paths []string) (map[string]bool, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	mask := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		field := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(path), "_", ""))
+		if !userUpdateFields[field] {
+			return nil, common.NewFieldError("updateMask", "oneof", "email firstName lastName")
+		}
+		mask[field] = true
+	}
+	return mask, nil
+}
+
+// userUpdateValue retourne la nouvelle valeur d'un champ: celle de la requête si le champ
+// est dans le masque, ou, sans masque, si elle n'est pas vide
+func userUpdateValue(mask map[string]bool, field, requested, current string) string {
+	requested = strings.TrimSpace(requested)
+	if mask == nil {
+		if requested != "" {
+			return requested
+		}
+		return current
+	}
+	if mask[field] {
+		return requested
+	}
+	return current
+}
+
+// userPageCursor position du dernier utilisateur d'une page
+type userPageCursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+}
+
+// encodeUserPageToken encode un curseur en jeton de page opaque
+func encodeUserPageToken(cursor userPageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeUserPageToken décode un jeton de page produit par encodeUserPageToken
+func decodeUserPageToken(token string) (*userPageCursor, error) {
+	var cursor userPageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil || cursor.ID == "" {
+		return nil, common.NewAppError(common.ErrCodeInvalidInput, "Jeton de page invalide")
+	}
+	return &cursor, nil
+}
+
+// withDefault retourne les valeurs par défaut si la liste est vide
+func withDefault(values, defaults []string) []string {
+	if len(values) == 0 {
+		return append([]string(nil), defaults...)
+	}
+	return values
+}
+
+// generateClientSecret génère un secret aléatoire encodé en base64 URL
+func generateClientSecret() (string, error) {
+	buf := make([]byte, oauth2ClientSecretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
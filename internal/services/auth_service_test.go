@@ -2,16 +2,38 @@ package services
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
 	"ndugu-backend/internal/common"
 	"ndugu-backend/internal/models"
 )
 
+// newSpanRecorder installe un fournisseur global enregistrant les spans en mémoire
+func newSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
 // MockUserRepository pour les tests
 type MockUserRepository struct {
 	users map[string]*models.User
+
+	// createErr erreur retournée par Create, si définie
+	createErr error
 }
 
 func NewMockUserRepository() *MockUserRepository {
@@ -21,6 +43,9 @@ func NewMockUserRepository() *MockUserRepository {
 }
 
 func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
 	m.users[user.ID] = user
 	return nil
 }
@@ -48,6 +73,9 @@ func (m *MockUserRepository) Update(ctx context.Context, user *models.User) erro
 }
 
 func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.users[id]; !exists {
+		return common.ErrUserNotFound
+	}
 	delete(m.users, id)
 	return nil
 }
@@ -60,14 +88,44 @@ func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*mo
 	return users, nil
 }
 
+func (m *MockUserRepository) Search(ctx context.Context, filter *models.UserFilter, limit int) ([]*models.User, error) {
+	users := make([]*models.User, 0, len(m.users))
+	for _, user := range m.users {
+		if filter.Matches(user) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].ID < users[j].ID
+		}
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
 // MockOryClient pour les tests
 type MockOryClient struct {
-	users map[string]*models.User
+	users   map[string]*models.User
+	clients map[string]*models.OAuth2Client
+
+	// deletedSubjects sujets dont les permissions Keto ont été supprimées
+	deletedSubjects []string
+
+	// checking, maxChecking vérifications Keto en cours et maximum observé
+	checking, maxChecking atomic.Int32
+
+	// relations tuples Keto listés par ListRelations
+	relations []*models.RelationTuple
 }
 
 func NewMockOryClient() *MockOryClient {
 	return &MockOryClient{
-		users: make(map[string]*models.User),
+		users:   make(map[string]*models.User),
+		clients: make(map[string]*models.OAuth2Client),
 	}
 }
 
@@ -93,37 +151,230 @@ func (m *MockOryClient) GetUser(ctx context.Context, userID string) (*models.Use
 	return user, nil
 }
 
+func (m *MockOryClient) UpdateUser(ctx context.Context, userID, email, firstName, lastName string) (*models.User, error) {
+	user, exists := m.users[userID]
+	if !exists {
+		return nil, common.ErrUserNotFound
+	}
+	updated := *user
+	updated.Email = email
+	updated.FirstName = firstName
+	updated.LastName = lastName
+	updated.UpdatedAt = time.Now()
+	m.users[userID] = &updated
+
+	result := updated
+	return &result, nil
+}
+
+func (m *MockOryClient) DeleteUser(ctx context.Context, userID string) error {
+	if _, exists := m.users[userID]; !exists {
+		return common.ErrUserNotFound
+	}
+	delete(m.users, userID)
+	return nil
+}
+
+func (m *MockOryClient) ListUsers(ctx context.Context, pageSize int, pageToken string) ([]*models.User, string, error) {
+	ids := make([]string, 0, len(m.users))
+	for id := range m.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	start := 0
+	if pageToken != "" {
+		start, _ = strconv.Atoi(pageToken)
+	}
+	end := len(ids)
+	if pageSize > 0 && start+pageSize < end {
+		end = start + pageSize
+	}
+
+	users := make([]*models.User, 0, end-start)
+	for _, id := range ids[start:end] {
+		user := *m.users[id]
+		users = append(users, &user)
+	}
+	next := ""
+	if end < len(ids) {
+		next = strconv.Itoa(end)
+	}
+	return users, next, nil
+}
+
 func (m *MockOryClient) ValidateSession(ctx context.Context, sessionToken string) (*models.Session, error) {
+	switch sessionToken {
+	case "invalid-token":
+		return nil, common.ErrInvalidSession
+	case "kratos-down":
+		return nil, common.NewAppError(common.ErrCodeKratosError, "Erreur lors de la validation de la session")
+	}
 	return &models.Session{
-		ID:        "session-id",
-		UserID:    "test-user-id",
-		Token:     sessionToken,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
-		CreatedAt: time.Now(),
+		ID:                    "session-id",
+		UserID:                "test-user-id",
+		Token:                 sessionToken,
+		AAL:                   "aal1",
+		AuthenticationMethods: []string{"password"},
+		AuthenticatedAt:       time.Now(),
+		ExpiresAt:             time.Now().Add(24 * time.Hour),
+		CreatedAt:             time.Now(),
 	}, nil
 }
 
-func (m *MockOryClient) CreateOAuth2Client(ctx context.Context, clientID, clientName, redirectURI string) (*models.OAuth2Client, error) {
-	return &models.OAuth2Client{
-		ID:           clientID,
-		Name:         clientName,
-		Secret:       "test-secret",
-		RedirectURIs: []string{redirectURI},
-		GrantTypes:   []string{"authorization_code"},
-		Scopes:       []string{"openid", "profile"},
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+func (m *MockOryClient) ValidateSessionCookie(ctx context.Context, cookie string) (*models.Session, error) {
+	return m.ValidateSession(ctx, cookie)
+}
+
+func (m *MockOryClient) RevokeSession(ctx context.Context, sessionToken string) error {
+	_, err := m.ValidateSession(ctx, sessionToken)
+	return err
+}
+
+func (m *MockOryClient) IntrospectToken(ctx context.Context, token string) (*models.TokenIntrospection, error) {
+	if token != "valid-access-token" {
+		return &models.TokenIntrospection{Active: false}, nil
+	}
+	return &models.TokenIntrospection{
+		Active:    true,
+		Subject:   "test-user-id",
+		ClientID:  "test-client",
+		Scopes:    []string{"openid"},
+		ExpiresAt: time.Now().Add(time.Hour),
 	}, nil
 }
 
+func (m *MockOryClient) CreateOAuth2Client(ctx context.Context, client *models.OAuth2Client) (*models.OAuth2Client, error) {
+	created := *client
+	if created.ID == "" {
+		created.ID = "generated-client-id"
+	}
+	if created.TokenEndpointAuthMethod != "none" {
+		created.Secret = "test-secret"
+	}
+	created.CreatedAt = time.Now()
+	created.UpdatedAt = time.Now()
+	m.clients[created.ID] = &created
+
+	result := created
+	return &result, nil
+}
+
+func (m *MockOryClient) GetOAuth2Client(ctx context.Context, clientID string) (*models.OAuth2Client, error) {
+	client, exists := m.clients[clientID]
+	if !exists {
+		return nil, common.ErrOAuth2ClientNotFound
+	}
+	result := *client
+	return &result, nil
+}
+
+func (m *MockOryClient) ListOAuth2Clients(ctx context.Context, pageSize int, pageToken string) ([]*models.OAuth2Client, string, error) {
+	clients := make([]*models.OAuth2Client, 0, len(m.clients))
+	for _, client := range m.clients {
+		result := *client
+		clients = append(clients, &result)
+	}
+	return clients, "", nil
+}
+
+func (m *MockOryClient) UpdateOAuth2Client(ctx context.Context, client *models.OAuth2Client) (*models.OAuth2Client, error) {
+	existing, exists := m.clients[client.ID]
+	if !exists {
+		return nil, common.ErrOAuth2ClientNotFound
+	}
+	updated := *client
+	updated.Secret = existing.Secret
+	m.clients[client.ID] = &updated
+
+	result := updated
+	return &result, nil
+}
+
+func (m *MockOryClient) DeleteOAuth2Client(ctx context.Context, clientID string) error {
+	if _, exists := m.clients[clientID]; !exists {
+		return common.ErrOAuth2ClientNotFound
+	}
+	delete(m.clients, clientID)
+	return nil
+}
+
+func (m *MockOryClient) SetOAuth2ClientSecret(ctx context.Context, clientID, secret string) (*models.OAuth2Client, error) {
+	client, exists := m.clients[clientID]
+	if !exists {
+		return nil, common.ErrOAuth2ClientNotFound
+	}
+	client.Secret = secret
+	result := *client
+	return &result, nil
+}
+
 func (m *MockOryClient) CreatePermission(ctx context.Context, namespace, object, relation, subject string) error {
 	return nil
 }
 
+func (m *MockOryClient) DeletePermission(ctx context.Context, namespace, object, relation, subject string) error {
+	return nil
+}
+
+// CheckPermission refuse le sujet "denied" et échoue pour le sujet "keto-down"
 func (m *MockOryClient) CheckPermission(ctx context.Context, namespace, object, relation, subject string) (bool, error) {
+	current := m.checking.Add(1)
+	defer m.checking.Add(-1)
+	for {
+		max := m.maxChecking.Load()
+		if current <= max || m.maxChecking.CompareAndSwap(max, current) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	switch subject {
+	case "denied":
+		return false, nil
+	case "keto-down":
+		return false, common.NewAppError(common.ErrCodeKetoError, "Erreur lors de la vérification de la permission")
+	}
 	return true, nil
 }
 
+func (m *MockOryClient) DeleteSubjectPermissions(ctx context.Context, subjectID string) error {
+	m.deletedSubjects = append(m.deletedSubjects, subjectID)
+	return nil
+}
+
+// ExpandPermission retourne un groupe de deux membres pour "file-1" et aucun sujet sinon
+func (m *MockOryClient) ExpandPermission(ctx context.Context, namespace, object, relation string, maxDepth int) (*models.SubjectTree, error) {
+	if object != "file-1" {
+		return nil, nil
+	}
+	return &models.SubjectTree{Type: "union", Subject: "files:file-1#" + relation, Children: []*models.SubjectTree{
+		{Type: "leaf", Subject: "user-1"},
+		{Type: "union", Subject: "groups:admins#member", Children: []*models.SubjectTree{{Type: "leaf", Subject: "user-2"}}},
+	}}, nil
+}
+
+func (m *MockOryClient) ListRelations(ctx context.Context, filter models.RelationTuple, pageSize int, pageToken string) ([]*models.RelationTuple, string, error) {
+	var matching []*models.RelationTuple
+	for _, tuple := range m.relations {
+		if (filter.Namespace == "" || tuple.Namespace == filter.Namespace) && (filter.Object == "" || tuple.Object == filter.Object) &&
+			(filter.Relation == "" || tuple.Relation == filter.Relation) && (filter.Subject == "" || tuple.Subject == filter.Subject) {
+			matching = append(matching, tuple)
+		}
+	}
+
+	start := 0
+	if pageToken != "" {
+		start, _ = strconv.Atoi(pageToken)
+	}
+	end := min(start+pageSize, len(matching))
+	next := ""
+	if end < len(matching) {
+		next = strconv.Itoa(end)
+	}
+	return matching[start:end], next, nil
+}
+
 func TestAuthService_CreateUser(t *testing.T) {
 	// Arrange
 	mockUserRepo := NewMockUserRepository()
@@ -183,6 +434,126 @@ func TestAuthService_CreateUser_InvalidEmail(t *testing.T) {
 	}
 }
 
+func TestAuthService_CreateUser_CompensatesLocalFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		localErr error
+		wantCode common.ErrorCode
+	}{
+		{name: "database unavailable", localErr: common.NewAppError(common.ErrCodeInternal, "Erreur lors de la création de l'utilisateur"), wantCode: common.ErrCodeInternal},
+		{name: "email taken locally", localErr: common.ErrUserExists, wantCode: common.ErrCodeUserExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userRepo := NewMockUserRepository()
+			userRepo.createErr = tt.localErr
+			oryClient := NewMockOryClient()
+			authService := NewAuthService(userRepo, oryClient, common.NewSimpleLogger())
+
+			// Act
+			_, err := authService.CreateUser(context.Background(), &models.CreateUserRequest{Email: "test@example.com", FirstName: "John", LastName: "Doe"})
+
+			// Assert
+			var appErr *common.AppError
+			if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+				t.Fatalf("CreateUser() error = %v, want code %s", err, tt.wantCode)
+			}
+			if len(oryClient.users) != 0 {
+				t.Errorf("Kratos identities = %d, want 0 after compensation", len(oryClient.users))
+			}
+		})
+	}
+}
+
+// racingUserRepository simule la réconciliation qui crée la ligne locale juste avant Create,
+// ou une base illisible après l'échec de Create
+type racingUserRepository struct {
+	*MockUserRepository
+	getErr error
+}
+
+func (r *racingUserRepository) Create(ctx context.Context, user *models.User) error {
+	if r.getErr == nil {
+		reconciled := *user
+		r.users[user.ID] = &reconciled
+	}
+	return common.ErrUserExists
+}
+
+func (r *racingUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	return r.MockUserRepository.GetByID(ctx, id)
+}
+
+func TestAuthService_CreateUser_KeepsIdentityWhenLocalRowExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		getErr   error
+		wantCode common.ErrorCode
+	}{
+		{name: "row created by the reconciler"},
+		{name: "local database unreadable", getErr: common.NewAppError(common.ErrCodeInternal, "Erreur lors de la récupération de l'utilisateur"), wantCode: common.ErrCodeUserExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userRepo := &racingUserRepository{MockUserRepository: NewMockUserRepository(), getErr: tt.getErr}
+			oryClient := NewMockOryClient()
+			authService := NewAuthService(userRepo, oryClient, common.NewSimpleLogger())
+
+			// Act
+			user, err := authService.CreateUser(context.Background(), &models.CreateUserRequest{Email: "test@example.com", FirstName: "John", LastName: "Doe"})
+
+			// Assert
+			if tt.wantCode == "" && (err != nil || user == nil || user.ID != "test-id-test@example.com") {
+				t.Fatalf("CreateUser() = %+v, %v, want the reconciled user", user, err)
+			}
+			if appErr, ok := err.(*common.AppError); tt.wantCode != "" && (!ok || appErr.Code != tt.wantCode) {
+				t.Fatalf("CreateUser() error = %v, want code %s", err, tt.wantCode)
+			}
+			if len(oryClient.users) != 1 {
+				t.Errorf("Kratos identities = %d, want the identity kept", len(oryClient.users))
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateUser_TracesCompensation(t *testing.T) {
+	// Arrange
+	recorder := newSpanRecorder(t)
+	userRepo := NewMockUserRepository()
+	userRepo.createErr = common.ErrUserExists
+	oryClient := NewMockOryClient()
+	authService := NewAuthService(userRepo, oryClient, common.NewSimpleLogger())
+
+	// Act
+	authService.CreateUser(context.Background(), &models.CreateUserRequest{Email: "test@example.com", FirstName: "John", LastName: "Doe"})
+
+	// Assert: le span de compensation est un enfant du span de création, qui porte l'échec
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	create, compensate := spans["services.auth.CreateUser"], spans["services.auth.CompensateUserCreation"]
+	if create == nil || compensate == nil {
+		t.Fatalf("spans = %v, want CreateUser and CompensateUserCreation", spans)
+	}
+	if compensate.Parent().SpanID() != create.SpanContext().SpanID() {
+		t.Error("CompensateUserCreation span is not a child of CreateUser")
+	}
+	if create.Status().Description != string(common.ErrCodeUserExists) {
+		t.Errorf("CreateUser span status = %+v, want %s", create.Status(), common.ErrCodeUserExists)
+	}
+	if compensate.Status().Description != "" {
+		t.Errorf("CompensateUserCreation span status = %+v, want success", compensate.Status())
+	}
+}
+
 func TestAuthService_GetUser(t *testing.T) {
 	// Arrange
 	mockUserRepo := NewMockUserRepository()
@@ -220,6 +591,209 @@ func TestAuthService_GetUser(t *testing.T) {
 	}
 }
 
+// newUserTestService crée un service dont l'utilisateur "user-1" (john@example.com)
+// existe dans Kratos et en base locale
+func newUserTestService() (AuthService, *MockUserRepository, *MockOryClient) {
+	userRepo := NewMockUserRepository()
+	oryClient := NewMockOryClient()
+	user := &models.User{ID: "user-1", Email: "john@example.com", FirstName: "John", LastName: "Doe", Traits: map[string]interface{}{"role": "user"}}
+	oryClient.users[user.ID] = user
+	local := *user
+	userRepo.users[user.ID] = &local
+	return NewAuthService(userRepo, oryClient, common.NewSimpleLogger()), userRepo, oryClient
+}
+
+func TestAuthService_UpdateUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.UpdateUserRequest
+		wantEmail     string
+		wantFirstName string
+		wantLastName  string
+		wantCode      common.ErrorCode
+	}{
+		{name: "non-empty fields without mask", req: &models.UpdateUserRequest{ID: "user-1", FirstName: "Johnny"}, wantEmail: "john@example.com", wantFirstName: "Johnny", wantLastName: "Doe"},
+		{name: "mask limits the update", req: &models.UpdateUserRequest{ID: "user-1", FirstName: "Ignored", LastName: "Smith", UpdateMask: []string{"last_name"}}, wantEmail: "john@example.com", wantFirstName: "John", wantLastName: "Smith"},
+		{name: "email change", req: &models.UpdateUserRequest{ID: "user-1", Email: "johnny@example.com", UpdateMask: []string{"email"}}, wantEmail: "johnny@example.com", wantFirstName: "John", wantLastName: "Doe"},
+		{name: "masked field cleared", req: &models.UpdateUserRequest{ID: "user-1", UpdateMask: []string{"firstName"}}, wantCode: common.ErrCodeInvalidInput},
+		{name: "unknown mask path", req: &models.UpdateUserRequest{ID: "user-1", FirstName: "Johnny", UpdateMask: []string{"traits"}}, wantCode: common.ErrCodeInvalidInput},
+		{name: "email used by another user", req: &models.UpdateUserRequest{ID: "user-1", Email: "jane@example.com"}, wantCode: common.ErrCodeUserExists},
+		{name: "unknown user", req: &models.UpdateUserRequest{ID: "missing", FirstName: "Johnny"}, wantCode: common.ErrCodeUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			authService, userRepo, _ := newUserTestService()
+			userRepo.users["user-2"] = &models.User{ID: "user-2", Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"}
+
+			// Act
+			response, err := authService.UpdateUser(context.Background(), tt.req)
+
+			// Assert
+			if tt.wantCode != "" {
+				if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("UpdateUser() error = %v, want %v", err, tt.wantCode)
+				}
+				if local := userRepo.users["user-1"]; local.FirstName != "John" || local.Email != "john@example.com" {
+					t.Errorf("local user modified after error: %+v", local)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateUser() error = %v", err)
+			}
+			if response.Email != tt.wantEmail || response.FirstName != tt.wantFirstName || response.LastName != tt.wantLastName {
+				t.Errorf("UpdateUser() = %+v, want %s %s %s", response, tt.wantEmail, tt.wantFirstName, tt.wantLastName)
+			}
+			local := userRepo.users["user-1"]
+			if local.Email != tt.wantEmail || local.FirstName != tt.wantFirstName || local.LastName != tt.wantLastName {
+				t.Errorf("local user = %+v, want synchronized with Kratos", local)
+			}
+			if local.Traits["role"] != "user" {
+				t.Errorf("local traits = %v, want other traits kept", local.Traits)
+			}
+		})
+	}
+}
+
+func TestAuthService_UpdateUser_CreatesMissingLocalUser(t *testing.T) {
+	// Arrange
+	authService, userRepo, _ := newUserTestService()
+	delete(userRepo.users, "user-1")
+
+	// Act
+	_, err := authService.UpdateUser(context.Background(), &models.UpdateUserRequest{ID: "user-1", LastName: "Smith"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	if local, exists := userRepo.users["user-1"]; !exists || local.LastName != "Smith" {
+		t.Errorf("local user = %+v, want created from Kratos", local)
+	}
+}
+
+func TestAuthService_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		inKratos     bool
+		inLocal      bool
+		wantNotFound bool
+	}{
+		{name: "kratos and local", inKratos: true, inLocal: true},
+		{name: "kratos only", inKratos: true},
+		{name: "local only, after a partial deletion", inLocal: true},
+		{name: "unknown user", wantNotFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			authService, userRepo, oryClient := newUserTestService()
+			if !tt.inKratos {
+				delete(oryClient.users, "user-1")
+			}
+			if !tt.inLocal {
+				delete(userRepo.users, "user-1")
+			}
+
+			// Act
+			err := authService.DeleteUser(context.Background(), "user-1")
+
+			// Assert
+			if tt.wantNotFound {
+				if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeUserNotFound {
+					t.Fatalf("DeleteUser() error = %v, want USER_NOT_FOUND", err)
+				}
+			} else if err != nil {
+				t.Fatalf("DeleteUser() error = %v", err)
+			}
+			if _, exists := oryClient.users["user-1"]; exists {
+				t.Error("Kratos identity not deleted")
+			}
+			if _, exists := userRepo.users["user-1"]; exists {
+				t.Error("local user not deleted")
+			}
+			if len(oryClient.deletedSubjects) != 1 || oryClient.deletedSubjects[0] != "user-1" {
+				t.Errorf("deleted Keto subjects = %v, want [user-1]", oryClient.deletedSubjects)
+			}
+		})
+	}
+}
+
+func TestAuthService_ListUsers(t *testing.T) {
+	// Arrange
+	userRepo := NewMockUserRepository()
+	authService := NewAuthService(userRepo, NewMockOryClient(), common.NewSimpleLogger())
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	emails := []string{"ana@example.com", "bob@example.com", "Anna@example.org", "carl@example.com", "andre@example.com"}
+	for i, email := range emails {
+		id := "user-" + string(rune('a'+i))
+		userRepo.users[id] = &models.User{ID: id, Email: email, CreatedAt: start.Add(time.Duration(i) * time.Hour)}
+	}
+	// Même date de création que user-a: départagé par l'ID
+	userRepo.users["user-f"] = &models.User{ID: "user-f", Email: "fred@example.com", CreatedAt: start}
+
+	tests := []struct {
+		name    string
+		req     models.ListUsersRequest
+		wantIDs []string
+	}{
+		{name: "all users", req: models.ListUsersRequest{PageSize: 2}, wantIDs: []string{"user-a", "user-f", "user-b", "user-c", "user-d", "user-e"}},
+		{name: "email prefix", req: models.ListUsersRequest{PageSize: 2, EmailPrefix: "AN"}, wantIDs: []string{"user-a", "user-c", "user-e"}},
+		{name: "creation range", req: models.ListUsersRequest{CreatedAfter: start.Add(time.Hour), CreatedBefore: start.Add(3 * time.Hour)}, wantIDs: []string{"user-b", "user-c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: parcourir toutes les pages
+			var gotIDs []string
+			req := tt.req
+			for page := 0; page < 10; page++ {
+				response, err := authService.ListUsers(context.Background(), &req)
+				if err != nil {
+					t.Fatalf("ListUsers() error = %v", err)
+				}
+				if req.PageSize > 0 && len(response.Users) > req.PageSize {
+					t.Fatalf("ListUsers() returned %d users, page size %d", len(response.Users), req.PageSize)
+				}
+				for _, user := range response.Users {
+					gotIDs = append(gotIDs, user.ID)
+				}
+				if response.NextPageToken == "" {
+					break
+				}
+				req.PageToken = response.NextPageToken
+			}
+
+			// Assert
+			if strings.Join(gotIDs, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("ListUsers() ids = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestAuthService_ListUsers_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *models.ListUsersRequest
+	}{
+		{name: "negative page size", req: &models.ListUsersRequest{PageSize: -1}},
+		{name: "malformed page token", req: &models.ListUsersRequest{PageToken: "not-a-token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestAuthService().ListUsers(context.Background(), tt.req)
+			if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeInvalidInput {
+				t.Errorf("ListUsers() error = %v, want INVALID_INPUT", err)
+			}
+		})
+	}
+}
+
 func TestAuthService_ValidateSession(t *testing.T) {
 	// Arrange
 	mockUserRepo := NewMockUserRepository()
@@ -246,4 +820,393 @@ func TestAuthService_ValidateSession(t *testing.T) {
 	if !response.Valid {
 		t.Error("ValidateSession() valid = false, want true")
 	}
+	if response.AAL != "aal1" || len(response.AuthenticationMethods) != 1 {
+		t.Errorf("ValidateSession() aal = %v, methods = %v", response.AAL, response.AuthenticationMethods)
+	}
+}
+
+func TestAuthService_ValidateSession_Outcomes(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *models.ValidateSessionRequest
+		wantValid bool
+		wantCode  common.ErrorCode
+	}{
+		{name: "browser cookie", req: &models.ValidateSessionRequest{SessionCookie: "valid-cookie"}, wantValid: true},
+		{name: "rejected session", req: &models.ValidateSessionRequest{SessionToken: "invalid-token"}},
+		{name: "kratos unavailable", req: &models.ValidateSessionRequest{SessionToken: "kratos-down"}, wantCode: common.ErrCodeKratosError},
+		{name: "no credentials", req: &models.ValidateSessionRequest{}, wantCode: common.ErrCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := newTestAuthService().ValidateSession(context.Background(), tt.req)
+
+			if tt.wantCode != "" {
+				if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("ValidateSession() error = %v, want %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateSession() error = %v", err)
+			}
+			if response.Valid != tt.wantValid {
+				t.Errorf("ValidateSession() valid = %v, want %v", response.Valid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		wantCode common.ErrorCode
+	}{
+		{name: "active session", token: "valid-token"},
+		{name: "already revoked session", token: "invalid-token"},
+		{name: "kratos unavailable", token: "kratos-down", wantCode: common.ErrCodeKratosError},
+		{name: "missing token", token: "", wantCode: common.ErrCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := newTestAuthService().RevokeSession(context.Background(), &models.RevokeSessionRequest{SessionToken: tt.token})
+
+			// Assert
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("RevokeSession() error = %v", err)
+				}
+				return
+			}
+			if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("RevokeSession() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAuthService_BatchCheckPermission(t *testing.T) {
+	check := func(subject string) *models.CheckPermissionRequest {
+		return &models.CheckPermissionRequest{Namespace: "files", Object: "file-1", Relation: "viewer", Subject: subject}
+	}
+	invalid := &models.CheckPermissionRequest{Namespace: "documents", Object: "file-1", Relation: "viewer", Subject: "user-1"}
+
+	// Arrange
+	checks := []*models.CheckPermissionRequest{check("user-1"), check("denied"), invalid, check("keto-down")}
+	for i := 0; i < 40; i++ {
+		checks = append(checks, check("user-"+strconv.Itoa(i)))
+	}
+	oryClient := NewMockOryClient()
+	service := NewAuthService(NewMockUserRepository(), oryClient, common.NewSimpleLogger())
+
+	// Act
+	response, err := service.BatchCheckPermission(context.Background(), &models.BatchCheckPermissionRequest{Checks: checks})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BatchCheckPermission() error = %v", err)
+	}
+	if len(response.Results) != len(checks) {
+		t.Fatalf("BatchCheckPermission() results = %d, want %d", len(response.Results), len(checks))
+	}
+	wantCodes := []common.ErrorCode{"", "", common.ErrCodeInvalidInput, common.ErrCodeKetoError}
+	for i, want := range wantCodes {
+		var appErr *common.AppError
+		if got := response.Results[i].Error; (want == "" && got != nil) || (want != "" && (!errors.As(got, &appErr) || appErr.Code != want)) {
+			t.Errorf("results[%d].Error = %v, want %q", i, got, want)
+		}
+	}
+	if !response.Results[0].HasPermission || response.Results[1].HasPermission || !response.Results[len(checks)-1].HasPermission {
+		t.Errorf("results = %+v, want allowed, denied, ..., allowed", response.Results)
+	}
+	if got := oryClient.maxChecking.Load(); got > permissionBatchConcurrency {
+		t.Errorf("concurrent Keto checks = %d, want at most %d", got, permissionBatchConcurrency)
+	}
+}
+
+func TestAuthService_BatchCheckPermission_InvalidBatch(t *testing.T) {
+	tooMany := make([]*models.CheckPermissionRequest, maxPermissionBatchSize+1)
+	tests := []struct {
+		name     string
+		checks   []*models.CheckPermissionRequest
+		wantRule string
+	}{
+		{name: "empty batch", checks: nil, wantRule: "required"},
+		{name: "too many checks", checks: tooMany, wantRule: "maxItems"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := newTestAuthService().BatchCheckPermission(context.Background(), &models.BatchCheckPermissionRequest{Checks: tt.checks})
+
+			// Assert
+			appErr, ok := err.(*common.AppError)
+			if !ok || appErr.Code != common.ErrCodeInvalidInput {
+				t.Fatalf("BatchCheckPermission() error = %v, want %v", err, common.ErrCodeInvalidInput)
+			}
+			if len(appErr.Violations) != 1 || appErr.Violations[0].Rule != tt.wantRule {
+				t.Errorf("BatchCheckPermission() violations = %+v, want rule %s", appErr.Violations, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestAuthService_ExpandPermission(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *models.ExpandPermissionRequest
+		wantSubjects []string
+		wantCode     common.ErrorCode
+	}{
+		{name: "nested subjects", req: &models.ExpandPermissionRequest{Namespace: "files", Object: "file-1", Relation: "viewer"}, wantSubjects: []string{"user-1", "groups:admins#member"}},
+		{name: "no subject", req: &models.ExpandPermissionRequest{Namespace: "files", Object: "file-2", Relation: "viewer"}},
+		{name: "unknown namespace", req: &models.ExpandPermissionRequest{Namespace: "documents", Object: "file-1", Relation: "viewer"}, wantCode: common.ErrCodeInvalidInput},
+		{name: "negative depth", req: &models.ExpandPermissionRequest{Namespace: "files", Object: "file-1", Relation: "viewer", MaxDepth: -1}, wantCode: common.ErrCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			tree, err := newTestAuthService().ExpandPermission(context.Background(), tt.req)
+
+			// Assert
+			if tt.wantCode != "" {
+				if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("ExpandPermission() error = %v, want %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandPermission() error = %v", err)
+			}
+			if tt.wantSubjects == nil {
+				if tree != nil {
+					t.Errorf("ExpandPermission() = %+v, want nil", tree)
+				}
+				return
+			}
+			var subjects []string
+			for _, child := range tree.Children {
+				subjects = append(subjects, child.Subject)
+			}
+			if strings.Join(subjects, ",") != strings.Join(tt.wantSubjects, ",") {
+				t.Errorf("ExpandPermission() children = %v, want %v", subjects, tt.wantSubjects)
+			}
+		})
+	}
+}
+
+func TestAuthService_ListRelations(t *testing.T) {
+	relations := []*models.RelationTuple{
+		{Namespace: "files", Object: "file-1", Relation: "viewer", Subject: "user-1"},
+		{Namespace: "files", Object: "file-1", Relation: "owner", Subject: "user-2"},
+		{Namespace: "files", Object: "file-2", Relation: "viewer", Subject: "groups:admins#member"},
+		{Namespace: "groups", Object: "admins", Relation: "member", Subject: "user-1"},
+	}
+
+	tests := []struct {
+		name        string
+		req         *models.ListRelationsRequest
+		wantObjects []string
+		wantNext    string
+		wantCode    common.ErrorCode
+	}{
+		{name: "all relations", req: &models.ListRelationsRequest{}, wantObjects: []string{"file-1", "file-1", "file-2", "admins"}},
+		{name: "what can user-1 access", req: &models.ListRelationsRequest{Subject: "user-1"}, wantObjects: []string{"file-1", "admins"}},
+		{name: "who can view file-1", req: &models.ListRelationsRequest{Namespace: "files", Object: "file-1", Relation: "viewer"}, wantObjects: []string{"file-1"}},
+		{name: "first page", req: &models.ListRelationsRequest{Namespace: "files", PageSize: 2}, wantObjects: []string{"file-1", "file-1"}, wantNext: "2"},
+		{name: "next page", req: &models.ListRelationsRequest{Namespace: "files", PageSize: 2, PageToken: "2"}, wantObjects: []string{"file-2"}},
+		{name: "unknown namespace", req: &models.ListRelationsRequest{Namespace: "documents"}, wantCode: common.ErrCodeInvalidInput},
+		{name: "negative page size", req: &models.ListRelationsRequest{PageSize: -1}, wantCode: common.ErrCodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			oryClient := NewMockOryClient()
+			oryClient.relations = relations
+			service := NewAuthService(NewMockUserRepository(), oryClient, common.NewSimpleLogger())
+
+			// Act
+			page, err := service.ListRelations(context.Background(), tt.req)
+
+			// Assert
+			if tt.wantCode != "" {
+				if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("ListRelations() error = %v, want %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListRelations() error = %v", err)
+			}
+			var objects []string
+			for _, relation := range page.Relations {
+				objects = append(objects, relation.Object)
+			}
+			if strings.Join(objects, ",") != strings.Join(tt.wantObjects, ",") || page.NextPageToken != tt.wantNext {
+				t.Errorf("ListRelations() = %v (next %q), want %v (next %q)", objects, page.NextPageToken, tt.wantObjects, tt.wantNext)
+			}
+		})
+	}
+}
+
+func newTestAuthService() AuthService {
+	return NewAuthService(NewMockUserRepository(), NewMockOryClient(), common.NewSimpleLogger())
+}
+
+func TestAuthService_CreateOAuth2Client(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *models.CreateOAuth2ClientRequest
+		wantSecret bool
+	}{
+		{
+			name: "defaults",
+			req: &models.CreateOAuth2ClientRequest{
+				Name:         "Web App",
+				RedirectURIs: []string{"https://app.example.com/callback", "http://localhost:3000/callback"},
+			},
+			wantSecret: true,
+		},
+		{
+			name: "public SPA client",
+			req: &models.CreateOAuth2ClientRequest{
+				Name:                    "SPA",
+				RedirectURIs:            []string{"https://spa.example.com/callback"},
+				TokenEndpointAuthMethod: "none",
+			},
+		},
+		{
+			name: "machine to machine client",
+			req: &models.CreateOAuth2ClientRequest{
+				ID:                      "billing-worker",
+				Name:                    "Billing Worker",
+				GrantTypes:              []string{"client_credentials"},
+				ResponseTypes:           []string{"token"},
+				Scopes:                  []string{"billing.read", "billing.write"},
+				TokenEndpointAuthMethod: "client_secret_post",
+			},
+			wantSecret: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newTestAuthService().CreateOAuth2Client(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("CreateOAuth2Client() error = %v", err)
+			}
+			if (client.Secret != "") != tt.wantSecret {
+				t.Errorf("CreateOAuth2Client() secret = %q, wantSecret %v", client.Secret, tt.wantSecret)
+			}
+			if len(client.GrantTypes) == 0 || len(client.ResponseTypes) == 0 || len(client.Scopes) == 0 {
+				t.Errorf("CreateOAuth2Client() missing defaults: %+v", client)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateOAuth2Client_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *models.CreateOAuth2ClientRequest
+	}{
+		{name: "missing name", req: &models.CreateOAuth2ClientRequest{RedirectURIs: []string{"https://app.example.com/cb"}}},
+		{name: "missing redirect uri", req: &models.CreateOAuth2ClientRequest{Name: "Web App"}},
+		{name: "relative redirect uri", req: &models.CreateOAuth2ClientRequest{Name: "Web App", RedirectURIs: []string{"/callback"}}},
+		{name: "unknown grant type", req: &models.CreateOAuth2ClientRequest{Name: "Web App", GrantTypes: []string{"password"}}},
+		{name: "unknown response type", req: &models.CreateOAuth2ClientRequest{Name: "Web App", RedirectURIs: []string{"https://app.example.com/cb"}, ResponseTypes: []string{"code device"}}},
+		{name: "unknown auth method", req: &models.CreateOAuth2ClientRequest{Name: "Web App", RedirectURIs: []string{"https://app.example.com/cb"}, TokenEndpointAuthMethod: "tls_client_auth"}},
+		{name: "public client credentials", req: &models.CreateOAuth2ClientRequest{Name: "Worker", GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: "none"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestAuthService().CreateOAuth2Client(context.Background(), tt.req)
+			appErr, ok := err.(*common.AppError)
+			if !ok || appErr.Code != common.ErrCodeInvalidInput {
+				t.Errorf("CreateOAuth2Client() error = %v, want INVALID_INPUT", err)
+			}
+		})
+	}
+}
+
+func TestAuthService_OAuth2ClientSecretReturnedOnce(t *testing.T) {
+	// Arrange
+	authService := newTestAuthService()
+	ctx := context.Background()
+	created, err := authService.CreateOAuth2Client(ctx, &models.CreateOAuth2ClientRequest{
+		Name:         "Web App",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	})
+	if err != nil {
+		t.Fatalf("CreateOAuth2Client() error = %v", err)
+	}
+
+	// Act & Assert
+	fetched, err := authService.GetOAuth2Client(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetOAuth2Client() error = %v", err)
+	}
+	if fetched.Secret != "" {
+		t.Error("GetOAuth2Client() returned the client secret")
+	}
+
+	updated, err := authService.UpdateOAuth2Client(ctx, &models.UpdateOAuth2ClientRequest{ID: created.ID, Scopes: []string{"openid"}})
+	if err != nil {
+		t.Fatalf("UpdateOAuth2Client() error = %v", err)
+	}
+	if updated.Secret != "" || len(updated.Scopes) != 1 || len(updated.RedirectURIs) != 1 {
+		t.Errorf("UpdateOAuth2Client() = %+v", updated)
+	}
+
+	page, err := authService.ListOAuth2Clients(ctx, 0, "")
+	if err != nil {
+		t.Fatalf("ListOAuth2Clients() error = %v", err)
+	}
+	for _, client := range page.Clients {
+		if client.Secret != "" {
+			t.Error("ListOAuth2Clients() returned a client secret")
+		}
+	}
+}
+
+func TestAuthService_RotateOAuth2ClientSecret(t *testing.T) {
+	// Arrange
+	authService := newTestAuthService()
+	ctx := context.Background()
+	created, _ := authService.CreateOAuth2Client(ctx, &models.CreateOAuth2ClientRequest{
+		Name:       "Worker",
+		GrantTypes: []string{"client_credentials"},
+	})
+	public, _ := authService.CreateOAuth2Client(ctx, &models.CreateOAuth2ClientRequest{
+		ID:                      "spa-client",
+		Name:                    "SPA",
+		RedirectURIs:            []string{"https://spa.example.com/callback"},
+		TokenEndpointAuthMethod: "none",
+	})
+
+	// Act
+	rotated, err := authService.RotateOAuth2ClientSecret(ctx, created.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RotateOAuth2ClientSecret() error = %v", err)
+	}
+	if rotated.Secret == "" || rotated.Secret == created.Secret {
+		t.Errorf("RotateOAuth2ClientSecret() secret = %q, want a new secret", rotated.Secret)
+	}
+
+	if _, err := authService.RotateOAuth2ClientSecret(ctx, public.ID); err == nil {
+		t.Error("RotateOAuth2ClientSecret() expected error for a public client")
+	}
+	if _, err := authService.RotateOAuth2ClientSecret(ctx, "missing"); err != common.ErrOAuth2ClientNotFound {
+		t.Errorf("RotateOAuth2ClientSecret() error = %v, want %v", err, common.ErrOAuth2ClientNotFound)
+	}
 }
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/password"
+	"ndugu-backend/internal/repository"
+	"ndugu-backend/internal/validation"
+)
+
+// Limites de pagination pour la liste des clients
+const (
+	defaultCustomerPageSize = 20
+	maxCustomerPageSize     = 100
+)
+
+// CustomerService interface pour le service de gestion des clients
+type CustomerService interface {
+	CreateCustomer(ctx context.Context, req *models.CreateCustomerRequest) (*models.CustomerResponse, error)
+	GetCustomer(ctx context.Context, customerID string) (*models.CustomerResponse, error)
+	GetCustomerByPhone(ctx context.Context, phoneCode, phoneNumber string) (*models.CustomerResponse, error)
+	UpdateCustomer(ctx context.Context, req *models.UpdateCustomerRequest) (*models.CustomerResponse, error)
+	DeactivateCustomer(ctx context.Context, customerID string) (*models.CustomerResponse, error)
+	ListCustomers(ctx context.Context, limit, offset int) ([]*models.CustomerResponse, error)
+	VerifyCustomerCredentials(ctx context.Context, phoneCode, phoneNumber, password string) (*models.CustomerResponse, error)
+}
+
+// customerService implémentation du service de gestion des clients
+type customerService struct {
+	customerRepo repository.CustomerRepository
+	hasher       password.Hasher
+	logger       common.Logger
+
+	// dummyHash sert à égaliser le temps de réponse quand le client n'existe pas
+	dummyHash string
+}
+
+// NewCustomerService crée une nouvelle instance du service client
+func NewCustomerService(
+	customerRepo repository.CustomerRepository,
+	hasher password.Hasher,
+	logger common.Logger,
+) CustomerService {
+	dummyHash, err := hasher.Hash("ndugu-dummy-password")
+	if err != nil {
+		logger.Warn("Impossible de calculer l'empreinte factice", "error", err)
+	}
+
+	return &customerService{
+		customerRepo: customerRepo,
+		hasher:       hasher,
+		logger:       logger,
+		dummyHash:    dummyHash,
+	}
+}
+
+// CreateCustomer crée un nouveau client
+func (s *customerService) CreateCustomer(ctx context.Context, req *models.CreateCustomerRequest) (*models.CustomerResponse, error) {
+	req.PhoneCode = normalizePhoneCode(req.PhoneCode)
+	req.PhoneNumber = normalizePhoneNumber(req.PhoneNumber)
+
+	s.logger.WithContext(ctx).Info("Début de création de client", "phoneCode", req.PhoneCode)
+
+	// Validation
+	if err := s.validateCreateCustomerRequest(req); err != nil {
+		s.logger.WithContext(ctx).Error("Validation échouée pour la création de client", "error", err)
+		return nil, err
+	}
+
+	// Vérifier si le numéro est déjà utilisé
+	existing, err := s.customerRepo.GetByPhone(ctx, req.PhoneCode, req.PhoneNumber)
+	if err == nil && existing != nil {
+		s.logger.WithContext(ctx).Warn("Tentative de création d'un client existant", "customerId", existing.ID)
+		return nil, common.ErrCustomerExists
+	}
+
+	hash, err := s.hasher.Hash(req.Password)
+	if errors.Is(err, password.ErrPasswordTooLong) {
+		return nil, passwordTooLongError()
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors du hachage du mot de passe", "error", err)
+		return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la création du client", err.Error())
+	}
+
+	customer := &models.Customer{
+		PhoneCode:   req.PhoneCode,
+		PhoneNumber: req.PhoneNumber,
+		Password:    hash,
+		IsActive:    true,
+	}
+
+	if err := s.customerRepo.Create(ctx, customer); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la sauvegarde du client", "error", err)
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Client créé avec succès", "customerId", customer.ID)
+
+	return customer.ToResponse(), nil
+}
+
+// GetCustomer récupère un client par son ID
+func (s *customerService) GetCustomer(ctx context.Context, customerID string) (*models.CustomerResponse, error) {
+	if customerID == "" {
+		s.logger.WithContext(ctx).Error("ID client vide fourni")
+		return nil, common.ErrInvalidInput
+	}
+
+	customer, err := s.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return customer.ToResponse(), nil
+}
+
+// GetCustomerByPhone récupère un client par son numéro de téléphone
+func (s *customerService) GetCustomerByPhone(ctx context.Context, phoneCode, phoneNumber string) (*models.CustomerResponse, error) {
+	phoneCode = normalizePhoneCode(phoneCode)
+	phoneNumber = normalizePhoneNumber(phoneNumber)
+
+	if err := s.validatePhone(phoneCode, phoneNumber); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.customerRepo.GetByPhone(ctx, phoneCode, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return customer.ToResponse(), nil
+}
+
+// UpdateCustomer met à jour un client; les champs vides ne sont pas modifiés
+func (s *customerService) UpdateCustomer(ctx context.Context, req *models.UpdateCustomerRequest) (*models.CustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("Début de mise à jour de client", "customerId", req.ID)
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.customerRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PhoneCode != "" {
+		customer.PhoneCode = normalizePhoneCode(req.PhoneCode)
+	}
+	if req.PhoneNumber != "" {
+		customer.PhoneNumber = normalizePhoneNumber(req.PhoneNumber)
+	}
+	if err := s.validatePhone(customer.PhoneCode, customer.PhoneNumber); err != nil {
+		return nil, err
+	}
+
+	if req.Password != "" {
+		hash, err := s.hasher.Hash(req.Password)
+		if errors.Is(err, password.ErrPasswordTooLong) {
+			return nil, passwordTooLongError()
+		}
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Erreur lors du hachage du mot de passe", "customerId", req.ID, "error", err)
+			return nil, common.NewAppError(common.ErrCodeInternal, "Erreur lors de la mise à jour du client", err.Error())
+		}
+		customer.Password = hash
+	}
+
+	if err := s.customerRepo.Update(ctx, customer); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour du client", "customerId", req.ID, "error", err)
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Client mis à jour avec succès", "customerId", customer.ID)
+
+	return customer.ToResponse(), nil
+}
+
+// DeactivateCustomer désactive un client sans le supprimer
+func (s *customerService) DeactivateCustomer(ctx context.Context, customerID string) (*models.CustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("Début de désactivation de client", "customerId", customerID)
+
+	if customerID == "" {
+		return nil, common.ErrInvalidInput
+	}
+
+	customer, err := s.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !customer.IsActive {
+		return customer.ToResponse(), nil
+	}
+
+	customer.IsActive = false
+	if err := s.customerRepo.Update(ctx, customer); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la désactivation du client", "customerId", customerID, "error", err)
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Client désactivé avec succès", "customerId", customerID)
+
+	return customer.ToResponse(), nil
+}
+
+// ListCustomers liste les clients avec pagination
+func (s *customerService) ListCustomers(ctx context.Context, limit, offset int) ([]*models.CustomerResponse, error) {
+	if limit <= 0 {
+		limit = defaultCustomerPageSize
+	}
+	if limit > maxCustomerPageSize {
+		limit = maxCustomerPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	customers, err := s.customerRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.CustomerResponse, 0, len(customers))
+	for _, customer := range customers {
+		responses = append(responses, customer.ToResponse())
+	}
+
+	return responses, nil
+}
+
+// VerifyCustomerCredentials vérifie le mot de passe d'un client identifié par son numéro.
+// L'empreinte est recalculée de manière transparente si les paramètres de hachage ont changé.
+func (s *customerService) VerifyCustomerCredentials(ctx context.Context, phoneCode, phoneNumber, plainPassword string) (*models.CustomerResponse, error) {
+	phoneCode = normalizePhoneCode(phoneCode)
+	phoneNumber = normalizePhoneNumber(phoneNumber)
+
+	if err := s.validatePhone(phoneCode, phoneNumber); err != nil {
+		return nil, err
+	}
+	if err := common.ValidateRequired(plainPassword, "Mot de passe"); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.customerRepo.GetByPhone(ctx, phoneCode, phoneNumber)
+	if err != nil {
+		if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeCustomerNotFound {
+			return nil, err
+		}
+		// Effectuer une vérification factice pour ne pas révéler l'existence du numéro
+		s.hasher.Verify(plainPassword, s.dummyHash)
+		s.logger.WithContext(ctx).Warn("Échec d'authentification client", "reason", "not_found")
+		return nil, common.ErrInvalidCredentials
+	}
+
+	match, err := s.hasher.Verify(plainPassword, customer.Password)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Empreinte de mot de passe illisible", "customerId", customer.ID, "error", err)
+		return nil, common.ErrInvalidCredentials
+	}
+	if !match {
+		s.logger.WithContext(ctx).Warn("Échec d'authentification client", "customerId", customer.ID, "reason", "password")
+		return nil, common.ErrInvalidCredentials
+	}
+
+	if !customer.IsActive {
+		return nil, common.ErrCustomerInactive
+	}
+
+	if s.hasher.NeedsRehash(customer.Password) {
+		s.rehashPassword(ctx, customer, plainPassword)
+	}
+
+	return customer.ToResponse(), nil
+}
+
+// rehashPassword met à jour l'empreinte avec les paramètres courants; un échec n'empêche pas la connexion
+func (s *customerService) rehashPassword(ctx context.Context, customer *models.Customer, plainPassword string) {
+	hash, err := s.hasher.Hash(plainPassword)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors du recalcul de l'empreinte", "customerId", customer.ID, "error", err)
+		return
+	}
+
+	customer.Password = hash
+	if err := s.customerRepo.Update(ctx, customer); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la sauvegarde de la nouvelle empreinte", "customerId", customer.ID, "error", err)
+		return
+	}
+
+	s.logger.WithContext(ctx).Info("Empreinte de mot de passe recalculée", "customerId", customer.ID)
+}
+
+// Méthodes de validation privées
+func (s *customerService) validateCreateCustomerRequest(req *models.CreateCustomerRequest) error {
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+	return s.validatePhone(req.PhoneCode, req.PhoneNumber)
+}
+
+// validatePhone vérifie l'indicatif puis le numéro complet au format E.164
+func (s *customerService) validatePhone(phoneCode, phoneNumber string) error {
+	if err := validation.Value("phoneCode", phoneCode, "required,phoneCode"); err != nil {
+		return err
+	}
+	return validation.Value("phoneNumber", phoneCode+phoneNumber, "e164")
+}
+
+// passwordTooLongError signale un mot de passe dont l'encodage dépasse la limite en octets
+// du hachage, même s'il compte au plus 72 caractères
+func passwordTooLongError() error {
+	return common.NewFieldError("password", "max", strconv.Itoa(password.MaxLength))
+}
+
+// normalizePhoneCode retourne l'indicatif au format +XXX
+func normalizePhoneCode(phoneCode string) string {
+	phoneCode = strings.TrimPrefix(strings.TrimSpace(phoneCode), "+")
+	if phoneCode == "" {
+		return ""
+	}
+	return "+" + phoneCode
+}
+
+// normalizePhoneNumber supprime les espaces et tirets du numéro
+func normalizePhoneNumber(phoneNumber string) string {
+	return strings.NewReplacer(" ", "", "-", "", ".", "").Replace(phoneNumber)
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/password"
+	"ndugu-backend/internal/repository"
+)
+
+// testPasswordConfig utilise des paramètres argon2id réduits pour accélérer les tests
+var testPasswordConfig = config.PasswordConfig{
+	Algorithm:         password.AlgorithmArgon2id,
+	Argon2Memory:      1024,
+	Argon2Iterations:  1,
+	Argon2Parallelism: 1,
+	Argon2SaltLength:  16,
+	Argon2KeyLength:   32,
+	BcryptCost:        4,
+}
+
+func newTestHasher(t *testing.T, cfg config.PasswordConfig) password.Hasher {
+	hasher, err := password.NewHasher(cfg)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+	return hasher
+}
+
+func newTestCustomerService(t *testing.T) CustomerService {
+	return NewCustomerService(repository.NewMemoryCustomerRepository(), newTestHasher(t, testPasswordConfig), common.NewSimpleLogger())
+}
+
+func TestCustomerService_CreateCustomer(t *testing.T) {
+	// Arrange
+	customerService := newTestCustomerService(t)
+	ctx := context.Background()
+	req := &models.CreateCustomerRequest{
+		PhoneCode:   "243",
+		PhoneNumber: "812 345 678",
+		Password:    "password123",
+	}
+
+	// Act
+	response, err := customerService.CreateCustomer(ctx, req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v, wantErr false", err)
+	}
+	if response.ID == "" {
+		t.Error("CreateCustomer() id is empty")
+	}
+	if response.PhoneCode != "+243" {
+		t.Errorf("CreateCustomer() phoneCode = %v, want +243", response.PhoneCode)
+	}
+	if response.PhoneNumber != "812345678" {
+		t.Errorf("CreateCustomer() phoneNumber = %v, want 812345678", response.PhoneNumber)
+	}
+	if !response.IsActive {
+		t.Error("CreateCustomer() isActive = false, want true")
+	}
+}
+
+func TestCustomerService_CreateCustomer_Duplicate(t *testing.T) {
+	// Arrange
+	customerService := newTestCustomerService(t)
+	ctx := context.Background()
+	req := &models.CreateCustomerRequest{PhoneCode: "+243", PhoneNumber: "812345678", Password: "password123"}
+	if _, err := customerService.CreateCustomer(ctx, req); err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	// Act
+	_, err := customerService.CreateCustomer(ctx, &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "password456"})
+
+	// Assert
+	if err != common.ErrCustomerExists {
+		t.Errorf("CreateCustomer() error = %v, want %v", err, common.ErrCustomerExists)
+	}
+}
+
+func TestCustomerService_CreateCustomer_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *models.CreateCustomerRequest
+	}{
+		{
+			name: "missing phone code",
+			req:  &models.CreateCustomerRequest{PhoneNumber: "812345678", Password: "password123"},
+		},
+		{
+			name: "invalid phone number",
+			req:  &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "12ab", Password: "password123"},
+		},
+		{
+			name: "short password",
+			req:  &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "short"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestCustomerService(t).CreateCustomer(context.Background(), tt.req)
+			if err == nil {
+				t.Error("CreateCustomer() expected error")
+			}
+		})
+	}
+}
+
+func TestCustomerService_CreateCustomer_PasswordTooLong(t *testing.T) {
+	cfg := testPasswordConfig
+	cfg.Algorithm = password.AlgorithmBcrypt
+	customerService := NewCustomerService(repository.NewMemoryCustomerRepository(), newTestHasher(t, cfg), common.NewSimpleLogger())
+
+	for name, plain := range map[string]string{
+		"over 72 characters":          strings.Repeat("a", 73),
+		"72 characters over 72 bytes": strings.Repeat("€", 72),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := customerService.CreateCustomer(context.Background(), &models.CreateCustomerRequest{
+				PhoneCode:   "+243",
+				PhoneNumber: "812345678",
+				Password:    plain,
+			})
+			if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeInvalidInput {
+				t.Errorf("CreateCustomer() error = %v, want INVALID_INPUT", err)
+			}
+		})
+	}
+}
+
+func TestCustomerService_DeactivateCustomer(t *testing.T) {
+	// Arrange
+	customerService := newTestCustomerService(t)
+	ctx := context.Background()
+	created, err := customerService.CreateCustomer(ctx, &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "password123"})
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	// Act
+	response, err := customerService.DeactivateCustomer(ctx, created.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DeactivateCustomer() error = %v", err)
+	}
+	if response.IsActive {
+		t.Error("DeactivateCustomer() isActive = true, want false")
+	}
+
+	fetched, err := customerService.GetCustomerByPhone(ctx, "+243", "812345678")
+	if err != nil {
+		t.Fatalf("GetCustomerByPhone() error = %v", err)
+	}
+	if fetched.IsActive {
+		t.Error("GetCustomerByPhone() isActive = true after deactivation")
+	}
+}
+
+func TestCustomerService_GetCustomer_NotFound(t *testing.T) {
+	// Act
+	_, err := newTestCustomerService(t).GetCustomer(context.Background(), "missing")
+
+	// Assert
+	if err != common.ErrCustomerNotFound {
+		t.Errorf("GetCustomer() error = %v, want %v", err, common.ErrCustomerNotFound)
+	}
+}
+
+func TestCustomerService_CreateCustomer_HashesPassword(t *testing.T) {
+	// Arrange
+	customerRepo := repository.NewMemoryCustomerRepository()
+	customerService := NewCustomerService(customerRepo, newTestHasher(t, testPasswordConfig), common.NewSimpleLogger())
+	ctx := context.Background()
+
+	// Act
+	created, err := customerService.CreateCustomer(ctx, &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "password123"})
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	// Assert
+	stored, _ := customerRepo.GetByID(ctx, created.ID)
+	if stored.Password == "password123" {
+		t.Error("CreateCustomer() stored the password in plaintext")
+	}
+}
+
+func TestCustomerService_VerifyCustomerCredentials(t *testing.T) {
+	// Arrange
+	customerService := newTestCustomerService(t)
+	ctx := context.Background()
+	created, err := customerService.CreateCustomer(ctx, &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "password123"})
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		phoneNumber string
+		password    string
+		wantErr     error
+	}{
+		{name: "valid credentials", phoneNumber: "812345678", password: "password123"},
+		{name: "wrong password", phoneNumber: "812345678", password: "wrong-password", wantErr: common.ErrInvalidCredentials},
+		{name: "unknown phone", phoneNumber: "899999999", password: "password123", wantErr: common.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := customerService.VerifyCustomerCredentials(ctx, "+243", tt.phoneNumber, tt.password)
+			if err != tt.wantErr {
+				t.Fatalf("VerifyCustomerCredentials() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && response.ID != created.ID {
+				t.Errorf("VerifyCustomerCredentials() id = %v, want %v", response.ID, created.ID)
+			}
+		})
+	}
+}
+
+func TestCustomerService_VerifyCustomerCredentials_Inactive(t *testing.T) {
+	// Arrange
+	customerService := newTestCustomerService(t)
+	ctx := context.Background()
+	created, _ := customerService.CreateCustomer(ctx, &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "password123"})
+	customerService.DeactivateCustomer(ctx, created.ID)
+
+	// Act
+	_, err := customerService.VerifyCustomerCredentials(ctx, "243", "812345678", "password123")
+
+	// Assert
+	if err != common.ErrCustomerInactive {
+		t.Errorf("VerifyCustomerCredentials() error = %v, want %v", err, common.ErrCustomerInactive)
+	}
+}
+
+func TestCustomerService_VerifyCustomerCredentials_Rehash(t *testing.T) {
+	// Arrange
+	customerRepo := repository.NewMemoryCustomerRepository()
+	ctx := context.Background()
+	oldService := NewCustomerService(customerRepo, newTestHasher(t, testPasswordConfig), common.NewSimpleLogger())
+	created, _ := oldService.CreateCustomer(ctx, &models.CreateCustomerRequest{PhoneCode: "243", PhoneNumber: "812345678", Password: "password123"})
+	before, _ := customerRepo.GetByID(ctx, created.ID)
+
+	bcryptConfig := testPasswordConfig
+	bcryptConfig.Algorithm = password.AlgorithmBcrypt
+	newService := NewCustomerService(customerRepo, newTestHasher(t, bcryptConfig), common.NewSimpleLogger())
+
+	// Act
+	if _, err := newService.VerifyCustomerCredentials(ctx, "243", "812345678", "password123"); err != nil {
+		t.Fatalf("VerifyCustomerCredentials() error = %v", err)
+	}
+
+	// Assert
+	after, _ := customerRepo.GetByID(ctx, created.ID)
+	if after.Password == before.Password || after.Password[:4] != "$2a$" {
+		t.Errorf("VerifyCustomerCredentials() did not rehash, hash = %v", after.Password)
+	}
+}
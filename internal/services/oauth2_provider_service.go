@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/repository"
+)
+
+// OAuth2ProviderService implémente l'application de login/consentement/déconnexion
+// vers laquelle Hydra redirige. L'authentification repose sur la session Kratos.
+type OAuth2ProviderService interface {
+	GetLoginRequest(ctx context.Context, challenge string) (*repository.HydraLoginRequest, error)
+	AcceptLogin(ctx context.Context, challenge string, credentials SessionCredentials) (string, error)
+	RejectLogin(ctx context.Context, challenge string) (string, error)
+	GetConsentRequest(ctx context.Context, challenge string) (*repository.HydraConsentRequest, error)
+	AcceptConsent(ctx context.Context, challenge string, credentials SessionCredentials, grant *ConsentGrant) (string, error)
+	RejectConsent(ctx context.Context, challenge string) (string, error)
+	GetLogoutRequest(ctx context.Context, challenge string) (*repository.HydraLogoutRequest, error)
+	AcceptLogout(ctx context.Context, challenge string) (string, error)
+}
+
+// SessionCredentials session Kratos présentée par l'interface de login: le cookie ory_kratos_session
+// d'un navigateur, ou un token de session pour les clients natifs
+type SessionCredentials struct {
+	Token  string
+	Cookie string
+}
+
+// ConsentGrant représente le choix de l'utilisateur sur l'écran de consentement:
+// les scopes et audiences accordés, parmi ceux demandés par le client
+type ConsentGrant struct {
+	Scopes   []string
+	Audience []string
+	Remember bool
+}
+
+// oauth2ProviderService implémentation du fournisseur de login/consentement
+type oauth2ProviderService struct {
+	hydraClient repository.HydraClient
+	oryClient   repository.OryClient
+	cfg         config.HydraConfig
+	logger      common.Logger
+}
+
+// NewOAuth2ProviderService crée une nouvelle instance du fournisseur de login/consentement
+func NewOAuth2ProviderService(
+	hydraClient repository.HydraClient,
+	oryClient repository.OryClient,
+	cfg config.HydraConfig,
+	logger common.Logger,
+) OAuth2ProviderService {
+	return &oauth2ProviderService{
+		hydraClient: hydraClient,
+		oryClient:   oryClient,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// GetLoginRequest récupère une requête de login pour que l'interface affiche le client demandeur
+func (s *oauth2ProviderService) GetLoginRequest(ctx context.Context, challenge string) (*repository.HydraLoginRequest, error) {
+	if err := common.ValidateRequired(challenge, "Login challenge"); err != nil {
+		return nil, err
+	}
+
+	loginReq, err := s.hydraClient.GetLoginRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de la requête de login", "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération de la requête de login")
+	}
+
+	return loginReq, nil
+}
+
+// AcceptLogin accepte une requête de login si Hydra a déjà authentifié le sujet
+// ou si la session Kratos fournie est valide
+func (s *oauth2ProviderService) AcceptLogin(ctx context.Context, challenge string, credentials SessionCredentials) (string, error) {
+	if err := common.ValidateRequired(challenge, "Login challenge"); err != nil {
+		return "", err
+	}
+
+	loginReq, err := s.hydraClient.GetLoginRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de la requête de login", "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération de la requête de login")
+	}
+
+	subject := loginReq.Subject
+	var amr []string
+	if !loginReq.Skip {
+		session, err := s.validateSession(ctx, credentials)
+		if err != nil {
+			return "", err
+		}
+		subject = session.UserID
+		amr = session.AuthenticationMethods
+	}
+
+	redirectTo, err := s.hydraClient.AcceptLoginRequest(ctx, challenge, &repository.HydraAcceptLogin{
+		Subject:     subject,
+		Remember:    !loginReq.Skip,
+		RememberFor: int64(s.cfg.LoginRememberFor.Seconds()),
+		AMR:         amr,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de l'acceptation de la requête de login", "subject", subject, "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de l'acceptation de la requête de login")
+	}
+
+	s.logger.WithContext(ctx).Info("Login OAuth2 accepté", "subject", subject, "clientId", loginReq.Client.ClientID, "skip", loginReq.Skip)
+
+	return redirectTo, nil
+}
+
+// RejectLogin refuse une requête de login (l'utilisateur a annulé)
+func (s *oauth2ProviderService) RejectLogin(ctx context.Context, challenge string) (string, error) {
+	if err := common.ValidateRequired(challenge, "Login challenge"); err != nil {
+		return "", err
+	}
+
+	redirectTo, err := s.hydraClient.RejectLoginRequest(ctx, challenge, &repository.HydraRejectRequest{
+		Error:            "access_denied",
+		ErrorDescription: "L'utilisateur a refusé la connexion",
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors du refus de la requête de login", "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors du refus de la requête de login")
+	}
+
+	return redirectTo, nil
+}
+
+// GetConsentRequest récupère une requête de consentement pour que l'interface affiche
+// le client et les scopes demandés
+func (s *oauth2ProviderService) GetConsentRequest(ctx context.Context, challenge string) (*repository.HydraConsentRequest, error) {
+	if err := common.ValidateRequired(challenge, "Consent challenge"); err != nil {
+		return nil, err
+	}
+
+	consentReq, err := s.hydraClient.GetConsentRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de la requête de consentement", "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération de la requête de consentement")
+	}
+
+	return consentReq, nil
+}
+
+// AcceptConsent accepte une requête de consentement pour les scopes choisis par l'utilisateur,
+// qui doivent faire partie des scopes demandés. Hors consentement mémorisé, la session Kratos
+// doit appartenir au sujet de la requête; un consentement mémorisé reconduit les scopes demandés.
+func (s *oauth2ProviderService) AcceptConsent(ctx context.Context, challenge string, credentials SessionCredentials, grant *ConsentGrant) (string, error) {
+	if err := common.ValidateRequired(challenge, "Consent challenge"); err != nil {
+		return "", err
+	}
+
+	consentReq, err := s.hydraClient.GetConsentRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de la requête de consentement", "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération de la requête de consentement")
+	}
+
+	var traits map[string]interface{}
+	accept := &repository.HydraAcceptConsent{RememberFor: int64(s.cfg.ConsentRememberFor.Seconds())}
+	if consentReq.Skip {
+		// Consentement déjà mémorisé: les claims sont relus depuis l'identité Kratos
+		user, err := s.oryClient.GetUser(ctx, consentReq.Subject)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Erreur lors de la récupération de l'identité", "subject", consentReq.Subject, "error", err)
+			return "", wrapOryError(err, common.ErrCodeKratosError, "Erreur lors de la récupération de l'identité")
+		}
+		traits = map[string]interface{}{
+			"email": user.Email,
+			"name":  map[string]interface{}{"first": user.FirstName, "last": user.LastName},
+		}
+		accept.GrantScope = consentReq.RequestedScope
+		accept.GrantAccessTokenAudience = consentReq.RequestedAccessTokenAudience
+	} else {
+		session, err := s.validateSession(ctx, credentials)
+		if err != nil {
+			return "", err
+		}
+		if session.UserID != consentReq.Subject {
+			s.logger.WithContext(ctx).Warn("Session Kratos différente du sujet du consentement", "subject", consentReq.Subject, "userId", session.UserID)
+			return "", common.NewAppError(common.ErrCodeForbidden, "La session ne correspond pas au sujet de la requête")
+		}
+		traits = session.Traits
+		if grant == nil {
+			grant = &ConsentGrant{}
+		}
+		if err := requireSubset("grantScope", grant.Scopes, consentReq.RequestedScope); err != nil {
+			return "", err
+		}
+		if err := requireSubset("grantAccessTokenAudience", grant.Audience, consentReq.RequestedAccessTokenAudience); err != nil {
+			return "", err
+		}
+		accept.GrantScope = append([]string{}, grant.Scopes...)
+		accept.GrantAccessTokenAudience = grant.Audience
+		accept.Remember = grant.Remember
+	}
+	accept.Session = repository.HydraConsentSession{IDToken: idTokenClaims(accept.GrantScope, traits)}
+
+	redirectTo, err := s.hydraClient.AcceptConsentRequest(ctx, challenge, accept)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de l'acceptation de la requête de consentement", "subject", consentReq.Subject, "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de l'acceptation de la requête de consentement")
+	}
+
+	s.logger.WithContext(ctx).Info("Consentement OAuth2 accepté", "subject", consentReq.Subject, "clientId", consentReq.Client.ClientID, "scopes", accept.GrantScope, "remember", accept.Remember)
+
+	return redirectTo, nil
+}
+
+// RejectConsent refuse une requête de consentement
+func (s *oauth2ProviderService) RejectConsent(ctx context.Context, challenge string) (string, error) {
+	if err := common.ValidateRequired(challenge, "Consent challenge"); err != nil {
+		return "", err
+	}
+
+	redirectTo, err := s.hydraClient.RejectConsentRequest(ctx, challenge, &repository.HydraRejectRequest{
+		Error:            "access_denied",
+		ErrorDescription: "L'utilisateur a refusé l'autorisation",
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors du refus de la requête de consentement", "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors du refus de la requête de consentement")
+	}
+
+	return redirectTo, nil
+}
+
+// GetLogoutRequest récupère une requête de déconnexion pour que l'interface demande confirmation
+func (s *oauth2ProviderService) GetLogoutRequest(ctx context.Context, challenge string) (*repository.HydraLogoutRequest, error) {
+	if err := common.ValidateRequired(challenge, "Logout challenge"); err != nil {
+		return nil, err
+	}
+
+	logoutReq, err := s.hydraClient.GetLogoutRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de la requête de déconnexion", "error", err)
+		return nil, wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération de la requête de déconnexion")
+	}
+
+	return logoutReq, nil
+}
+
+// AcceptLogout accepte une requête de déconnexion OpenID Connect
+func (s *oauth2ProviderService) AcceptLogout(ctx context.Context, challenge string) (string, error) {
+	if err := common.ValidateRequired(challenge, "Logout challenge"); err != nil {
+		return "", err
+	}
+
+	logoutReq, err := s.hydraClient.GetLogoutRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de la requête de déconnexion", "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de la récupération de la requête de déconnexion")
+	}
+
+	redirectTo, err := s.hydraClient.AcceptLogoutRequest(ctx, challenge)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de l'acceptation de la requête de déconnexion", "subject", logoutReq.Subject, "error", err)
+		return "", wrapOryError(err, common.ErrCodeHydraError, "Erreur lors de l'acceptation de la requête de déconnexion")
+	}
+
+	s.logger.WithContext(ctx).Info("Déconnexion OAuth2 acceptée", "subject", logoutReq.Subject)
+
+	return redirectTo, nil
+}
+
+// validateSession vérifie la session Kratos présentée par l'interface de login
+func (s *oauth2ProviderService) validateSession(ctx context.Context, credentials SessionCredentials) (*models.Session, error) {
+	var (
+		session *models.Session
+		err     error
+	)
+	switch {
+	case credentials.Token != "":
+		session, err = s.oryClient.ValidateSession(ctx, credentials.Token)
+	case credentials.Cookie != "":
+		session, err = s.oryClient.ValidateSessionCookie(ctx, credentials.Cookie)
+	default:
+		return nil, common.NewAppError(common.ErrCodeUnauthorized, "Session Kratos requise")
+	}
+	if err != nil {
+		if appErr, ok := err.(*common.AppError); ok && appErr.Code == common.ErrCodeKratosError {
+			s.logger.WithContext(ctx).Error("Erreur lors de la validation de la session Kratos", "error", err)
+			return nil, err
+		}
+		s.logger.WithContext(ctx).Warn("Session Kratos invalide pour le fournisseur OAuth2", "error", err)
+		return nil, common.ErrInvalidSession
+	}
+
+	return session, nil
+}
+
+// requireSubset vérifie que chaque valeur accordée fait partie des valeurs demandées par le client
+func requireSubset(field string, granted, requested []string) error {
+	allowed := make(map[string]bool, len(requested))
+	for _, value := range requested {
+		allowed[value] = true
+	}
+	for _, value := range granted {
+		if !allowed[value] {
+			return common.NewFieldError(field, "oneof", strings.Join(requested, " "))
+		}
+	}
+	return nil
+}
+
+// idTokenClaims convertit les traits Kratos (email, name.first/last) en claims
+// OpenID Connect, selon les scopes accordés
+func idTokenClaims(scopes []string, traits map[string]interface{}) map[string]interface{} {
+	claims := map[string]interface{}{}
+
+	granted := map[string]bool{}
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	if granted["email"] {
+		if email, ok := traits["email"].(string); ok && email != "" {
+			claims["email"] = email
+		}
+	}
+
+	if granted["profile"] {
+		name, _ := traits["name"].(map[string]interface{})
+		first, _ := name["first"].(string)
+		last, _ := name["last"].(string)
+		if first != "" {
+			claims["given_name"] = first
+		}
+		if last != "" {
+			claims["family_name"] = last
+		}
+		if fullName := strings.TrimSpace(first + " " + last); fullName != "" {
+			claims["name"] = fullName
+		}
+	}
+
+	return claims
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/repository"
+)
+
+// fakeHydraFlows simule les requêtes login/consent/logout de Hydra
+type fakeHydraFlows struct {
+	repository.HydraClient
+	login           *repository.HydraLoginRequest
+	consent         *repository.HydraConsentRequest
+	acceptedLogin   *repository.HydraAcceptLogin
+	acceptedConsent *repository.HydraAcceptConsent
+	rejected        *repository.HydraRejectRequest
+}
+
+func (f *fakeHydraFlows) GetLoginRequest(ctx context.Context, challenge string) (*repository.HydraLoginRequest, error) {
+	if f.login == nil || f.login.Challenge != challenge {
+		return nil, common.NewAppError(common.ErrCodeNotFound, "Challenge OAuth2 introuvable")
+	}
+	return f.login, nil
+}
+
+func (f *fakeHydraFlows) AcceptLoginRequest(ctx context.Context, challenge string, body *repository.HydraAcceptLogin) (string, error) {
+	f.acceptedLogin = body
+	return "http://hydra/oauth2/auth?login_verifier=v", nil
+}
+
+func (f *fakeHydraFlows) RejectLoginRequest(ctx context.Context, challenge string, body *repository.HydraRejectRequest) (string, error) {
+	f.rejected = body
+	return "http://client/callback?error=access_denied", nil
+}
+
+func (f *fakeHydraFlows) GetConsentRequest(ctx context.Context, challenge string) (*repository.HydraConsentRequest, error) {
+	if f.consent == nil || f.consent.Challenge != challenge {
+		return nil, common.NewAppError(common.ErrCodeNotFound, "Challenge OAuth2 introuvable")
+	}
+	return f.consent, nil
+}
+
+func (f *fakeHydraFlows) AcceptConsentRequest(ctx context.Context, challenge string, body *repository.HydraAcceptConsent) (string, error) {
+	f.acceptedConsent = body
+	return "http://hydra/oauth2/auth?consent_verifier=v", nil
+}
+
+func (f *fakeHydraFlows) GetLogoutRequest(ctx context.Context, challenge string) (*repository.HydraLogoutRequest, error) {
+	return &repository.HydraLogoutRequest{Challenge: challenge, Subject: "user-1"}, nil
+}
+
+func (f *fakeHydraFlows) AcceptLogoutRequest(ctx context.Context, challenge string) (string, error) {
+	return "http://client/logged-out", nil
+}
+
+// sessionOryClient retourne une session Kratos avec des traits pour un token connu
+type sessionOryClient struct {
+	*MockOryClient
+}
+
+func (m *sessionOryClient) ValidateSession(ctx context.Context, sessionToken string) (*models.Session, error) {
+	if sessionToken != "valid-token" {
+		return nil, common.ErrInvalidSession
+	}
+	return &models.Session{
+		ID:     "session-id",
+		UserID: "user-1",
+		Token:  sessionToken,
+		Traits: map[string]interface{}{
+			"email": "jane@example.com",
+			"name":  map[string]interface{}{"first": "Jane", "last": "Doe"},
+		},
+		AuthenticationMethods: []string{"password"},
+		ExpiresAt:             time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (m *sessionOryClient) ValidateSessionCookie(ctx context.Context, cookie string) (*models.Session, error) {
+	if cookie != "valid-cookie" {
+		return nil, common.ErrInvalidSession
+	}
+	return m.ValidateSession(ctx, "valid-token")
+}
+
+func newTestOAuth2ProviderService(hydra *fakeHydraFlows) OAuth2ProviderService {
+	cfg := config.HydraConfig{LoginRememberFor: time.Hour, ConsentRememberFor: 24 * time.Hour}
+	return NewOAuth2ProviderService(hydra, &sessionOryClient{NewMockOryClient()}, cfg, common.NewSimpleLogger())
+}
+
+func TestOAuth2ProviderService_AcceptLogin(t *testing.T) {
+	tests := []struct {
+		name        string
+		skip        bool
+		credentials SessionCredentials
+		wantSubject string
+		wantCode    common.ErrorCode
+	}{
+		{name: "valid session token", credentials: SessionCredentials{Token: "valid-token"}, wantSubject: "user-1"},
+		{name: "valid session cookie", credentials: SessionCredentials{Cookie: "valid-cookie"}, wantSubject: "user-1"},
+		{name: "skip uses hydra subject", skip: true, wantSubject: "remembered-user"},
+		{name: "missing session", wantCode: common.ErrCodeUnauthorized},
+		{name: "invalid session", credentials: SessionCredentials{Token: "expired-token"}, wantCode: common.ErrCodeInvalidSession},
+		{name: "invalid cookie", credentials: SessionCredentials{Cookie: "valid-token"}, wantCode: common.ErrCodeInvalidSession},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hydra := &fakeHydraFlows{login: &repository.HydraLoginRequest{Challenge: "login-1", Skip: tt.skip, Subject: "remembered-user"}}
+
+			redirectTo, err := newTestOAuth2ProviderService(hydra).AcceptLogin(context.Background(), "login-1", tt.credentials)
+
+			if tt.wantCode != "" {
+				if appErr, ok := err.(*common.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("AcceptLogin() error = %v, want %v", err, tt.wantCode)
+				}
+				if hydra.acceptedLogin != nil {
+					t.Error("AcceptLogin() accepted the challenge without a valid session")
+				}
+				return
+			}
+			if err != nil || redirectTo == "" {
+				t.Fatalf("AcceptLogin() = %q, %v", redirectTo, err)
+			}
+			if hydra.acceptedLogin.Subject != tt.wantSubject {
+				t.Errorf("AcceptLogin() subject = %v, want %v", hydra.acceptedLogin.Subject, tt.wantSubject)
+			}
+			if !tt.skip && !reflect.DeepEqual(hydra.acceptedLogin.AMR, []string{"password"}) {
+				t.Errorf("AcceptLogin() amr = %v, want [password]", hydra.acceptedLogin.AMR)
+			}
+			if hydra.acceptedLogin.Remember == tt.skip {
+				t.Errorf("AcceptLogin() remember = %v with skip = %v", hydra.acceptedLogin.Remember, tt.skip)
+			}
+		})
+	}
+}
+
+func TestOAuth2ProviderService_AcceptConsent(t *testing.T) {
+	// Arrange
+	hydra := &fakeHydraFlows{consent: &repository.HydraConsentRequest{
+		Challenge:      "consent-1",
+		Subject:        "user-1",
+		RequestedScope: []string{"openid", "email", "profile"},
+	}}
+
+	grant := &ConsentGrant{Scopes: []string{"openid", "email"}, Remember: true}
+
+	// Act
+	_, err := newTestOAuth2ProviderService(hydra).AcceptConsent(context.Background(), "consent-1", SessionCredentials{Token: "valid-token"}, grant)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("AcceptConsent() error = %v", err)
+	}
+	accepted := hydra.acceptedConsent
+	if !accepted.Remember || accepted.RememberFor != 86400 {
+		t.Errorf("AcceptConsent() remember = %v/%d, want true/86400", accepted.Remember, accepted.RememberFor)
+	}
+	if !reflect.DeepEqual(accepted.GrantScope, []string{"openid", "email"}) {
+		t.Errorf("AcceptConsent() grantScope = %v, want the scopes granted by the user", accepted.GrantScope)
+	}
+	want := map[string]interface{}{"email": "jane@example.com"}
+	if !reflect.DeepEqual(accepted.Session.IDToken, want) {
+		t.Errorf("AcceptConsent() id_token = %v, want %v", accepted.Session.IDToken, want)
+	}
+}
+
+func TestOAuth2ProviderService_AcceptConsent_UnrequestedScope(t *testing.T) {
+	hydra := &fakeHydraFlows{consent: &repository.HydraConsentRequest{
+		Challenge:      "consent-1",
+		Subject:        "user-1",
+		RequestedScope: []string{"openid"},
+	}}
+	grant := &ConsentGrant{Scopes: []string{"openid", "offline_access"}}
+
+	_, err := newTestOAuth2ProviderService(hydra).AcceptConsent(context.Background(), "consent-1", SessionCredentials{Token: "valid-token"}, grant)
+
+	if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeInvalidInput {
+		t.Fatalf("AcceptConsent() error = %v, want INVALID_INPUT", err)
+	}
+	if hydra.acceptedConsent != nil {
+		t.Error("AcceptConsent() accepted a scope the client did not request")
+	}
+}
+
+func TestOAuth2ProviderService_AcceptConsent_Skip(t *testing.T) {
+	hydra := &fakeHydraFlows{consent: &repository.HydraConsentRequest{
+		Challenge:      "consent-1",
+		Skip:           true,
+		Subject:        "test-id-jane@example.com",
+		RequestedScope: []string{"openid", "email"},
+	}}
+	oryClient := NewMockOryClient()
+	oryClient.CreateUser(context.Background(), "jane@example.com", "Jane", "Doe")
+	service := NewOAuth2ProviderService(hydra, oryClient, config.HydraConfig{ConsentRememberFor: 24 * time.Hour}, common.NewSimpleLogger())
+
+	_, err := service.AcceptConsent(context.Background(), "consent-1", SessionCredentials{}, nil)
+
+	if err != nil {
+		t.Fatalf("AcceptConsent() error = %v", err)
+	}
+	if accepted := hydra.acceptedConsent; accepted.Remember || !reflect.DeepEqual(accepted.GrantScope, []string{"openid", "email"}) {
+		t.Errorf("AcceptConsent() = remember %v, grantScope %v, want the remembered scopes", accepted.Remember, accepted.GrantScope)
+	}
+}
+
+func TestOAuth2ProviderService_AcceptConsent_SubjectMismatch(t *testing.T) {
+	hydra := &fakeHydraFlows{consent: &repository.HydraConsentRequest{Challenge: "consent-1", Subject: "someone-else"}}
+
+	_, err := newTestOAuth2ProviderService(hydra).AcceptConsent(context.Background(), "consent-1", SessionCredentials{Token: "valid-token"}, &ConsentGrant{})
+
+	if appErr, ok := err.(*common.AppError); !ok || appErr.Code != common.ErrCodeForbidden {
+		t.Errorf("AcceptConsent() error = %v, want FORBIDDEN", err)
+	}
+}
+
+func TestOAuth2ProviderService_RejectLogin(t *testing.T) {
+	hydra := &fakeHydraFlows{}
+
+	redirectTo, err := newTestOAuth2ProviderService(hydra).RejectLogin(context.Background(), "login-1")
+
+	if err != nil || redirectTo == "" {
+		t.Fatalf("RejectLogin() = %q, %v", redirectTo, err)
+	}
+	if hydra.rejected.Error != "access_denied" {
+		t.Errorf("RejectLogin() error = %v, want access_denied", hydra.rejected.Error)
+	}
+}
+
+func TestIDTokenClaims(t *testing.T) {
+	traits := map[string]interface{}{
+		"email": "jane@example.com",
+		"name":  map[string]interface{}{"first": "Jane", "last": "Doe"},
+	}
+
+	if claims := idTokenClaims([]string{"openid"}, traits); len(claims) != 0 {
+		t.Errorf("idTokenClaims(openid) = %v, want no claims", claims)
+	}
+	if claims := idTokenClaims([]string{"openid", "email"}, traits); !reflect.DeepEqual(claims, map[string]interface{}{"email": "jane@example.com"}) {
+		t.Errorf("idTokenClaims(email) = %v", claims)
+	}
+}
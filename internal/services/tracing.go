@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName nom de l'instrumentation des services
+const tracerName = "ndugu-backend/internal/services"
+
+// attrUserID attribut des spans désignant l'utilisateur concerné (identifiant seulement, jamais l'email)
+const attrUserID = "enduser.id"
+
+// startSpan ouvre un span "services.<service>.<operation>"; il est terminé par tracing.End
+func startSpan(ctx context.Context, service, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "services."+service+"."+operation, trace.WithAttributes(attrs...))
+}
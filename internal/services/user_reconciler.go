@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/repository"
+	"ndugu-backend/internal/tracing"
+)
+
+// UserReconciler répare périodiquement les écarts entre les identités Kratos et la base locale.
+// Kratos fait référence: un utilisateur absent de la base locale y est créé, un utilisateur
+// dont l'email ou le nom diffère y est mis à jour. Un utilisateur local sans identité Kratos
+// est seulement signalé: sa suppression reste une décision d'administration (DeleteUser).
+type UserReconciler struct {
+	userRepo  repository.UserRepository
+	oryClient repository.OryClient
+	interval  time.Duration
+	pageSize  int
+	logger    common.Logger
+}
+
+// NewUserReconciler crée un UserReconciler qui parcourt les identités par pages de pageSize
+func NewUserReconciler(userRepo repository.UserRepository, oryClient repository.OryClient, interval time.Duration, pageSize int, logger common.Logger) *UserReconciler {
+	return &UserReconciler{
+		userRepo:  userRepo,
+		oryClient: oryClient,
+		interval:  interval,
+		pageSize:  pageSize,
+		logger:    logger,
+	}
+}
+
+// Run réconcilie à chaque intervalle, jusqu'à l'annulation de ctx.
+// La première réconciliation a lieu après un intervalle, pour ne pas ralentir le démarrage.
+func (r *UserReconciler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		report, err := r.Reconcile(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			r.logger.Error("Échec de la réconciliation des utilisateurs", "error", err)
+			continue
+		}
+		r.logger.Info("Réconciliation des utilisateurs terminée",
+			"checked", report.Checked, "created", report.Created, "updated", report.Updated,
+			"failed", report.Failed, "orphans", len(report.Orphans))
+	}
+}
+
+// Reconcile parcourt toutes les identités Kratos, répare la base locale puis recherche les
+// utilisateurs locaux orphelins. Une réparation en échec est comptée et n'interrompt pas le
+// parcours; une erreur de lecture l'interrompt. Les utilisateurs locaux créés après le début
+// de la réconciliation ne sont pas examinés: leur identité a pu être créée après le parcours.
+func (r *UserReconciler) Reconcile(ctx context.Context) (_ *models.UserReconciliationReport, err error) {
+	start := time.Now()
+	report := &models.UserReconciliationReport{}
+	ctx, span := startSpan(ctx, "reconciler", "Reconcile")
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("reconciliation.checked", report.Checked),
+			attribute.Int("reconciliation.created", report.Created),
+			attribute.Int("reconciliation.updated", report.Updated),
+			attribute.Int("reconciliation.failed", report.Failed),
+			attribute.Int("reconciliation.orphans", len(report.Orphans)),
+		)
+		tracing.End(span, err)
+	}()
+	seen := make(map[string]bool)
+
+	pageToken := ""
+	for {
+		identities, next, err := r.oryClient.ListUsers(ctx, r.pageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, identity := range identities {
+			seen[identity.ID] = true
+			report.Checked++
+			r.repair(ctx, identity, report)
+		}
+		if next == "" || len(identities) == 0 {
+			break
+		}
+		pageToken = next
+	}
+
+	filter := &models.UserFilter{CreatedBefore: start}
+	for {
+		users, err := r.userRepo.Search(ctx, filter, r.pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if !seen[user.ID] {
+				r.logger.Warn("Utilisateur local sans identité Kratos", "userId", user.ID)
+				report.Orphans = append(report.Orphans, user.ID)
+			}
+		}
+		if len(users) < r.pageSize {
+			break
+		}
+		last := users[len(users)-1]
+		filter.AfterCreatedAt, filter.AfterID = last.CreatedAt, last.ID
+	}
+
+	return report, nil
+}
+
+// repair aligne l'utilisateur local sur l'identité Kratos. Chaque réparation tentée est
+// consignée comme événement du span de la réconciliation, les utilisateurs à jour ne le sont pas.
+func (r *UserReconciler) repair(ctx context.Context, identity *models.User, report *models.UserReconciliationReport) {
+	span := trace.SpanFromContext(ctx)
+	event := func(action string) {
+		span.AddEvent("user.repair", trace.WithAttributes(attribute.String(attrUserID, identity.ID), attribute.String("repair.action", action)))
+	}
+
+	local, err := r.userRepo.GetByID(ctx, identity.ID)
+	switch {
+	case isUserNotFound(err):
+		if err := r.userRepo.Create(ctx, identity); err != nil {
+			r.logger.Error("Impossible de créer l'utilisateur local manquant", "userId", identity.ID, "error", err)
+			event("create_failed")
+			report.Failed++
+			return
+		}
+		r.logger.Warn("Utilisateur local manquant créé depuis Kratos", "userId", identity.ID)
+		event("created")
+		report.Created++
+	case err != nil:
+		r.logger.Error("Impossible de lire l'utilisateur local", "userId", identity.ID, "error", err)
+		event("read_failed")
+		report.Failed++
+	case local.Email != identity.Email || local.FirstName != identity.FirstName || local.LastName != identity.LastName:
+		updated := *local
+		updated.Email = identity.Email
+		updated.FirstName = identity.FirstName
+		updated.LastName = identity.LastName
+		updated.Traits = identity.Traits
+		updated.UpdatedAt = identity.UpdatedAt
+		if err := r.userRepo.Update(ctx, &updated); err != nil {
+			r.logger.Error("Impossible de mettre à jour l'utilisateur local", "userId", identity.ID, "error", err)
+			event("update_failed")
+			report.Failed++
+			return
+		}
+		r.logger.Warn("Utilisateur local mis à jour depuis Kratos", "userId", identity.ID)
+		event("updated")
+		report.Updated++
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+)
+
+func TestUserReconciler_Reconcile(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	john := &models.User{ID: "user-1", Email: "john@example.com", FirstName: "John", LastName: "Doe", CreatedAt: created}
+	jane := &models.User{ID: "user-2", Email: "jane@example.com", FirstName: "Jane", LastName: "Doe", CreatedAt: created}
+
+	tests := []struct {
+		name        string
+		identities  []*models.User
+		local       []*models.User
+		localErr    error
+		wantReport  models.UserReconciliationReport
+		wantLocalFN map[string]string
+	}{
+		{
+			name:        "in sync",
+			identities:  []*models.User{john, jane},
+			local:       []*models.User{john, jane},
+			wantReport:  models.UserReconciliationReport{Checked: 2},
+			wantLocalFN: map[string]string{"user-1": "John", "user-2": "Jane"},
+		},
+		{
+			name:        "missing local user is created",
+			identities:  []*models.User{john, jane},
+			local:       []*models.User{john},
+			wantReport:  models.UserReconciliationReport{Checked: 2, Created: 1},
+			wantLocalFN: map[string]string{"user-1": "John", "user-2": "Jane"},
+		},
+		{
+			name:        "stale local user is updated",
+			identities:  []*models.User{john},
+			local:       []*models.User{{ID: "user-1", Email: "john@example.com", FirstName: "Johnny", LastName: "Doe", CreatedAt: created}},
+			wantReport:  models.UserReconciliationReport{Checked: 1, Updated: 1},
+			wantLocalFN: map[string]string{"user-1": "John"},
+		},
+		{
+			name:        "orphan local user is reported",
+			identities:  []*models.User{john},
+			local:       []*models.User{john, jane},
+			wantReport:  models.UserReconciliationReport{Checked: 1, Orphans: []string{"user-2"}},
+			wantLocalFN: map[string]string{"user-1": "John", "user-2": "Jane"},
+		},
+		{
+			name:        "failed repair is counted",
+			identities:  []*models.User{john, jane},
+			local:       []*models.User{john},
+			localErr:    common.ErrUserExists,
+			wantReport:  models.UserReconciliationReport{Checked: 2, Failed: 1},
+			wantLocalFN: map[string]string{"user-1": "John"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userRepo := NewMockUserRepository()
+			oryClient := NewMockOryClient()
+			for _, user := range tt.identities {
+				identity := *user
+				oryClient.users[user.ID] = &identity
+			}
+			for _, user := range tt.local {
+				local := *user
+				userRepo.users[user.ID] = &local
+			}
+			userRepo.createErr = tt.localErr
+			reconciler := NewUserReconciler(userRepo, oryClient, time.Hour, 1, common.NewSimpleLogger())
+
+			// Act
+			report, err := reconciler.Reconcile(context.Background())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if !reflect.DeepEqual(*report, tt.wantReport) {
+				t.Errorf("Reconcile() report = %+v, want %+v", *report, tt.wantReport)
+			}
+			got := make(map[string]string, len(userRepo.users))
+			for id, user := range userRepo.users {
+				got[id] = user.FirstName
+			}
+			if !reflect.DeepEqual(got, tt.wantLocalFN) {
+				t.Errorf("local users = %v, want %v", got, tt.wantLocalFN)
+			}
+		})
+	}
+}
+
+func TestUserReconciler_IgnoresUsersCreatedDuringReconciliation(t *testing.T) {
+	// Arrange
+	userRepo := NewMockUserRepository()
+	userRepo.users["user-new"] = &models.User{ID: "user-new", Email: "new@example.com", CreatedAt: time.Now().Add(time.Minute)}
+	reconciler := NewUserReconciler(userRepo, NewMockOryClient(), time.Hour, 10, common.NewSimpleLogger())
+
+	// Act
+	report, err := reconciler.Reconcile(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(report.Orphans) != 0 {
+		t.Errorf("orphans = %v, want none", report.Orphans)
+	}
+}
+
+func TestUserReconciler_ReconcileSpan(t *testing.T) {
+	// Arrange
+	recorder := newSpanRecorder(t)
+	userRepo := NewMockUserRepository()
+	oryClient := NewMockOryClient()
+	oryClient.users["user-1"] = &models.User{ID: "user-1", Email: "john@example.com", FirstName: "John", LastName: "Doe"}
+	reconciler := NewUserReconciler(userRepo, oryClient, time.Hour, 10, common.NewSimpleLogger())
+
+	// Act
+	if _, err := reconciler.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// Assert
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "services.reconciler.Reconcile" {
+		t.Fatalf("spans = %v, want services.reconciler.Reconcile", spans)
+	}
+	attributes := map[string]int64{}
+	for _, attr := range spans[0].Attributes() {
+		attributes[string(attr.Key)] = attr.Value.AsInt64()
+	}
+	if attributes["reconciliation.checked"] != 1 || attributes["reconciliation.created"] != 1 {
+		t.Errorf("Reconcile span attributes = %v, want checked=1 created=1", attributes)
+	}
+	if events := spans[0].Events(); len(events) != 1 || events[0].Name != "user.repair" {
+		t.Errorf("Reconcile span events = %v, want one user.repair", events)
+	}
+}
@@ -0,0 +1,112 @@
+// Package tracing configure OpenTelemetry: fournisseur de traces, exportateur
+// (OTLP, stdout ou aucun) et propagation W3C trace-context.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/metrics"
+)
+
+// Exportateurs disponibles
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// Setup installe la propagation W3C (traceparent, baggage) et le fournisseur global de traces.
+// Avec l'exportateur "none", les traces entrantes sont propagées sans être enregistrées.
+// La fonction retournée vide les spans en attente; elle est appelée à l'arrêt.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("ressource OpenTelemetry invalide: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Une trace entrante échantillonnée par l'appelant (APISIX) est toujours conservée
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newExporter crée l'exportateur configuré; nil pour "none"
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		// La connexion au collecteur est établie en arrière-plan: son absence ne bloque pas le démarrage
+		exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("exportateur OTLP: %w", err)
+		}
+		return exporter, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("exportateur de traces inconnu %q", cfg.Exporter)
+	}
+}
+
+// InstrumentClient retourne une copie du client HTTP dont chaque requête crée un span client
+// et propage le contexte de trace (en-tête traceparent) vers Kratos, Hydra et Keto
+func InstrumentClient(client *http.Client) *http.Client {
+	instrumented := *client
+	instrumented.Transport = otelhttp.NewTransport(client.Transport, otelhttp.WithSpanNameFormatter(clientSpanName))
+	return &instrumented
+}
+
+// clientSpanName nomme le span d'après l'opération Ory annotée (ex: "kratos CreateUser")
+func clientSpanName(operation string, req *http.Request) string {
+	if service, name, ok := metrics.OperationFromContext(req.Context()); ok {
+		return service + " " + name
+	}
+	return "HTTP " + req.Method
+}
+
+// End termine un span en consignant l'échec éventuel. Seuls le code d'erreur et le type
+// sont enregistrés: les messages peuvent contenir des données personnelles.
+func End(span trace.Span, err error) {
+	if err != nil {
+		description := "error"
+		var appErr *common.AppError
+		if errors.As(err, &appErr) {
+			description = string(appErr.Code)
+		}
+		span.SetAttributes(attribute.String("error.type", fmt.Sprintf("%T", err)))
+		span.SetStatus(codes.Error, description)
+	}
+	span.End()
+}
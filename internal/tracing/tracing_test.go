@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/metrics"
+)
+
+// newRecorder installe un fournisseur global enregistrant les spans en mémoire
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestInstrumentClient_PropagatesTraceContext(t *testing.T) {
+	// Arrange
+	recorder := newRecorder(t)
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "AuthService/CreateUser")
+	ctx = metrics.WithOperation(ctx, metrics.ServiceKratos, "CreateUser")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/admin/identities", nil)
+
+	// Act
+	resp, err := InstrumentClient(&http.Client{Timeout: time.Second}).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+	parent.End()
+
+	// Assert
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want client span and parent", len(spans))
+	}
+	client := spans[0]
+	if client.Name() != "kratos CreateUser" {
+		t.Errorf("span name = %q, want %q", client.Name(), "kratos CreateUser")
+	}
+	if client.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("client span is not a child of the caller span")
+	}
+	if want := "00-" + client.SpanContext().TraceID().String() + "-" + client.SpanContext().SpanID().String() + "-01"; traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+}
+
+func TestEnd_RecordsErrorCodeOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+		wantDesc   string
+	}{
+		{name: "success", err: nil, wantStatus: codes.Unset},
+		{name: "application error", err: common.ErrUserExists, wantStatus: codes.Error, wantDesc: string(common.ErrCodeUserExists)},
+		{name: "other error", err: errors.New("duplicate key jean@example.com"), wantStatus: codes.Error, wantDesc: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := newRecorder(t)
+			_, span := otel.Tracer("test").Start(context.Background(), "operation")
+
+			End(span, tt.err)
+
+			got := recorder.Ended()[0].Status()
+			if got.Code != tt.wantStatus || got.Description != tt.wantDesc {
+				t.Errorf("status = %v %q, want %v %q", got.Code, got.Description, tt.wantStatus, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestSetup_Exporters(t *testing.T) {
+	newRecorder(t) // restaure le fournisseur et le propagateur globaux à la fin du test
+	for _, exporter := range []string{ExporterNone, ExporterStdout} {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: exporter, ServiceName: "test", SampleRatio: 1})
+		if err != nil {
+			t.Fatalf("Setup(%q) error = %v", exporter, err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Errorf("shutdown(%q) error = %v", exporter, err)
+		}
+	}
+
+	if _, err := Setup(context.Background(), config.TracingConfig{Exporter: "jaeger"}); err == nil {
+		t.Error("Setup() error = nil, want unknown exporter error")
+	}
+}
@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"ndugu-backend/internal/common"
+)
+
+// ruleFunc vérifie une valeur; param est la partie après "=" dans le tag
+type ruleFunc func(value reflect.Value, param string) bool
+
+// ruleFuncs règles disponibles dans les tags
+var ruleFuncs map[string]ruleFunc
+
+func init() {
+	ruleFuncs = map[string]ruleFunc{
+		"required": func(v reflect.Value, _ string) bool { return !isEmpty(v) },
+		"min":      func(v reflect.Value, p string) bool { return length(v) >= atoi(p) },
+		"max":      func(v reflect.Value, p string) bool { return length(v) <= atoi(p) },
+		"maxItems": func(v reflect.Value, p string) bool { return length(v) <= atoi(p) },
+		"gte":      gte,
+		"oneof":    oneOf,
+		"email":    matches(common.EmailRegex),
+		"url":      isURL,
+
+		// Règles propres au domaine
+		"e164":          matches(E164Regex),
+		"phoneCode":     matches(PhoneCodeRegex),
+		"ketoNamespace": isKetoNamespace,
+	}
+}
+
+// E164Regex numéro de téléphone international complet, ex: +243812345678
+var E164Regex = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+
+// PhoneCodeRegex indicatif téléphonique international, ex: +243
+var PhoneCodeRegex = regexp.MustCompile(`^\+?[1-9]\d{0,3}$`)
+
+// KetoNamespaces namespaces déclarés dans la configuration Keto (ory/keto/keto.yml)
+var KetoNamespaces = []string{"files", "directories", "groups", "organizations", "system"}
+
+// length longueur d'une chaîne en caractères ou d'une liste en éléments
+func length(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String())
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len()
+	default:
+		return 0
+	}
+}
+
+// atoi convertit le paramètre numérique d'une règle
+func atoi(param string) int {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		panic("validation: paramètre numérique invalide " + strconv.Quote(param))
+	}
+	return n
+}
+
+// gte vérifie qu'un nombre est supérieur ou égal au paramètre
+func gte(v reflect.Value, param string) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() >= int64(atoi(param))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()) >= int64(atoi(param))
+	default:
+		return false
+	}
+}
+
+// matches crée une règle vérifiant une chaîne par expression régulière
+func matches(re *regexp.Regexp) ruleFunc {
+	return func(v reflect.Value, _ string) bool {
+		return v.Kind() == reflect.String && re.MatchString(v.String())
+	}
+}
+
+// oneOf vérifie que la chaîne fait partie des valeurs séparées par des espaces
+func oneOf(v reflect.Value, param string) bool {
+	for _, allowed := range strings.Fields(param) {
+		if v.String() == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// isURL vérifie une URI absolue; les schémas personnalisés (applications natives) sont acceptés
+func isURL(v reflect.Value, _ string) bool {
+	parsed, err := url.Parse(v.String())
+	return err == nil && parsed.Scheme != "" && (parsed.Host != "" || parsed.Opaque != "" || parsed.Path != "")
+}
+
+// isKetoNamespace vérifie que le namespace est déclaré dans Keto
+func isKetoNamespace(v reflect.Value, _ string) bool {
+	for _, namespace := range KetoNamespaces {
+		if v.String() == namespace {
+			return true
+		}
+	}
+	return false
+}
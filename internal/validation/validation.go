@@ -0,0 +1,190 @@
+// Package validation applique les règles déclarées dans les tags `validate:"..."` des modèles.
+//
+// Les règles sont séparées par des virgules et s'appliquent dans l'ordre:
+//
+//	Email string `json:"email" validate:"required,email"`
+//	URIs  []string `json:"redirectUris" validate:"omitempty,dive,url"`
+//
+// omitempty ignore les règles suivantes pour une valeur vide; dive applique les
+// règles suivantes à chaque élément d'une liste. Les champs sont désignés par leur
+// nom JSON (ex: "redirectUris[1]"). Les règles sont analysées une seule fois par type.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"ndugu-backend/internal/common"
+)
+
+// rule règle d'un tag, ex: {name: "max", param: "50"}
+type rule struct {
+	name  string
+	param string
+}
+
+// fieldRules règles d'un champ de structure
+type fieldRules struct {
+	index []int
+	name  string // nom JSON du champ
+	rules []rule
+	// nested champ de type structure validé récursivement
+	nested bool
+}
+
+// cache règles analysées par type de structure
+var cache sync.Map // map[reflect.Type][]fieldRules
+
+// Struct valide une structure (ou un pointeur vers une structure) selon ses tags.
+// Toutes les violations sont rapportées dans une common.AppError INVALID_INPUT.
+func Struct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return common.ErrInvalidInput
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validation: %T n'est pas une structure", v))
+	}
+
+	var violations []common.FieldViolation
+	validateStruct("", value, &violations)
+	if len(violations) > 0 {
+		return common.NewValidationError(violations...)
+	}
+	return nil
+}
+
+// Value valide une valeur isolée avec les règles d'un tag, ex: Value("phoneCode", code, "required,phoneCode")
+func Value(field string, value interface{}, tag string) error {
+	var violations []common.FieldViolation
+	validateValue(field, reflect.ValueOf(value), parseTag(tag), &violations)
+	if len(violations) > 0 {
+		return common.NewValidationError(violations...)
+	}
+	return nil
+}
+
+// validateStruct applique les règles de chaque champ d'une structure
+func validateStruct(prefix string, value reflect.Value, violations *[]common.FieldViolation) {
+	for _, f := range rulesFor(value.Type()) {
+		path := f.name
+		if prefix != "" {
+			path = prefix + "." + f.name
+		}
+		field := value.FieldByIndex(f.index)
+
+		if len(f.rules) > 0 {
+			validateValue(path, field, f.rules, violations)
+		}
+		if f.nested {
+			for field.Kind() == reflect.Ptr && !field.IsNil() {
+				field = field.Elem()
+			}
+			if field.Kind() == reflect.Struct {
+				validateStruct(path, field, violations)
+			}
+		}
+	}
+}
+
+// validateValue applique les règles à une valeur et s'arrête à la première violation
+func validateValue(path string, value reflect.Value, rules []rule, violations *[]common.FieldViolation) {
+	for i, r := range rules {
+		switch r.name {
+		case "omitempty":
+			if isEmpty(value) {
+				return
+			}
+		case "dive":
+			for j := 0; j < value.Len(); j++ {
+				validateValue(fmt.Sprintf("%s[%d]", path, j), value.Index(j), rules[i+1:], violations)
+			}
+			return
+		default:
+			if !ruleFuncs[r.name](value, r.param) {
+				*violations = append(*violations, common.NewViolation(path, r.name, r.param))
+				return
+			}
+		}
+	}
+}
+
+// rulesFor retourne les règles d'un type, analysées au premier appel
+func rulesFor(t reflect.Type) []fieldRules {
+	if cached, ok := cache.Load(t); ok {
+		return cached.([]fieldRules)
+	}
+
+	var fields []fieldRules
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("validate")
+		nested := isStruct(sf.Type)
+		if (tag == "" || tag == "-") && !nested {
+			continue
+		}
+
+		name := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = sf.Name
+		}
+		f := fieldRules{index: sf.Index, name: name, nested: nested}
+		if tag != "-" {
+			f.rules = parseTag(tag)
+		}
+		fields = append(fields, f)
+	}
+
+	cached, _ := cache.LoadOrStore(t, fields)
+	return cached.([]fieldRules)
+}
+
+// parseTag analyse un tag; une règle inconnue est une erreur de programmation
+func parseTag(tag string) []rule {
+	if tag == "" {
+		return nil
+	}
+	parts := strings.Split(tag, ",")
+	rules := make([]rule, 0, len(parts))
+	for _, part := range parts {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "omitempty" && name != "dive" {
+			if _, ok := ruleFuncs[name]; !ok {
+				panic(fmt.Sprintf("validation: règle inconnue %q dans le tag %q", name, tag))
+			}
+		}
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+// isStruct indique si un type de champ est une structure à valider récursivement
+func isStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// time.Time et les types similaires n'ont pas de champs exportés à valider
+	return t.Kind() == reflect.Struct && t.PkgPath() != "time"
+}
+
+// isEmpty indique si une valeur est vide (chaîne blanche, liste vide, valeur zéro)
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	case reflect.Invalid:
+		return true
+	default:
+		return value.IsZero()
+	}
+}
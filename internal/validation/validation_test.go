@@ -0,0 +1,147 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/models"
+)
+
+// violations extrait les violations d'une erreur de validation
+func violations(t *testing.T, err error) []common.FieldViolation {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) || appErr.Code != common.ErrCodeInvalidInput {
+		t.Fatalf("error = %v, want INVALID_INPUT AppError", err)
+	}
+	return appErr.Violations
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  []common.FieldViolation
+	}{
+		{
+			name:  "valid user",
+			input: &models.CreateUserRequest{Email: "jean@example.com", FirstName: "Jean", LastName: "Mukendi"},
+		},
+		{
+			name:  "every invalid field is reported",
+			input: &models.CreateUserRequest{Email: "jean@", FirstName: "J"},
+			want: []common.FieldViolation{
+				{Field: "email", Rule: "email"},
+				{Field: "firstName", Rule: "min"},
+				{Field: "lastName", Rule: "required"},
+			},
+		},
+		{
+			name:  "blank value is missing",
+			input: models.CreateUserRequest{Email: "jean@example.com", FirstName: "   ", LastName: "Mukendi"},
+			want:  []common.FieldViolation{{Field: "firstName", Rule: "required"}},
+		},
+		{
+			name:  "omitempty skips empty fields",
+			input: &models.UpdateUserRequest{ID: "user-1"},
+		},
+		{
+			name:  "max counts characters, not bytes",
+			input: &models.UpdateUserRequest{ID: "user-1", FirstName: strings.Repeat("é", 50)},
+		},
+		{
+			name:  "dive reports the element index",
+			input: &models.CreateOAuth2ClientRequest{Name: "Web App", RedirectURIs: []string{"https://app.example.com/cb", "/callback", "com.example.app:/cb"}},
+			want:  []common.FieldViolation{{Field: "redirectUris[1]", Rule: "url"}},
+		},
+		{
+			name:  "phone code",
+			input: &models.CreateCustomerRequest{PhoneCode: "00243", PhoneNumber: "812345678", Password: "password123"},
+			want:  []common.FieldViolation{{Field: "phoneCode", Rule: "phoneCode"}},
+		},
+		{
+			name:  "unknown keto namespace",
+			input: &models.CheckPermissionRequest{Namespace: "documents", Object: "doc-1", Relation: "viewer", Subject: "user-1"},
+			want:  []common.FieldViolation{{Field: "namespace", Rule: "ketoNamespace"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := violations(t, Struct(tt.input))
+
+			// Assert
+			if len(got) != len(tt.want) {
+				t.Fatalf("Struct() violations = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Field != tt.want[i].Field || got[i].Rule != tt.want[i].Rule {
+					t.Errorf("violation[%d] = %s/%s, want %s/%s", i, got[i].Field, got[i].Rule, tt.want[i].Field, tt.want[i].Rule)
+				}
+				if got[i].Description == "" {
+					t.Errorf("violation[%d] has no description", i)
+				}
+			}
+		})
+	}
+}
+
+func TestStruct_NestedPath(t *testing.T) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type request struct {
+		Address *address `json:"address"`
+	}
+
+	got := violations(t, Struct(&request{Address: &address{}}))
+
+	if len(got) != 1 || got[0].Field != "address.city" {
+		t.Errorf("Struct() violations = %+v, want address.city", got)
+	}
+}
+
+func TestStruct_MessageParameter(t *testing.T) {
+	got := violations(t, Struct(&models.CreatePermissionRequest{Namespace: "groups", Object: "admins", Relation: "member", Subject: strings.Repeat("a", 101)}))
+
+	if len(got) != 1 || got[0].Description != "Ne peut pas dépasser 100 caractères" {
+		t.Errorf("Struct() violations = %+v, want max=100 message", got)
+	}
+}
+
+func TestValue_E164(t *testing.T) {
+	tests := []struct {
+		phone   string
+		wantErr bool
+	}{
+		{phone: "+243812345678"},
+		{phone: "243812345678", wantErr: true},
+		{phone: "+2438123"},
+		{phone: "+0812345678", wantErr: true},
+		{phone: "+24381234567890123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			err := Value("phoneNumber", tt.phone, "e164")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Value(%q) error = %v, wantErr %v", tt.phone, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTag_UnknownRulePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("parseTag() did not panic on an unknown rule")
+		}
+	}()
+	parseTag("required,phone")
+}
@@ -0,0 +1,16 @@
+-- Table des utilisateurs synchronisés avec les identités Kratos
+CREATE TABLE IF NOT EXISTS users (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email       TEXT        NOT NULL,
+    first_name  TEXT        NOT NULL DEFAULT '',
+    last_name   TEXT        NOT NULL DEFAULT '',
+    traits      JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Unicité de l'email insensible à la casse
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_idx ON users (lower(email));
+
+-- Pagination stable par date de création
+CREATE INDEX IF NOT EXISTS users_created_at_idx ON users (created_at, id);
@@ -0,0 +1,3 @@
+-- Recherche par préfixe d'email insensible à la casse (lower(email) LIKE 'prefixe%'),
+-- indépendamment de la collation de la base
+CREATE INDEX IF NOT EXISTS users_email_lower_pattern_idx ON users (lower(email) text_pattern_ops);
@@ -0,0 +1,13 @@
+-- Réponses mémorisées des requêtes portant une clé d'idempotence.
+-- key est l'empreinte de la clé, de la méthode gRPC et du sujet authentifié;
+-- response reste NULL tant que la requête est en cours de traitement.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key           TEXT PRIMARY KEY,
+    request_hash  TEXT        NOT NULL,
+    response      BYTEA,
+    created_at    TIMESTAMPTZ NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL
+);
+
+-- Purge des enregistrements expirés
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,13 @@
+// Package migrations embarque les migrations SQL du schéma applicatif Ndugu.
+//
+// Les fichiers suivent la convention <version>_<description>.up.sql /
+// <version>_<description>.down.sql. Seuls les fichiers .up.sql sont appliqués
+// automatiquement au démarrage; les fichiers .down.sql servent au rollback manuel.
+package migrations
+
+import "embed"
+
+// FS contient l'ensemble des fichiers de migration SQL
+//
+//go:embed *.sql
+var FS embed.FS
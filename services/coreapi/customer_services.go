@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+
+	"ndugu-backend/internal/common"
+	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/models"
+	"ndugu-backend/internal/services"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// customerGRPCServer implémente le service gRPC CustomerService
+type customerGRPCServer struct {
+	v1.UnimplementedCustomerServiceServer
+	customerService services.CustomerService
+	logger          common.Logger
+}
+
+// ============================================================================
+// CustomerService Implementation
+// ============================================================================
+
+// CreateCustomer crée un nouveau client
+func (s *customerGRPCServer) CreateCustomer(ctx context.Context, req *v1.CreateCustomerRequest) (*v1.CreateCustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC CreateCustomer appelé", "phoneCode", req.PhoneCode)
+
+	// Appeler le service
+	customer, err := s.customerService.CreateCustomer(ctx, &models.CreateCustomerRequest{
+		PhoneCode:   req.PhoneCode,
+		PhoneNumber: req.PhoneNumber,
+		Password:    req.Password,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la création du client via gRPC", "error", err)
+		return nil, err
+	}
+
+	return &v1.CreateCustomerResponse{Customer: toProtoCustomer(customer)}, nil
+}
+
+// GetCustomer récupère un client par son ID
+func (s *customerGRPCServer) GetCustomer(ctx context.Context, req *v1.GetCustomerRequest) (*v1.GetCustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC GetCustomer appelé", "customerId", req.CustomerId)
+
+	if req.CustomerId == "" {
+		return nil, common.NewFieldError("clientId", "required")
+	}
+
+	customer, err := s.customerService.GetCustomer(ctx, req.CustomerId)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération du client", "customerId", req.CustomerId, "error", err)
+		return nil, err
+	}
+
+	return &v1.GetCustomerResponse{Customer: toProtoCustomer(customer)}, nil
+}
+
+// GetCustomerByPhone récupère un client par son numéro de téléphone
+func (s *customerGRPCServer) GetCustomerByPhone(ctx context.Context, req *v1.GetCustomerByPhoneRequest) (*v1.GetCustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC GetCustomerByPhone appelé", "phoneCode", req.PhoneCode)
+
+	if req.PhoneCode == "" {
+		return nil, common.NewFieldError("phoneCode", "required")
+	}
+	if req.PhoneNumber == "" {
+		return nil, common.NewFieldError("phoneNumber", "required")
+	}
+
+	customer, err := s.customerService.GetCustomerByPhone(ctx, req.PhoneCode, req.PhoneNumber)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération du client par téléphone", "error", err)
+		return nil, err
+	}
+
+	return &v1.GetCustomerResponse{Customer: toProtoCustomer(customer)}, nil
+}
+
+// UpdateCustomer met à jour un client
+func (s *customerGRPCServer) UpdateCustomer(ctx context.Context, req *v1.UpdateCustomerRequest) (*v1.UpdateCustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC UpdateCustomer appelé", "customerId", req.CustomerId)
+
+	if req.CustomerId == "" {
+		return nil, common.NewFieldError("clientId", "required")
+	}
+
+	customer, err := s.customerService.UpdateCustomer(ctx, &models.UpdateCustomerRequest{
+		ID:          req.CustomerId,
+		PhoneCode:   req.PhoneCode,
+		PhoneNumber: req.PhoneNumber,
+		Password:    req.Password,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour du client", "customerId", req.CustomerId, "error", err)
+		return nil, err
+	}
+
+	return &v1.UpdateCustomerResponse{Customer: toProtoCustomer(customer)}, nil
+}
+
+// DeactivateCustomer désactive un client
+func (s *customerGRPCServer) DeactivateCustomer(ctx context.Context, req *v1.DeactivateCustomerRequest) (*v1.DeactivateCustomerResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC DeactivateCustomer appelé", "customerId", req.CustomerId)
+
+	if req.CustomerId == "" {
+		return nil, common.NewFieldError("clientId", "required")
+	}
+
+	customer, err := s.customerService.DeactivateCustomer(ctx, req.CustomerId)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la désactivation du client", "customerId", req.CustomerId, "error", err)
+		return nil, err
+	}
+
+	return &v1.DeactivateCustomerResponse{Customer: toProtoCustomer(customer)}, nil
+}
+
+// ListCustomers liste les clients avec pagination
+func (s *customerGRPCServer) ListCustomers(ctx context.Context, req *v1.ListCustomersRequest) (*v1.ListCustomersResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC ListCustomers appelé", "limit", req.Limit, "offset", req.Offset)
+
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, common.NewFieldError("limit", "gte", "0")
+	}
+
+	customers, err := s.customerService.ListCustomers(ctx, int(req.Limit), int(req.Offset))
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération des clients", "error", err)
+		return nil, err
+	}
+
+	response := &v1.ListCustomersResponse{
+		Customers: make([]*v1.Customer, 0, len(customers)),
+	}
+	for _, customer := range customers {
+		response.Customers = append(response.Customers, toProtoCustomer(customer))
+	}
+
+	return response, nil
+}
+
+// VerifyCustomerCredentials vérifie le numéro et le mot de passe d'un client
+func (s *customerGRPCServer) VerifyCustomerCredentials(ctx context.Context, req *v1.VerifyCustomerCredentialsRequest) (*v1.VerifyCustomerCredentialsResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC VerifyCustomerCredentials appelé", "phoneCode", req.PhoneCode)
+
+	if req.PhoneCode == "" {
+		return nil, common.NewFieldError("phoneCode", "required")
+	}
+	if req.PhoneNumber == "" {
+		return nil, common.NewFieldError("phoneNumber", "required")
+	}
+	if req.Password == "" {
+		return nil, common.NewFieldError("password", "required")
+	}
+
+	customer, err := s.customerService.VerifyCustomerCredentials(ctx, req.PhoneCode, req.PhoneNumber, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.VerifyCustomerCredentialsResponse{Customer: toProtoCustomer(customer)}, nil
+}
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// toProtoCustomer convertit un CustomerResponse en message gRPC
+func toProtoCustomer(customer *models.CustomerResponse) *v1.Customer {
+	return &v1.Customer{
+		CustomerId:  customer.ID,
+		PhoneCode:   customer.PhoneCode,
+		PhoneNumber: customer.PhoneNumber,
+		IsActive:    customer.IsActive,
+		CreatedAt:   timestamppb.New(customer.CreatedAt),
+		UpdatedAt:   timestamppb.New(customer.UpdatedAt),
+	}
+}
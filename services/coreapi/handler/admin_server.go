@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+
+	"ndugu-backend/internal/config"
+)
+
+// NewAdminServer crée le serveur HTTP d'administration, exposant les métriques Prometheus
+// sur GET /metrics. Il écoute sur un port distinct, non routé par la passerelle.
+func NewAdminServer(cfg config.ServerConfig, metricsHandler http.Handler) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metricsHandler)
+
+	return &http.Server{
+		Addr:         net.JoinHostPort(cfg.Host, cfg.AdminPort),
+		Handler:      mux,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+}
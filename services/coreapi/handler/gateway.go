@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"ndugu-backend/internal/common"
+	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/interceptors"
+)
+
+// forwardedHeaders en-têtes HTTP transmis sans préfixe en métadonnées gRPC: ce sont ceux
+// que lisent les intercepteurs (session Kratos, langue, corrélation, idempotence). Authorization
+// est transmis d'office par la passerelle.
+var forwardedHeaders = map[string]bool{
+	"X-Session-Token": true,
+	"Accept-Language": true,
+	"X-Request-Id":    true,
+	"Idempotency-Key": true,
+}
+
+// returnedHeaders métadonnées de réponse renvoyées sans préfixe en en-têtes HTTP
+var returnedHeaders = map[string]bool{
+	interceptors.IdempotentReplayedHeader: true,
+}
+
+// NewGateway crée la passerelle REST/JSON des services ndugu.v1, dont les routes sont
+// déclarées par les annotations google.api.http de api/coreapi.proto.
+// Les requêtes sont transmises au serveur gRPC par conn: elles passent par les mêmes
+// intercepteurs (authentification, validation, traduction des erreurs) que les appels gRPC.
+// Les réponses utilisent l'enveloppe common.Response et le statut HTTP de l'AppError.
+func NewGateway(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &envelopeMarshaler{JSONPb: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}}),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithMetadata(nameSpan),
+		runtime.WithErrorHandler(writeGatewayError),
+		runtime.WithRoutingErrorHandler(writeRoutingError),
+	)
+	if err := v1.RegisterAuthServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := v1.RegisterCustomerServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// envelopeMarshaler encode les réponses dans l'enveloppe common.Response
+// ({"success": true, "data": ...}); les messages sont encodés par protojson
+type envelopeMarshaler struct {
+	*runtime.JSONPb
+}
+
+// Marshal enveloppe le message encodé en JSON
+func (m *envelopeMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(common.SuccessResponse(json.RawMessage(data)))
+}
+
+// incomingHeader transmet les en-têtes lus par les intercepteurs sous leur nom de métadonnée
+func incomingHeader(key string) (string, bool) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	if forwardedHeaders[key] {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader renvoie les métadonnées de returnedHeaders sous leur nom d'en-tête HTTP,
+// les autres avec le préfixe Grpc-Metadata- de la passerelle
+func outgoingHeader(key string) (string, bool) {
+	if returnedHeaders[key] {
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// nameSpan renomme le span de la requête HTTP d'après la route de la passerelle
+// ("GET /v1/users/{userId}"), pour que son nom ne dépende pas des paramètres du chemin
+func nameSpan(ctx context.Context, r *http.Request) metadata.MD {
+	if pattern, ok := runtime.HTTPPathPattern(ctx); ok {
+		trace.SpanFromContext(ctx).SetName(r.Method + " " + pattern)
+	}
+	return nil
+}
+
+// writeGatewayError écrit l'erreur d'un appel gRPC dans l'enveloppe common.Response.
+// Les messages sont déjà traduits par le serveur gRPC selon Accept-Language.
+func writeGatewayError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("Content-Language", common.RequestLanguage(r))
+	common.WriteError(w, interceptors.FromStatus(status.Convert(err)))
+}
+
+// writeRoutingError écrit une erreur NOT_FOUND pour une route inconnue ou une méthode HTTP non déclarée
+func writeRoutingError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	lang := common.RequestLanguage(r)
+	appErr := common.ErrNotFound.Localize(lang)
+	appErr.HTTPStatus = httpStatus
+	w.Header().Set("Content-Language", lang)
+	common.WriteError(w, appErr)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"ndugu-backend/internal/common"
+	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/interceptors"
+)
+
+// fakeCustomerServer connaît le client "c-1"; les autres méthodes ne sont pas implémentées.
+// Une requête portant une clé d'idempotence est signalée comme rejouée.
+type fakeCustomerServer struct {
+	v1.UnimplementedCustomerServiceServer
+	lastRequestID      string
+	lastIdempotencyKey string
+}
+
+func (s *fakeCustomerServer) GetCustomer(ctx context.Context, req *v1.GetCustomerRequest) (*v1.GetCustomerResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(common.RequestIDHeader); len(values) > 0 {
+		s.lastRequestID = values[0]
+	}
+	if values := md.Get(interceptors.IdempotencyKeyHeader); len(values) > 0 {
+		s.lastIdempotencyKey = values[0]
+		grpc.SetHeader(ctx, metadata.Pairs(interceptors.IdempotentReplayedHeader, "true"))
+	}
+	if req.CustomerId != "c-1" {
+		return nil, common.ErrCustomerNotFound
+	}
+	return &v1.GetCustomerResponse{Customer: &v1.Customer{CustomerId: "c-1", PhoneCode: "+243", PhoneNumber: "812345678"}}, nil
+}
+
+func (s *fakeCustomerServer) CreateCustomer(ctx context.Context, req *v1.CreateCustomerRequest) (*v1.CreateCustomerResponse, error) {
+	return nil, common.NewValidationError(common.NewViolation("password", "required", ""))
+}
+
+// newTestGateway démarre un serveur gRPC en mémoire, avec l'intercepteur d'erreurs, et sa passerelle
+func newTestGateway(t *testing.T) (http.Handler, *fakeCustomerServer) {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(interceptors.NewErrorInterceptor(common.NewSimpleLogger()).Unary()))
+	customers := &fakeCustomerServer{}
+	v1.RegisterCustomerServiceServer(server, customers)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	gateway, err := NewGateway(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("NewGateway() error = %v", err)
+	}
+	return gateway, customers
+}
+
+func TestGateway(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		language    string
+		wantStatus  int
+		wantSuccess bool
+		wantCode    common.ErrorCode
+	}{
+		{name: "found", method: http.MethodGet, path: "/v1/customers/c-1", wantStatus: http.StatusOK, wantSuccess: true},
+		{name: "not found", method: http.MethodGet, path: "/v1/customers/c-2", wantStatus: http.StatusNotFound, wantCode: common.ErrCodeCustomerNotFound},
+		{name: "validation error", method: http.MethodPost, path: "/v1/customers", body: `{"phoneCode":"+243","phoneNumber":"812345678"}`, wantStatus: http.StatusBadRequest, wantCode: common.ErrCodeInvalidInput},
+		{name: "malformed body", method: http.MethodPost, path: "/v1/customers", body: `{"phoneCode":`, wantStatus: http.StatusBadRequest, wantCode: common.ErrCodeInvalidInput},
+		{name: "unimplemented method", method: http.MethodGet, path: "/v1/customers", wantStatus: http.StatusNotImplemented, wantCode: common.ErrCodeInternal},
+		{name: "unknown route", method: http.MethodGet, path: "/v1/unknown", wantStatus: http.StatusNotFound, wantCode: common.ErrCodeNotFound},
+		{name: "english message", method: http.MethodGet, path: "/v1/customers/c-2", language: "en", wantStatus: http.StatusNotFound, wantCode: common.ErrCodeCustomerNotFound},
+	}
+
+	gateway, _ := newTestGateway(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.language != "" {
+				req.Header.Set("Accept-Language", tt.language)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			gateway.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			var resp struct {
+				Success bool             `json:"success"`
+				Data    json.RawMessage  `json:"data"`
+				Error   *common.AppError `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid envelope: %v (%s)", err, rec.Body.String())
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Errorf("success = %v, want %v", resp.Success, tt.wantSuccess)
+			}
+			if tt.wantCode != "" && (resp.Error == nil || resp.Error.Code != tt.wantCode) {
+				t.Errorf("error = %+v, want code %s", resp.Error, tt.wantCode)
+			}
+			if tt.language != "" {
+				want := common.ErrCustomerNotFound.Localize(tt.language).Message
+				if resp.Error == nil || resp.Error.Message != want {
+					t.Errorf("error = %+v, want message %q", resp.Error, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGateway_ResponseData(t *testing.T) {
+	gateway, customers := newTestGateway(t)
+	req := httptest.NewRequest(http.MethodGet, "/v1/customers/c-1", nil)
+	req.Header.Set("X-Request-Id", "req-123")
+	rec := httptest.NewRecorder()
+
+	gateway.ServeHTTP(rec, req)
+
+	var resp struct {
+		Data struct {
+			Customer struct {
+				CustomerID string `json:"customerId"`
+				PhoneCode  string `json:"phoneCode"`
+				IsActive   *bool  `json:"isActive"`
+			} `json:"customer"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid envelope: %v", err)
+	}
+	if resp.Data.Customer.CustomerID != "c-1" || resp.Data.Customer.PhoneCode != "+243" {
+		t.Errorf("customer = %+v, want c-1", resp.Data.Customer)
+	}
+	if resp.Data.Customer.IsActive == nil {
+		t.Error("isActive is missing, want unpopulated fields to be emitted")
+	}
+	if customers.lastRequestID != "req-123" {
+		t.Errorf("x-request-id metadata = %q, want %q", customers.lastRequestID, "req-123")
+	}
+}
+
+func TestGateway_IdempotencyHeaders(t *testing.T) {
+	gateway, customers := newTestGateway(t)
+	req := httptest.NewRequest(http.MethodGet, "/v1/customers/c-1", nil)
+	req.Header.Set("Idempotency-Key", "key-123")
+	rec := httptest.NewRecorder()
+
+	gateway.ServeHTTP(rec, req)
+
+	if customers.lastIdempotencyKey != "key-123" {
+		t.Errorf("idempotency-key metadata = %q, want %q", customers.lastIdempotencyKey, "key-123")
+	}
+	if got := rec.Header().Get("Idempotent-Replayed"); got != "true" {
+		t.Errorf("Idempotent-Replayed header = %q, want %q", got, "true")
+	}
+}
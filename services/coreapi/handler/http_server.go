@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/health"
+)
+
+// NewHTTPServer crée le serveur HTTP exposant les endpoints REST.
+// gateway sert les services ndugu.v1 en REST/JSON sous /v1/ (voir NewGateway);
+// checker fournit les sondes de vivacité et de disponibilité pour les orchestrateurs.
+func NewHTTPServer(cfg config.ServerConfig, gateway http.Handler, oauth2Handler *OAuth2ProviderHandler, checker *health.Checker) *http.Server {
+	mux := http.NewServeMux()
+
+	// Passerelle REST/JSON des services gRPC
+	mux.Handle("/v1/", gateway)
+
+	// Fournisseur de login/consentement pour Hydra
+	oauth2Handler.RegisterRoutes(mux)
+
+	// Sondes pour les orchestrateurs: vivacité (le processus répond) et disponibilité
+	// (démarrage terminé, arrêt non commencé, dépendances joignables).
+	// /health et /ready sont conservés pour les déploiements existants.
+	mux.Handle("GET /health/live", checker.LiveHandler())
+	mux.Handle("GET /health/ready", checker.ReadyHandler())
+	mux.Handle("GET /health", checker.ReadyHandler())
+	mux.Handle("GET /ready", checker.ReadyHandler())
+
+	return &http.Server{
+		Addr:         net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:      otelhttp.NewHandler(withRequestContext(mux), "coreapi", otelhttp.WithSpanNameFormatter(serverSpanName)),
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+}
+
+// withRequestContext place l'identifiant de corrélation (X-Request-Id repris ou généré),
+// la route et la trace en cours dans le contexte des logs, et renvoie l'identifiant au client.
+// L'identifiant est aussi reporté dans la requête, que la passerelle transmet au serveur gRPC.
+func withRequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := common.RequestIDOrNew(r.Header.Get(common.RequestIDHeader))
+		w.Header().Set(common.RequestIDHeader, requestID)
+		r.Header.Set(common.RequestIDHeader, requestID)
+		ctx := common.WithLogFields(r.Context(), common.LogKeyRequestID, requestID, common.LogKeyMethod, r.Method+" "+r.URL.Path)
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			ctx = common.WithLogFields(ctx, common.LogKeyTraceID, spanContext.TraceID().String())
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// serverSpanName nomme le span d'une requête entrante d'après son chemin; la passerelle
+// le renomme d'après sa route, les chemins /v1/ ayant des paramètres
+func serverSpanName(operation string, r *http.Request) string {
+	return r.Method + " " + r.URL.Path
+}
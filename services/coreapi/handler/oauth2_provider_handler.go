@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/repository"
+	"ndugu-backend/internal/services"
+)
+
+// OAuth2ProviderHandler expose les endpoints de login/consentement/déconnexion
+// appelés par l'interface vers laquelle Hydra redirige (urls.login, urls.consent, urls.logout)
+type OAuth2ProviderHandler struct {
+	providerService services.OAuth2ProviderService
+	logger          common.Logger
+}
+
+// NewOAuth2ProviderHandler crée une nouvelle instance du handler
+func NewOAuth2ProviderHandler(providerService services.OAuth2ProviderService, logger common.Logger) *OAuth2ProviderHandler {
+	return &OAuth2ProviderHandler{
+		providerService: providerService,
+		logger:          logger,
+	}
+}
+
+// redirectResponse indique à l'interface où rediriger le navigateur
+type redirectResponse struct {
+	RedirectTo string `json:"redirectTo"`
+}
+
+// oauth2ClientResponse client OAuth2 à l'origine d'une requête de login ou de consentement
+type oauth2ClientResponse struct {
+	ClientID   string `json:"clientId"`
+	ClientName string `json:"clientName"`
+}
+
+// loginRequestResponse requête de login présentée à l'interface
+type loginRequestResponse struct {
+	Challenge      string               `json:"challenge"`
+	Skip           bool                 `json:"skip"`
+	Subject        string               `json:"subject"`
+	Client         oauth2ClientResponse `json:"client"`
+	RequestedScope []string             `json:"requestedScope"`
+}
+
+// consentRequestResponse requête de consentement présentée à l'interface: le client
+// et les scopes parmi lesquels l'utilisateur choisit
+type consentRequestResponse struct {
+	Challenge                    string               `json:"challenge"`
+	Skip                         bool                 `json:"skip"`
+	Subject                      string               `json:"subject"`
+	Client                       oauth2ClientResponse `json:"client"`
+	RequestedScope               []string             `json:"requestedScope"`
+	RequestedAccessTokenAudience []string             `json:"requestedAccessTokenAudience"`
+}
+
+// logoutRequestResponse requête de déconnexion présentée à l'interface
+type logoutRequestResponse struct {
+	Challenge   string `json:"challenge"`
+	Subject     string `json:"subject"`
+	RPInitiated bool   `json:"rpInitiated"`
+}
+
+// acceptConsentBody choix de l'utilisateur envoyé par l'interface de consentement
+type acceptConsentBody struct {
+	GrantScope               []string `json:"grantScope"`
+	GrantAccessTokenAudience []string `json:"grantAccessTokenAudience"`
+	Remember                 bool     `json:"remember"`
+}
+
+// RegisterRoutes enregistre les endpoints du fournisseur OAuth2. Les requêtes GET ne font
+// que lire le challenge; seules les requêtes POST l'acceptent ou le refusent.
+func (h *OAuth2ProviderHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /oauth2/login", h.handleGetLogin)
+	mux.HandleFunc("POST /oauth2/login", h.handleAcceptLogin)
+	mux.HandleFunc("POST /oauth2/login/reject", h.handleRejectLogin)
+	mux.HandleFunc("GET /oauth2/consent", h.handleGetConsent)
+	mux.HandleFunc("POST /oauth2/consent", h.handleAcceptConsent)
+	mux.HandleFunc("POST /oauth2/consent/reject", h.handleRejectConsent)
+	mux.HandleFunc("GET /oauth2/logout", h.handleGetLogout)
+	mux.HandleFunc("POST /oauth2/logout", h.handleAcceptLogout)
+}
+
+// handleGetLogin retourne la requête de login (client, scopes demandés, skip)
+func (h *OAuth2ProviderHandler) handleGetLogin(w http.ResponseWriter, r *http.Request) {
+	loginReq, err := h.providerService.GetLoginRequest(r.Context(), r.FormValue("login_challenge"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	common.WriteSuccess(w, loginRequestResponse{
+		Challenge:      loginReq.Challenge,
+		Skip:           loginReq.Skip,
+		Subject:        loginReq.Subject,
+		Client:         clientResponse(loginReq.Client),
+		RequestedScope: loginReq.RequestedScope,
+	})
+}
+
+// handleAcceptLogin accepte le login_challenge avec la session Kratos de l'utilisateur
+func (h *OAuth2ProviderHandler) handleAcceptLogin(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(ctx context.Context) (string, error) {
+		return h.providerService.AcceptLogin(ctx, r.FormValue("login_challenge"), sessionCredentials(r))
+	})
+}
+
+// handleRejectLogin refuse le login_challenge
+func (h *OAuth2ProviderHandler) handleRejectLogin(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(ctx context.Context) (string, error) {
+		return h.providerService.RejectLogin(ctx, r.FormValue("login_challenge"))
+	})
+}
+
+// handleGetConsent retourne la requête de consentement (client, scopes et audiences demandés)
+func (h *OAuth2ProviderHandler) handleGetConsent(w http.ResponseWriter, r *http.Request) {
+	consentReq, err := h.providerService.GetConsentRequest(r.Context(), r.FormValue("consent_challenge"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	common.WriteSuccess(w, consentRequestResponse{
+		Challenge:                    consentReq.Challenge,
+		Skip:                         consentReq.Skip,
+		Subject:                      consentReq.Subject,
+		Client:                       clientResponse(consentReq.Client),
+		RequestedScope:               consentReq.RequestedScope,
+		RequestedAccessTokenAudience: consentReq.RequestedAccessTokenAudience,
+	})
+}
+
+// handleAcceptConsent accepte le consent_challenge pour les scopes accordés par l'utilisateur (corps JSON)
+func (h *OAuth2ProviderHandler) handleAcceptConsent(w http.ResponseWriter, r *http.Request) {
+	challenge := r.URL.Query().Get("consent_challenge")
+
+	var body acceptConsentBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		common.WriteLocalizedError(w, r, common.NewAppError(common.ErrCodeInvalidInput, "Corps de requête JSON invalide"))
+		return
+	}
+
+	h.respond(w, r, func(ctx context.Context) (string, error) {
+		return h.providerService.AcceptConsent(ctx, challenge, sessionCredentials(r), &services.ConsentGrant{
+			Scopes:   body.GrantScope,
+			Audience: body.GrantAccessTokenAudience,
+			Remember: body.Remember,
+		})
+	})
+}
+
+// handleRejectConsent refuse le consent_challenge
+func (h *OAuth2ProviderHandler) handleRejectConsent(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(ctx context.Context) (string, error) {
+		return h.providerService.RejectConsent(ctx, r.FormValue("consent_challenge"))
+	})
+}
+
+// handleGetLogout retourne la requête de déconnexion à confirmer
+func (h *OAuth2ProviderHandler) handleGetLogout(w http.ResponseWriter, r *http.Request) {
+	logoutReq, err := h.providerService.GetLogoutRequest(r.Context(), r.FormValue("logout_challenge"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	common.WriteSuccess(w, logoutRequestResponse{
+		Challenge:   logoutReq.Challenge,
+		Subject:     logoutReq.Subject,
+		RPInitiated: logoutReq.RPInitiated,
+	})
+}
+
+// handleAcceptLogout accepte le logout_challenge
+func (h *OAuth2ProviderHandler) handleAcceptLogout(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(ctx context.Context) (string, error) {
+		return h.providerService.AcceptLogout(ctx, r.FormValue("logout_challenge"))
+	})
+}
+
+// respond exécute l'action et écrit l'URL de redirection ou l'erreur
+func (h *OAuth2ProviderHandler) respond(w http.ResponseWriter, r *http.Request, action func(ctx context.Context) (string, error)) {
+	redirectTo, err := action(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	common.WriteSuccess(w, redirectResponse{RedirectTo: redirectTo})
+}
+
+// writeError écrit une erreur d'application, ou une erreur interne pour toute autre erreur
+func (h *OAuth2ProviderHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if appErr, ok := err.(*common.AppError); ok {
+		common.WriteLocalizedError(w, r, appErr)
+		return
+	}
+	h.logger.WithContext(r.Context()).Error("Erreur du fournisseur OAuth2", "error", err)
+	common.WriteLocalizedError(w, r, common.NewAppError(common.ErrCodeInternal, "Erreur du fournisseur OAuth2"))
+}
+
+// clientResponse convertit le client OAuth2 d'une requête Hydra
+func clientResponse(client repository.HydraOAuth2ClientSummary) oauth2ClientResponse {
+	return oauth2ClientResponse{ClientID: client.ClientID, ClientName: client.ClientName}
+}
+
+// sessionCredentials extrait la session Kratos: cookie ory_kratos_session du navigateur ou en-tête X-Session-Token
+func sessionCredentials(r *http.Request) services.SessionCredentials {
+	credentials := services.SessionCredentials{Token: r.Header.Get("X-Session-Token")}
+	if cookie, err := r.Cookie(auth.KratosSessionCookie); err == nil {
+		credentials.Cookie = cookie.Value
+	}
+	return credentials
+}
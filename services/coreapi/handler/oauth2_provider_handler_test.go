@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ndugu-backend/internal/auth"
+	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/repository"
+	"ndugu-backend/internal/services"
+)
+
+// fakeProviderService connaît le consent_challenge "consent-1" et enregistre le choix accepté
+type fakeProviderService struct {
+	services.OAuth2ProviderService
+	accepted    *services.ConsentGrant
+	credentials services.SessionCredentials
+}
+
+func (s *fakeProviderService) GetConsentRequest(ctx context.Context, challenge string) (*repository.HydraConsentRequest, error) {
+	if challenge != "consent-1" {
+		return nil, common.NewAppError(common.ErrCodeNotFound, "Challenge OAuth2 introuvable")
+	}
+	return &repository.HydraConsentRequest{
+		Challenge:      "consent-1",
+		Subject:        "user-1",
+		Client:         repository.HydraOAuth2ClientSummary{ClientID: "shop", ClientName: "Boutique"},
+		RequestedScope: []string{"openid", "email", "profile"},
+	}, nil
+}
+
+func (s *fakeProviderService) AcceptConsent(ctx context.Context, challenge string, credentials services.SessionCredentials, grant *services.ConsentGrant) (string, error) {
+	s.accepted = grant
+	s.credentials = credentials
+	return "http://hydra/oauth2/auth?consent_verifier=v", nil
+}
+
+func newTestProviderMux() (*http.ServeMux, *fakeProviderService) {
+	provider := &fakeProviderService{}
+	mux := http.NewServeMux()
+	NewOAuth2ProviderHandler(provider, common.NewSimpleLogger()).RegisterRoutes(mux)
+	return mux, provider
+}
+
+func TestOAuth2ProviderHandler_GetConsentDoesNotAccept(t *testing.T) {
+	mux, provider := newTestProviderMux()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/oauth2/consent?consent_challenge=consent-1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /oauth2/consent status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if provider.accepted != nil {
+		t.Error("GET /oauth2/consent accepted the challenge")
+	}
+	var body struct {
+		Data consentRequestResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("GET /oauth2/consent body = %s: %v", rec.Body, err)
+	}
+	if body.Data.Client.ClientID != "shop" || !reflect.DeepEqual(body.Data.RequestedScope, []string{"openid", "email", "profile"}) {
+		t.Errorf("GET /oauth2/consent = %+v, want the client and its requested scopes", body.Data)
+	}
+}
+
+func TestOAuth2ProviderHandler_AcceptConsentGrantsBodyScopes(t *testing.T) {
+	mux, provider := newTestProviderMux()
+
+	req := httptest.NewRequest(http.MethodPost, "/oauth2/consent?consent_challenge=consent-1",
+		strings.NewReader(`{"grantScope":["openid","email"],"remember":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer access-token")
+	req.AddCookie(&http.Cookie{Name: auth.KratosSessionCookie, Value: "kratos-cookie"})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /oauth2/consent status = %d, body = %s", rec.Code, rec.Body)
+	}
+	want := &services.ConsentGrant{Scopes: []string{"openid", "email"}, Remember: true}
+	if !reflect.DeepEqual(provider.accepted, want) {
+		t.Errorf("POST /oauth2/consent grant = %+v, want %+v", provider.accepted, want)
+	}
+	// Le cookie Kratos du navigateur authentifie l'utilisateur, jamais un jeton Bearer
+	if want := (services.SessionCredentials{Cookie: "kratos-cookie"}); provider.credentials != want {
+		t.Errorf("POST /oauth2/consent credentials = %+v, want %+v", provider.credentials, want)
+	}
+}
@@ -1,56 +1,300 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"ndugu-backend/internal/common"
+	"ndugu-backend/internal/config"
+	"ndugu-backend/internal/database"
+	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/health"
+	"ndugu-backend/internal/interceptors"
+	"ndugu-backend/internal/lifecycle"
+	"ndugu-backend/internal/metrics"
+	"ndugu-backend/internal/password"
 	"ndugu-backend/internal/repository"
 	"ndugu-backend/internal/services"
+	"ndugu-backend/internal/tracing"
+	"ndugu-backend/migrations"
+	"ndugu-backend/services/coreapi/handler"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
 )
 
 func main() {
+	if err := run(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// run initialise les dépendances puis confie les serveurs au gestionnaire de cycle de vie.
+// Il retourne à la fin de l'arrêt gracieux.
+func run() error {
 	// Initialiser le logger
 	logger := common.NewSugarLogger()
 
+	// Charger la configuration: fichier, variables d'environnement puis options
+	cfg, opts, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if opts.PrintConfig {
+		cfg.Print(os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration invalide:\n%v\n", err)
+		}
+		return err
+	}
+	if err != nil {
+		logger.Error("Configuration invalide", "error", err)
+		return err
+	}
+	logger.SetLevel(cfg.Logging.Level)
+	logger.SetFormat(cfg.Logging.Format)
+	redactor, err := common.NewRedactor(common.RedactionPolicy(cfg.Logging.Redaction), cfg.Logging.RedactionKey)
+	if err != nil {
+		logger.Error("Configuration invalide", "error", err)
+		return err
+	}
+	logger.SetRedactor(redactor)
+
+	// Le contexte est annulé à la réception de SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	manager := lifecycle.NewManager(cfg.Server.ShutdownTimeout, cfg.Server.ShutdownDelay, logger)
+
+	// Vider les logs en dernier lors de l'arrêt
+	if syncer, ok := logger.(interface{ Sync() error }); ok {
+		manager.Add("logs", lifecycle.Closer(func() error {
+			syncer.Sync()
+			return nil
+		}))
+	}
+
+	// Traçage OpenTelemetry; les spans en attente sont vidés juste avant les logs
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		logger.Error("Configuration du traçage invalide", "error", err)
+		return err
+	}
+	manager.Add("tracing", lifecycle.Func{OnStop: shutdownTracing})
+
+	// Métriques Prometheus, exposées sur le port d'administration
+	m := metrics.New()
+
+	// Ouvrir la base de données et appliquer les migrations
+	db, err := database.Open(ctx, cfg.Database)
+	if err != nil {
+		logger.Error("Erreur lors de la connexion à la base de données", "error", err)
+		return err
+	}
+	// Fermée au retour de run: après l'arrêt des serveurs, ou sur une erreur d'initialisation
+	defer db.Close()
+	m.RegisterDB(db, cfg.Database.Name)
+
+	if err := database.Migrate(ctx, db, migrations.FS, logger); err != nil {
+		logger.Error("Erreur lors de l'application des migrations", "error", err)
+		return err
+	}
+
+	// Client HTTP partagé par les appels REST vers les services Ory, instrumenté par opération
+	// (métriques, spans et propagation du contexte de trace)
+	httpClient := m.InstrumentClient(tracing.InstrumentClient(&http.Client{Timeout: 10 * time.Second}))
+	manager.Add("ory-http-client", lifecycle.Closer(func() error {
+		httpClient.CloseIdleConnections()
+		return nil
+	}))
+
 	// Initialiser les repositories
-	userRepo := repository.NewMockUserRepository() // TODO: Remplacer par une vraie implémentation
-	oryClient := repository.NewOryClient(logger)
+	userRepo := repository.NewInstrumentedUserRepository(repository.NewPostgresUserRepository(db), m)
+	customerRepo := repository.NewInstrumentedCustomerRepository(repository.NewMemoryCustomerRepository(), m)
+	// Cache des sessions Kratos validées, désactivable (SESSION_CACHE_ENABLED=false)
+	var sessionCache *repository.SessionCache
+	if cfg.SessionCache.Enabled {
+		sessionCache = repository.NewSessionCache(cfg.SessionCache.Size, cfg.SessionCache.TTL, m)
+	}
+	// Cache des décisions Keto, vidé à chaque écriture de permission (PERMISSION_CACHE_ENABLED=false le désactive)
+	var permissionCache *repository.PermissionCache
+	if cfg.PermissionCache.Enabled {
+		permissionCache = repository.NewPermissionCache(cfg.PermissionCache.Size, cfg.PermissionCache.TTL, m)
+	}
+	oryClient := repository.NewOryClient(cfg.Ory, httpClient, sessionCache, permissionCache, logger)
+	hydraClient := repository.NewHydraClient(cfg.Ory.Hydra, httpClient)
+
+	// Initialiser le hachage des mots de passe
+	hasher, err := password.NewHasher(cfg.Password)
+	if err != nil {
+		logger.Error("Configuration de hachage des mots de passe invalide", "error", err)
+		return err
+	}
 
 	// Initialiser les services
 	authService := services.NewAuthService(userRepo, oryClient, logger)
+	customerService := services.NewCustomerService(customerRepo, hasher, logger)
+	oauth2ProviderService := services.NewOAuth2ProviderService(hydraClient, oryClient, cfg.Ory.Hydra, logger)
 
-	// Créer le serveur gRPC
-	grpcServer := NewGRPCServer(authService, logger)
+	// Clés d'idempotence des méthodes de modification, purgées périodiquement
+	idempotencyRepo := repository.NewMemoryIdempotencyRepository()
+	if cfg.Idempotency.Store == "postgres" {
+		idempotencyRepo = repository.NewPostgresIdempotencyRepository(db)
+	}
+	idempotencyRepo = repository.NewInstrumentedIdempotencyRepository(idempotencyRepo, m)
+	idempotencyInterceptor := interceptors.NewIdempotencyInterceptor(idempotencyRepo, idempotentMethods, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout, logger)
+	manager.Add("idempotency-cleanup", lifecycle.Worker(purgeIdempotencyKeys(idempotencyRepo, cfg.Idempotency.CleanupInterval, logger)))
 
-	// Démarrer le serveur gRPC
-	go func() {
-		lis, err := net.Listen("tcp", ":50051")
-		if err != nil {
-			logger.Error("Erreur lors de l'écoute gRPC: %v", err)
-			os.Exit(1)
-		}
+	// Sondes des dépendances: état du service de santé gRPC et disponibilité HTTP.
+	// Le dépôt des clients est en mémoire: CustomerService ne dépend d'aucun service externe.
+	healthServer := grpchealth.NewServer()
+	probeClient := &http.Client{Timeout: cfg.Health.CheckTimeout}
+	checker := health.NewChecker(healthServer, []health.Dependency{
+		{Name: "database", Probe: health.PingProbe(db)},
+		{Name: "kratos", Probe: health.HTTPProbe(probeClient, cfg.Ory.Kratos.AdminURL+"/health/ready")},
+		{Name: "hydra", Probe: health.HTTPProbe(probeClient, cfg.Ory.Hydra.AdminURL+"/health/ready")},
+		{Name: "keto", Probe: health.HTTPProbe(probeClient, cfg.Ory.Keto.ReadURL+"/health/ready")},
+	}, map[string][]string{
+		v1.AuthService_ServiceDesc.ServiceName:     {"database", "kratos", "hydra", "keto"},
+		v1.CustomerService_ServiceDesc.ServiceName: {},
+	}, cfg.Health.CheckInterval, cfg.Health.CheckTimeout, manager.Ready, logger)
+	manager.OnDrain(checker.Drain)
 
-		logger.Info("Serveur gRPC démarré sur le port 50051")
-		if err := grpcServer.Serve(lis); err != nil {
-			logger.Error("Erreur lors du démarrage du serveur gRPC: %v", err)
-			os.Exit(1)
+	// Serveur d'administration démarré en premier et arrêté en dernier: les métriques restent lisibles pendant l'arrêt
+	manager.Add("admin", lifecycle.HTTPServer(handler.NewAdminServer(cfg.Server, m.Handler())))
+
+	// Serveur gRPC, puis serveur HTTP (passerelle REST, fournisseur de login/consentement Hydra).
+	// Ils sont arrêtés dans l'ordre inverse, après l'échéance de retrait.
+	grpcServer := NewGRPCServer(authService, customerService, healthServer, idempotencyInterceptor, m, logger)
+	manager.Add("grpc", lifecycle.GRPCServer(grpcServer, net.JoinHostPort(cfg.Server.Host, cfg.Server.GRPCPort)))
+
+	// Passerelle REST/JSON: connexion locale au serveur gRPC, établie à la première requête
+	gatewayConn, err := grpc.NewClient(loopbackAddr(cfg.Server.Host, cfg.Server.GRPCPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		logger.Error("Erreur lors de la création de la passerelle REST", "error", err)
+		return err
+	}
+	manager.Add("gateway-conn", lifecycle.Closer(gatewayConn.Close))
+	gateway, err := handler.NewGateway(ctx, gatewayConn)
+	if err != nil {
+		logger.Error("Erreur lors de la création de la passerelle REST", "error", err)
+		return err
+	}
+
+	httpServer := handler.NewHTTPServer(cfg.Server, gateway, handler.NewOAuth2ProviderHandler(oauth2ProviderService, logger), checker)
+	manager.Add("http", lifecycle.HTTPServer(httpServer))
+
+	manager.Add("health-checker", lifecycle.Worker(checker.Run))
+
+	// Réconciliation périodique des identités Kratos avec la base locale
+	if cfg.Reconciliation.Interval > 0 {
+		reconciler := services.NewUserReconciler(userRepo, oryClient, cfg.Reconciliation.Interval, cfg.Reconciliation.PageSize, logger)
+		manager.Add("user-reconciler", lifecycle.Worker(reconciler.Run))
+	}
+
+	logStartup(logger, cfg)
+
+	if err := manager.Run(ctx); err != nil {
+		logger.Error("Arrêt du serveur en erreur", "error", err)
+		return err
+	}
+
+	logger.Info("🛑 Serveur arrêté")
+	return nil
+}
+
+// loopbackAddr adresse de connexion locale à un serveur: un hôte d'écoute non spécifié
+// (toutes les interfaces) est joint via localhost
+func loopbackAddr(host, port string) string {
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// purgeIdempotencyKeys supprime les clés d'idempotence expirées à chaque intervalle
+func purgeIdempotencyKeys(repo repository.IdempotencyRepository, interval time.Duration, logger common.Logger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+			deleted, err := repo.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				logger.Error("Erreur lors de la purge des clés d'idempotence", "error", err)
+				continue
+			}
+			logger.Debug("Clés d'idempotence expirées purgées", "deleted", deleted)
 		}
-	}()
+	}
+}
 
+// logStartup affiche les endpoints disponibles
+func logStartup(logger common.Logger, cfg *config.Config) {
 	logger.Info("🚀 Serveur Ndugu Backend démarré")
-	logger.Info("📡 gRPC Server: localhost:50051")
+	logger.Info("📡 gRPC Server", "addr", net.JoinHostPort(cfg.Server.Host, cfg.Server.GRPCPort))
+	logger.Info("🌐 HTTP Server", "addr", net.JoinHostPort(cfg.Server.Host, cfg.Server.Port))
+	logger.Info("📈 Admin Server (GET /metrics)", "addr", net.JoinHostPort(cfg.Server.Host, cfg.Server.AdminPort))
 	logger.Info("")
 	logger.Info("🔗 Endpoints gRPC disponibles:")
 	logger.Info("    - ndugu.v1.AuthService/CreateUser - Créer un utilisateur")
 	logger.Info("    - ndugu.v1.AuthService/GetUser - Récupérer un utilisateur")
+	logger.Info("    - ndugu.v1.AuthService/UpdateUser - Mettre à jour un utilisateur")
+	logger.Info("    - ndugu.v1.AuthService/DeleteUser - Supprimer un utilisateur")
+	logger.Info("    - ndugu.v1.AuthService/ListUsers - Lister les utilisateurs")
 	logger.Info("    - ndugu.v1.AuthService/ValidateSession - Valider une session")
+	logger.Info("    - ndugu.v1.AuthService/RevokeSession - Révoquer une session")
 	logger.Info("    - ndugu.v1.AuthService/CreateOAuth2Client - Créer un client OAuth2")
+	logger.Info("    - ndugu.v1.AuthService/GetOAuth2Client - Récupérer un client OAuth2")
+	logger.Info("    - ndugu.v1.AuthService/ListOAuth2Clients - Lister les clients OAuth2")
+	logger.Info("    - ndugu.v1.AuthService/UpdateOAuth2Client - Mettre à jour un client OAuth2")
+	logger.Info("    - ndugu.v1.AuthService/DeleteOAuth2Client - Supprimer un client OAuth2")
+	logger.Info("    - ndugu.v1.AuthService/RotateOAuth2ClientSecret - Renouveler le secret d'un client OAuth2")
 	logger.Info("    - ndugu.v1.AuthService/CreatePermission - Créer une permission")
+	logger.Info("    - ndugu.v1.AuthService/DeletePermission - Supprimer une permission")
 	logger.Info("    - ndugu.v1.AuthService/CheckPermission - Vérifier une permission")
+	logger.Info("    - ndugu.v1.AuthService/BatchCheckPermission - Vérifier plusieurs permissions")
+	logger.Info("    - ndugu.v1.AuthService/ExpandPermission - Arbre des sujets d'une relation")
+	logger.Info("    - ndugu.v1.AuthService/ListRelations - Lister les relations")
 	logger.Info("    - ndugu.v1.CustomerService/CreateCustomer - Créer un client")
+	logger.Info("    - ndugu.v1.CustomerService/GetCustomer - Récupérer un client")
+	logger.Info("    - ndugu.v1.CustomerService/GetCustomerByPhone - Récupérer un client par téléphone")
+	logger.Info("    - ndugu.v1.CustomerService/UpdateCustomer - Mettre à jour un client")
+	logger.Info("    - ndugu.v1.CustomerService/DeactivateCustomer - Désactiver un client")
+	logger.Info("    - ndugu.v1.CustomerService/ListCustomers - Lister les clients")
+	logger.Info("    - ndugu.v1.CustomerService/VerifyCustomerCredentials - Vérifier les identifiants d'un client")
+	logger.Info("    - grpc.health.v1.Health/Check|Watch - Santé du service (\"\", ndugu.v1.AuthService, ndugu.v1.CustomerService)")
+	logger.Info("")
+	logger.Info("🔗 Endpoints HTTP disponibles:")
+	logger.Info("    - /v1/... - Passerelle REST/JSON des services ndugu.v1 (voir API_ENDPOINTS.md)")
+	logger.Info("    - GET /oauth2/login - Lire un login_challenge Hydra")
+	logger.Info("    - POST /oauth2/login - Accepter un login_challenge Hydra")
+	logger.Info("    - POST /oauth2/login/reject - Refuser un login_challenge Hydra")
+	logger.Info("    - GET /oauth2/consent - Lire un consent_challenge Hydra (client, scopes demandés)")
+	logger.Info("    - POST /oauth2/consent - Accepter un consent_challenge Hydra pour les scopes accordés")
+	logger.Info("    - POST /oauth2/consent/reject - Refuser un consent_challenge Hydra")
+	logger.Info("    - GET /oauth2/logout - Lire un logout_challenge Hydra")
+	logger.Info("    - POST /oauth2/logout - Accepter un logout_challenge Hydra")
+	logger.Info("    - GET /health/live - Vivacité du processus")
+	logger.Info("    - GET /health/ready - Disponibilité du service et de ses dépendances (503 sinon)")
 	logger.Info("")
 	logger.Info("🔧 Services Ory:")
 	logger.Info("  - Kratos: http://localhost:4433 (public), http://localhost:4434 (admin)")
@@ -58,12 +302,4 @@ func main() {
 	logger.Info("  - Keto: http://localhost:4466 (read), http://localhost:4467 (write)")
 	logger.Info("")
 	logger.Info("🌍 API Gateway (APISIX): http://localhost:9080")
-
-	// Attendre un signal d'arrêt
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	logger.Info("🛑 Arrêt du serveur...")
-	// TODO: Implémenter l'arrêt gracieux
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/interceptors"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// Relation Keto des administrateurs de l'API (namespace "system" de ory/keto/keto.yml)
+const (
+	adminNamespace = "system"
+	adminObject    = "coreapi"
+	adminRelation  = "admin"
+)
+
+// methodPolicies déclare la politique d'accès de chaque méthode gRPC.
+// Toute méthode absente de cette table est refusée par l'intercepteur.
+var methodPolicies = map[string]interceptors.Policy{
+	// AuthService
+	v1.AuthService_CreateUser_FullMethodName:               admin(),
+	v1.AuthService_GetUser_FullMethodName:                  selfOrAdmin(getUserOwner),
+	v1.AuthService_UpdateUser_FullMethodName:               admin(),
+	v1.AuthService_DeleteUser_FullMethodName:               admin(),
+	v1.AuthService_ListUsers_FullMethodName:                admin(),
+	v1.AuthService_ValidateSession_FullMethodName:          interceptors.Public(),
+	v1.AuthService_RevokeSession_FullMethodName:            interceptors.Public(), // la possession du token suffit
+	v1.AuthService_CreateOAuth2Client_FullMethodName:       admin(),
+	v1.AuthService_GetOAuth2Client_FullMethodName:          admin(),
+	v1.AuthService_ListOAuth2Clients_FullMethodName:        admin(),
+	v1.AuthService_UpdateOAuth2Client_FullMethodName:       admin(),
+	v1.AuthService_DeleteOAuth2Client_FullMethodName:       admin(),
+	v1.AuthService_RotateOAuth2ClientSecret_FullMethodName: admin(),
+	v1.AuthService_CreatePermission_FullMethodName:         admin(),
+	v1.AuthService_DeletePermission_FullMethodName:         admin(),
+	v1.AuthService_CheckPermission_FullMethodName:          selfOrAdmin(checkPermissionOwner),
+	v1.AuthService_BatchCheckPermission_FullMethodName:     selfOrAdmin(batchCheckPermissionOwner),
+	v1.AuthService_ExpandPermission_FullMethodName:         admin(),
+	v1.AuthService_ListRelations_FullMethodName:            admin(),
+
+	// CustomerService: l'inscription et la vérification des identifiants sont publiques.
+	// Un client n'est rattaché à aucune identité Kratos: sa propriété ne peut pas être
+	// vérifiée, la lecture et la modification sont donc réservées aux administrateurs.
+	v1.CustomerService_CreateCustomer_FullMethodName:            interceptors.Public(),
+	v1.CustomerService_GetCustomer_FullMethodName:               admin(),
+	v1.CustomerService_GetCustomerByPhone_FullMethodName:        admin(),
+	v1.CustomerService_UpdateCustomer_FullMethodName:            admin(),
+	v1.CustomerService_DeactivateCustomer_FullMethodName:        admin(),
+	v1.CustomerService_ListCustomers_FullMethodName:             admin(),
+	v1.CustomerService_VerifyCustomerCredentials_FullMethodName: interceptors.Public(),
+
+	// Santé: interrogée par les orchestrateurs et répartiteurs de charge, sans authentification
+	healthpb.Health_Check_FullMethodName: interceptors.Public(),
+	healthpb.Health_List_FullMethodName:  interceptors.Public(),
+	healthpb.Health_Watch_FullMethodName: interceptors.Public(),
+
+	// Réflexion gRPC (débogage)
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName:      interceptors.Public(),
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: interceptors.Public(),
+}
+
+// idempotentMethods méthodes de modification acceptant une clé d'idempotence (métadonnée
+// idempotency-key, en-tête HTTP Idempotency-Key): une requête renvoyée avec la même clé
+// reçoit la réponse de la première au lieu d'être traitée à nouveau. Les réponses étant stockées
+// en clair, CreateOAuth2Client et RotateOAuth2ClientSecret, qui renvoient un secret, en sont exclues.
+var idempotentMethods = map[string]bool{
+	v1.AuthService_CreateUser_FullMethodName:         true,
+	v1.AuthService_UpdateUser_FullMethodName:         true,
+	v1.AuthService_DeleteUser_FullMethodName:         true,
+	v1.AuthService_UpdateOAuth2Client_FullMethodName: true,
+	v1.AuthService_DeleteOAuth2Client_FullMethodName: true,
+	v1.AuthService_CreatePermission_FullMethodName:   true,
+	v1.AuthService_DeletePermission_FullMethodName:   true,
+
+	v1.CustomerService_CreateCustomer_FullMethodName:     true,
+	v1.CustomerService_UpdateCustomer_FullMethodName:     true,
+	v1.CustomerService_DeactivateCustomer_FullMethodName: true,
+}
+
+// admin politique des méthodes réservées aux administrateurs de l'API
+func admin() interceptors.Policy {
+	return interceptors.RequireRelation(adminNamespace, adminObject, adminRelation)
+}
+
+// selfOrAdmin politique des méthodes réservées au sujet propriétaire de la ressource et aux administrateurs
+func selfOrAdmin(owner func(req interface{}) string) interceptors.Policy {
+	return interceptors.RequireOwnerOrRelation(owner, adminNamespace, adminObject, adminRelation)
+}
+
+// getUserOwner un utilisateur est propriétaire de son propre enregistrement
+func getUserOwner(req interface{}) string {
+	r, _ := req.(*v1.GetUserRequest)
+	return r.GetUserId()
+}
+
+// checkPermissionOwner un sujet peut vérifier ses propres permissions; vérifier celles
+// d'un autre sujet ou d'un subject set révélerait ses accès
+func checkPermissionOwner(req interface{}) string {
+	r, _ := req.(*v1.CheckPermissionRequest)
+	return r.GetSubject()
+}
+
+// batchCheckPermissionOwner propriétaire d'un lot dont toutes les vérifications portent sur le même sujet
+func batchCheckPermissionOwner(req interface{}) string {
+	r, _ := req.(*v1.BatchCheckPermissionRequest)
+	owner := ""
+	for i, check := range r.GetChecks() {
+		if i > 0 && check.GetSubject() != owner {
+			return ""
+		}
+		owner = check.GetSubject()
+	}
+	return owner
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"ndugu-backend/internal/common"
+	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/interceptors"
+	"ndugu-backend/internal/models"
+)
+
+// policyAuthenticator authentifie la session "user-session" (user-1, sans relation Keto)
+type policyAuthenticator struct{}
+
+func (policyAuthenticator) ValidateSession(ctx context.Context, req *models.ValidateSessionRequest) (*models.ValidateSessionResponse, error) {
+	return &models.ValidateSessionResponse{Valid: req.SessionToken == "user-session", UserID: "user-1"}, nil
+}
+
+func (policyAuthenticator) IntrospectAccessToken(ctx context.Context, token string) (*models.TokenIntrospection, error) {
+	return &models.TokenIntrospection{Active: false}, nil
+}
+
+func (policyAuthenticator) CheckPermission(ctx context.Context, req *models.CheckPermissionRequest) (*models.PermissionResponse, error) {
+	return &models.PermissionResponse{HasPermission: false}, nil
+}
+
+func TestMethodPolicies_OtherUsersRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{name: "own user", method: v1.AuthService_GetUser_FullMethodName, req: &v1.GetUserRequest{UserId: "user-1"}, wantCode: codes.OK},
+		{name: "other user", method: v1.AuthService_GetUser_FullMethodName, req: &v1.GetUserRequest{UserId: "user-2"}, wantCode: codes.PermissionDenied},
+		{name: "customer by id", method: v1.CustomerService_GetCustomer_FullMethodName, req: &v1.GetCustomerRequest{CustomerId: "c-1"}, wantCode: codes.PermissionDenied},
+		{name: "customer by phone", method: v1.CustomerService_GetCustomerByPhone_FullMethodName, req: &v1.GetCustomerByPhoneRequest{}, wantCode: codes.PermissionDenied},
+		{name: "customer password update", method: v1.CustomerService_UpdateCustomer_FullMethodName, req: &v1.UpdateCustomerRequest{CustomerId: "c-1"}, wantCode: codes.PermissionDenied},
+		{name: "own permission", method: v1.AuthService_CheckPermission_FullMethodName, req: &v1.CheckPermissionRequest{Subject: "user-1"}, wantCode: codes.OK},
+		{name: "other user's permission", method: v1.AuthService_CheckPermission_FullMethodName, req: &v1.CheckPermissionRequest{Subject: "user-2"}, wantCode: codes.PermissionDenied},
+		{name: "subject set permission", method: v1.AuthService_CheckPermission_FullMethodName, req: &v1.CheckPermissionRequest{Subject: "groups:admins#member"}, wantCode: codes.PermissionDenied},
+		{name: "own permissions batch", method: v1.AuthService_BatchCheckPermission_FullMethodName, req: &v1.BatchCheckPermissionRequest{Checks: []*v1.CheckPermissionRequest{{Subject: "user-1"}, {Subject: "user-1"}}}, wantCode: codes.OK},
+		{name: "batch with another user", method: v1.AuthService_BatchCheckPermission_FullMethodName, req: &v1.BatchCheckPermissionRequest{Checks: []*v1.CheckPermissionRequest{{Subject: "user-1"}, {Subject: "user-2"}}}, wantCode: codes.PermissionDenied},
+	}
+
+	interceptor := interceptors.NewAuthInterceptor(policyAuthenticator{}, methodPolicies, common.NewSimpleLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-session-token", "user-session"))
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+			_, err := interceptor.Unary()(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestIdempotentMethods_ExcludeSecretResponses(t *testing.T) {
+	// Les réponses rejouées sont stockées en clair: un secret client ne doit jamais y figurer
+	for _, method := range []string{v1.AuthService_CreateOAuth2Client_FullMethodName, v1.AuthService_RotateOAuth2ClientSecret_FullMethodName} {
+		if idempotentMethods[method] {
+			t.Errorf("%s accepts an idempotency key, its client secret would be stored", method)
+		}
+	}
+}
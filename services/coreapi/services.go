@@ -5,13 +5,16 @@ import (
 
 	"ndugu-backend/internal/common"
 	v1 "ndugu-backend/internal/grpc/api/v1"
+	"ndugu-backend/internal/interceptors"
+	"ndugu-backend/internal/metrics"
 	"ndugu-backend/internal/models"
 	"ndugu-backend/internal/services"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,8 +26,20 @@ type gRPCServer struct {
 }
 
 // NewGRPCServer crée une nouvelle instance du serveur gRPC
-func NewGRPCServer(authService services.AuthService, logger common.Logger) *grpc.Server {
-	server := grpc.NewServer()
+func NewGRPCServer(authService services.AuthService, customerService services.CustomerService, healthServer *health.Server, idempotencyInterceptor *interceptors.IdempotencyInterceptor, m *metrics.Metrics, logger common.Logger) *grpc.Server {
+	// La journalisation est la plus externe pour corréler tout l'appel et consigner le statut final;
+	// les métriques observent aussi le statut final; la traduction des erreurs couvre aussi celles de l'authentification.
+	// L'idempotence suit l'authentification: les clés sont propres au sujet authentifié.
+	loggingInterceptor := interceptors.NewLoggingInterceptor(logger)
+	metricsInterceptor := interceptors.NewMetricsInterceptor(m)
+	errorInterceptor := interceptors.NewErrorInterceptor(logger)
+	authInterceptor := interceptors.NewAuthInterceptor(authService, methodPolicies, logger)
+	server := grpc.NewServer(
+		// Le contexte de trace W3C des métadonnées entrantes est extrait avant les intercepteurs
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(loggingInterceptor.Unary(), metricsInterceptor.Unary(), errorInterceptor.Unary(), authInterceptor.Unary(), idempotencyInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(loggingInterceptor.Stream(), metricsInterceptor.Stream(), errorInterceptor.Stream(), authInterceptor.Stream()),
+	)
 
 	// Créer l'implémentation du service
 	grpcService := &gRPCServer{
@@ -34,6 +49,13 @@ func NewGRPCServer(authService services.AuthService, logger common.Logger) *grpc
 
 	// Enregistrer les services gRPC
 	v1.RegisterAuthServiceServer(server, grpcService)
+	v1.RegisterCustomerServiceServer(server, &customerGRPCServer{
+		customerService: customerService,
+		logger:          logger,
+	})
+
+	// Service de santé standard (grpc.health.v1), alimenté par le health.Checker
+	healthpb.RegisterHealthServer(server, healthServer)
 
 	// Activer la réflexion gRPC pour le débogage
 	reflection.Register(server)
@@ -47,21 +69,7 @@ func NewGRPCServer(authService services.AuthService, logger common.Logger) *grpc
 
 // CreateUser crée un nouvel utilisateur via Kratos
 func (s *gRPCServer) CreateUser(ctx context.Context, req *v1.CreateUserRequest) (*v1.CreateUserResponse, error) {
-	s.logger.Info("gRPC CreateUser appelé", "email", req.Email, "firstName", req.FirstName, "lastName", req.LastName)
-
-	// Validation des données d'entrée
-	if req.Email == "" {
-		s.logger.Error("Email requis manquant dans la requête gRPC CreateUser")
-		return nil, status.Error(codes.InvalidArgument, "Email requis")
-	}
-	if req.FirstName == "" {
-		s.logger.Error("Prénom requis manquant dans la requête gRPC CreateUser", "email", req.Email)
-		return nil, status.Error(codes.InvalidArgument, "Prénom requis")
-	}
-	if req.LastName == "" {
-		s.logger.Error("Nom requis manquant dans la requête gRPC CreateUser", "email", req.Email)
-		return nil, status.Error(codes.InvalidArgument, "Nom requis")
-	}
+	s.logger.WithContext(ctx).Info("gRPC CreateUser appelé", "email", req.Email, "firstName", req.FirstName, "lastName", req.LastName)
 
 	// Créer la requête pour le service
 	createReq := &models.CreateUserRequest{
@@ -73,11 +81,11 @@ func (s *gRPCServer) CreateUser(ctx context.Context, req *v1.CreateUserRequest)
 	// Appeler le service
 	user, err := s.authService.CreateUser(ctx, createReq)
 	if err != nil {
-		s.logger.Error("Erreur lors de la création de l'utilisateur via gRPC", "email", req.Email, "error", err)
-		return nil, status.Error(codes.Internal, "Erreur lors de la création de l'utilisateur")
+		s.logger.WithContext(ctx).Error("Erreur lors de la création de l'utilisateur via gRPC", "email", req.Email, "error", err)
+		return nil, err
 	}
 
-	s.logger.Info("Utilisateur créé avec succès via gRPC", "userId", user.ID, "email", user.Email)
+	s.logger.WithContext(ctx).Info("Utilisateur créé avec succès via gRPC", "userId", user.ID, "email", user.Email)
 
 	// Convertir en réponse gRPC
 	return &v1.CreateUserResponse{
@@ -91,21 +99,18 @@ func (s *gRPCServer) CreateUser(ctx context.Context, req *v1.CreateUserRequest)
 
 // GetUser récupère un utilisateur par son ID
 func (s *gRPCServer) GetUser(ctx context.Context, req *v1.GetUserRequest) (*v1.GetUserResponse, error) {
-	s.logger.Info("gRPC GetUser appelé pour ID: %s", req.UserId)
+	s.logger.WithContext(ctx).Info("gRPC GetUser appelé", "userId", req.UserId)
 
 	// Validation des données d'entrée
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "ID utilisateur requis")
+		return nil, common.NewFieldError("userId", "required")
 	}
 
 	// Appeler le service
 	user, err := s.authService.GetUser(ctx, req.UserId)
 	if err != nil {
-		s.logger.Error("Erreur lors de la récupération de l'utilisateur: %v", err)
-		if isNotFoundError(err) {
-			return nil, status.Error(codes.NotFound, "Utilisateur non trouvé")
-		}
-		return nil, status.Error(codes.Internal, "Erreur lors de la récupération de l'utilisateur")
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération de l'utilisateur", "userId", req.UserId, "error", err)
+		return nil, err
 	}
 
 	// Convertir en réponse gRPC
@@ -119,25 +124,105 @@ func (s *gRPCServer) GetUser(ctx context.Context, req *v1.GetUserRequest) (*v1.G
 	}, nil
 }
 
+// UpdateUser met à jour l'email et le nom d'un utilisateur selon le masque de mise à jour
+func (s *gRPCServer) UpdateUser(ctx context.Context, req *v1.UpdateUserRequest) (*v1.UpdateUserResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC UpdateUser appelé", "userId", req.UserId, "updateMask", req.GetUpdateMask().GetPaths())
+
+	if req.UserId == "" {
+		return nil, common.NewFieldError("userId", "required")
+	}
+
+	user, err := s.authService.UpdateUser(ctx, &models.UpdateUserRequest{
+		ID:         req.UserId,
+		Email:      req.Email,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		UpdateMask: req.GetUpdateMask().GetPaths(),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour de l'utilisateur", "userId", req.UserId, "error", err)
+		return nil, err
+	}
+
+	return &v1.UpdateUserResponse{User: toProtoUser(user)}, nil
+}
+
+// DeleteUser supprime un utilisateur, son identité Kratos et ses permissions Keto
+func (s *gRPCServer) DeleteUser(ctx context.Context, req *v1.DeleteUserRequest) (*v1.DeleteUserResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC DeleteUser appelé", "userId", req.UserId)
+
+	if req.UserId == "" {
+		return nil, common.NewFieldError("userId", "required")
+	}
+
+	if err := s.authService.DeleteUser(ctx, req.UserId); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression de l'utilisateur", "userId", req.UserId, "error", err)
+		return nil, err
+	}
+
+	return &v1.DeleteUserResponse{
+		Success: true,
+		Message: "Utilisateur supprimé",
+	}, nil
+}
+
+// ListUsers liste les utilisateurs avec pagination par jeton et filtres optionnels
+func (s *gRPCServer) ListUsers(ctx context.Context, req *v1.ListUsersRequest) (*v1.ListUsersResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC ListUsers appelé", "pageSize", req.PageSize)
+
+	if req.PageSize < 0 {
+		return nil, common.NewFieldError("pageSize", "gte", "0")
+	}
+
+	listReq := &models.ListUsersRequest{
+		PageSize:    int(req.PageSize),
+		PageToken:   req.PageToken,
+		EmailPrefix: req.EmailPrefix,
+	}
+	if req.CreatedAfter != nil {
+		listReq.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		listReq.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+
+	page, err := s.authService.ListUsers(ctx, listReq)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération des utilisateurs", "error", err)
+		return nil, err
+	}
+
+	response := &v1.ListUsersResponse{
+		Users:         make([]*v1.User, 0, len(page.Users)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, user := range page.Users {
+		response.Users = append(response.Users, toProtoUser(user))
+	}
+
+	return response, nil
+}
+
 // ValidateSession valide une session
 func (s *gRPCServer) ValidateSession(ctx context.Context, req *v1.ValidateSessionRequest) (*v1.ValidateSessionResponse, error) {
-	s.logger.Info("gRPC ValidateSession appelé")
+	s.logger.WithContext(ctx).Info("gRPC ValidateSession appelé")
 
 	// Validation des données d'entrée
-	if req.SessionToken == "" {
-		return nil, status.Error(codes.InvalidArgument, "Token de session requis")
+	if req.SessionToken == "" && req.SessionCookie == "" {
+		return nil, common.NewFieldError("sessionToken", "required")
 	}
 
 	// Créer la requête pour le service
 	validateReq := &models.ValidateSessionRequest{
-		SessionToken: req.SessionToken,
+		SessionToken:  req.SessionToken,
+		SessionCookie: req.SessionCookie,
 	}
 
 	// Appeler le service
 	session, err := s.authService.ValidateSession(ctx, validateReq)
 	if err != nil {
-		s.logger.Error("Erreur lors de la validation de la session: %v", err)
-		return nil, status.Error(codes.Internal, "Erreur lors de la validation de la session")
+		s.logger.WithContext(ctx).Error("Erreur lors de la validation de la session", "error", err)
+		return nil, err
 	}
 
 	// Convertir en réponse gRPC
@@ -149,38 +234,57 @@ func (s *gRPCServer) ValidateSession(ctx context.Context, req *v1.ValidateSessio
 		response.UserId = session.UserID
 		response.Email = session.Email
 		response.ExpiresAt = timestamppb.New(session.ExpiresAt)
+		response.AuthenticatedAt = timestamppb.New(session.AuthenticatedAt)
+		response.Aal = session.AAL
+		response.AuthenticationMethods = session.AuthenticationMethods
 	}
 
 	return response, nil
 }
 
-// CreateOAuth2Client crée un client OAuth2
-func (s *gRPCServer) CreateOAuth2Client(ctx context.Context, req *v1.CreateOAuth2ClientRequest) (*v1.CreateOAuth2ClientResponse, error) {
-	s.logger.Info("gRPC CreateOAuth2Client appelé pour client: %s", req.ClientId)
+// RevokeSession révoque un token de session
+func (s *gRPCServer) RevokeSession(ctx context.Context, req *v1.RevokeSessionRequest) (*v1.RevokeSessionResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC RevokeSession appelé")
 
-	// Validation des données d'entrée
-	if req.ClientId == "" {
-		return nil, status.Error(codes.InvalidArgument, "ID client requis")
-	}
-	if req.ClientName == "" {
-		return nil, status.Error(codes.InvalidArgument, "Nom client requis")
+	if req.SessionToken == "" {
+		return nil, common.NewFieldError("sessionToken", "required")
 	}
-	if req.RedirectUri == "" {
-		return nil, status.Error(codes.InvalidArgument, "URI de redirection requise")
+
+	if err := s.authService.RevokeSession(ctx, &models.RevokeSessionRequest{SessionToken: req.SessionToken}); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la révocation de la session", "error", err)
+		return nil, err
 	}
 
+	return &v1.RevokeSessionResponse{
+		Success: true,
+		Message: "Session révoquée",
+	}, nil
+}
+
+// CreateOAuth2Client crée un client OAuth2; le secret n'est renvoyé qu'ici
+func (s *gRPCServer) CreateOAuth2Client(ctx context.Context, req *v1.CreateOAuth2ClientRequest) (*v1.CreateOAuth2ClientResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC CreateOAuth2Client appelé", "clientId", req.ClientId, "clientName", req.ClientName)
+
 	// Créer la requête pour le service
+	redirectURIs := req.RedirectUris
+	if req.RedirectUri != "" {
+		redirectURIs = append([]string{req.RedirectUri}, redirectURIs...)
+	}
 	createReq := &models.CreateOAuth2ClientRequest{
-		ID:          req.ClientId,
-		Name:        req.ClientName,
-		RedirectURI: req.RedirectUri,
+		ID:                      req.ClientId,
+		Name:                    req.ClientName,
+		RedirectURIs:            redirectURIs,
+		GrantTypes:              req.GrantTypes,
+		ResponseTypes:           req.ResponseTypes,
+		Scopes:                  req.Scopes,
+		TokenEndpointAuthMethod: req.TokenEndpointAuthMethod,
 	}
 
 	// Appeler le service
 	client, err := s.authService.CreateOAuth2Client(ctx, createReq)
 	if err != nil {
-		s.logger.Error("Erreur lors de la création du client OAuth2: %v", err)
-		return nil, status.Error(codes.Internal, "Erreur lors de la création du client OAuth2")
+		s.logger.WithContext(ctx).Error("Erreur lors de la création du client OAuth2", "clientName", req.ClientName, "error", err)
+		return nil, err
 	}
 
 	// Convertir en réponse gRPC
@@ -189,27 +293,120 @@ func (s *gRPCServer) CreateOAuth2Client(ctx context.Context, req *v1.CreateOAuth
 		ClientName:   client.Name,
 		ClientSecret: client.Secret,
 		RedirectUris: client.RedirectURIs,
+		Client:       toProtoOAuth2Client(client),
 	}, nil
 }
 
-// CreatePermission crée une permission
-func (s *gRPCServer) CreatePermission(ctx context.Context, req *v1.CreatePermissionRequest) (*v1.CreatePermissionResponse, error) {
-	s.logger.Info("gRPC CreatePermission appelé pour %s:%s#%s@%s", req.Namespace, req.Object, req.Relation, req.Subject)
+// GetOAuth2Client récupère un client OAuth2
+func (s *gRPCServer) GetOAuth2Client(ctx context.Context, req *v1.GetOAuth2ClientRequest) (*v1.GetOAuth2ClientResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC GetOAuth2Client appelé", "clientId", req.ClientId)
 
-	// Validation des données d'entrée
-	if req.Namespace == "" {
-		return nil, status.Error(codes.InvalidArgument, "Namespace requis")
+	if req.ClientId == "" {
+		return nil, common.NewFieldError("clientId", "required")
+	}
+
+	client, err := s.authService.GetOAuth2Client(ctx, req.ClientId)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération du client OAuth2", "clientId", req.ClientId, "error", err)
+		return nil, err
+	}
+
+	return &v1.GetOAuth2ClientResponse{Client: toProtoOAuth2Client(client)}, nil
+}
+
+// ListOAuth2Clients liste les clients OAuth2 avec pagination par jeton
+func (s *gRPCServer) ListOAuth2Clients(ctx context.Context, req *v1.ListOAuth2ClientsRequest) (*v1.ListOAuth2ClientsResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC ListOAuth2Clients appelé", "pageSize", req.PageSize)
+
+	if req.PageSize < 0 {
+		return nil, common.NewFieldError("pageSize", "gte", "0")
+	}
+
+	page, err := s.authService.ListOAuth2Clients(ctx, int(req.PageSize), req.PageToken)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération des clients OAuth2", "error", err)
+		return nil, err
+	}
+
+	response := &v1.ListOAuth2ClientsResponse{
+		Clients:       make([]*v1.OAuth2Client, 0, len(page.Clients)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, client := range page.Clients {
+		response.Clients = append(response.Clients, toProtoOAuth2Client(client))
+	}
+
+	return response, nil
+}
+
+// UpdateOAuth2Client met à jour un client OAuth2
+func (s *gRPCServer) UpdateOAuth2Client(ctx context.Context, req *v1.UpdateOAuth2ClientRequest) (*v1.UpdateOAuth2ClientResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC UpdateOAuth2Client appelé", "clientId", req.ClientId)
+
+	if req.ClientId == "" {
+		return nil, common.NewFieldError("clientId", "required")
 	}
-	if req.Object == "" {
-		return nil, status.Error(codes.InvalidArgument, "Objet requis")
+
+	client, err := s.authService.UpdateOAuth2Client(ctx, &models.UpdateOAuth2ClientRequest{
+		ID:                      req.ClientId,
+		Name:                    req.ClientName,
+		RedirectURIs:            req.RedirectUris,
+		GrantTypes:              req.GrantTypes,
+		ResponseTypes:           req.ResponseTypes,
+		Scopes:                  req.Scopes,
+		TokenEndpointAuthMethod: req.TokenEndpointAuthMethod,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la mise à jour du client OAuth2", "clientId", req.ClientId, "error", err)
+		return nil, err
 	}
-	if req.Relation == "" {
-		return nil, status.Error(codes.InvalidArgument, "Relation requise")
+
+	return &v1.UpdateOAuth2ClientResponse{Client: toProtoOAuth2Client(client)}, nil
+}
+
+// DeleteOAuth2Client supprime un client OAuth2
+func (s *gRPCServer) DeleteOAuth2Client(ctx context.Context, req *v1.DeleteOAuth2ClientRequest) (*v1.DeleteOAuth2ClientResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC DeleteOAuth2Client appelé", "clientId", req.ClientId)
+
+	if req.ClientId == "" {
+		return nil, common.NewFieldError("clientId", "required")
+	}
+
+	if err := s.authService.DeleteOAuth2Client(ctx, req.ClientId); err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression du client OAuth2", "clientId", req.ClientId, "error", err)
+		return nil, err
+	}
+
+	return &v1.DeleteOAuth2ClientResponse{
+		Success: true,
+		Message: "Client OAuth2 supprimé",
+	}, nil
+}
+
+// RotateOAuth2ClientSecret génère un nouveau secret; il n'est renvoyé qu'une fois
+func (s *gRPCServer) RotateOAuth2ClientSecret(ctx context.Context, req *v1.RotateOAuth2ClientSecretRequest) (*v1.RotateOAuth2ClientSecretResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC RotateOAuth2ClientSecret appelé", "clientId", req.ClientId)
+
+	if req.ClientId == "" {
+		return nil, common.NewFieldError("clientId", "required")
 	}
-	if req.Subject == "" {
-		return nil, status.Error(codes.InvalidArgument, "Sujet requis")
+
+	client, err := s.authService.RotateOAuth2ClientSecret(ctx, req.ClientId)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la rotation du secret OAuth2", "clientId", req.ClientId, "error", err)
+		return nil, err
 	}
 
+	return &v1.RotateOAuth2ClientSecretResponse{
+		ClientId:     client.ID,
+		ClientSecret: client.Secret,
+	}, nil
+}
+
+// CreatePermission crée une permission
+func (s *gRPCServer) CreatePermission(ctx context.Context, req *v1.CreatePermissionRequest) (*v1.CreatePermissionResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC CreatePermission appelé", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation, "subject", req.Subject)
+
 	// Créer la requête pour le service
 	createReq := &models.CreatePermissionRequest{
 		Namespace: req.Namespace,
@@ -221,8 +418,8 @@ func (s *gRPCServer) CreatePermission(ctx context.Context, req *v1.CreatePermiss
 	// Appeler le service
 	permission, err := s.authService.CreatePermission(ctx, createReq)
 	if err != nil {
-		s.logger.Error("Erreur lors de la création de la permission: %v", err)
-		return nil, status.Error(codes.Internal, "Erreur lors de la création de la permission")
+		s.logger.WithContext(ctx).Error("Erreur lors de la création de la permission", "error", err)
+		return nil, err
 	}
 
 	// Convertir en réponse gRPC
@@ -232,24 +429,36 @@ func (s *gRPCServer) CreatePermission(ctx context.Context, req *v1.CreatePermiss
 	}, nil
 }
 
-// CheckPermission vérifie une permission
-func (s *gRPCServer) CheckPermission(ctx context.Context, req *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
-	s.logger.Info("gRPC CheckPermission appelé pour %s:%s#%s@%s", req.Namespace, req.Object, req.Relation, req.Subject)
+// DeletePermission supprime une permission
+func (s *gRPCServer) DeletePermission(ctx context.Context, req *v1.DeletePermissionRequest) (*v1.DeletePermissionResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC DeletePermission appelé", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation, "subject", req.Subject)
 
-	// Validation des données d'entrée
-	if req.Namespace == "" {
-		return nil, status.Error(codes.InvalidArgument, "Namespace requis")
-	}
-	if req.Object == "" {
-		return nil, status.Error(codes.InvalidArgument, "Objet requis")
-	}
-	if req.Relation == "" {
-		return nil, status.Error(codes.InvalidArgument, "Relation requise")
+	// Créer la requête pour le service
+	deleteReq := &models.DeletePermissionRequest{
+		Namespace: req.Namespace,
+		Object:    req.Object,
+		Relation:  req.Relation,
+		Subject:   req.Subject,
 	}
-	if req.Subject == "" {
-		return nil, status.Error(codes.InvalidArgument, "Sujet requis")
+
+	// Appeler le service
+	permission, err := s.authService.DeletePermission(ctx, deleteReq)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la suppression de la permission", "error", err)
+		return nil, err
 	}
 
+	// Convertir en réponse gRPC
+	return &v1.DeletePermissionResponse{
+		Success: true,
+		Message: permission.Message,
+	}, nil
+}
+
+// CheckPermission vérifie une permission
+func (s *gRPCServer) CheckPermission(ctx context.Context, req *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC CheckPermission appelé", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation, "subject", req.Subject)
+
 	// Créer la requête pour le service
 	checkReq := &models.CheckPermissionRequest{
 		Namespace: req.Namespace,
@@ -261,8 +470,8 @@ func (s *gRPCServer) CheckPermission(ctx context.Context, req *v1.CheckPermissio
 	// Appeler le service
 	permission, err := s.authService.CheckPermission(ctx, checkReq)
 	if err != nil {
-		s.logger.Error("Erreur lors de la vérification de la permission: %v", err)
-		return nil, status.Error(codes.Internal, "Erreur lors de la vérification de la permission")
+		s.logger.WithContext(ctx).Error("Erreur lors de la vérification de la permission", "error", err)
+		return nil, err
 	}
 
 	// Convertir en réponse gRPC
@@ -272,16 +481,142 @@ func (s *gRPCServer) CheckPermission(ctx context.Context, req *v1.CheckPermissio
 	}, nil
 }
 
+// BatchCheckPermission vérifie plusieurs permissions; chaque résultat porte sa propre erreur
+func (s *gRPCServer) BatchCheckPermission(ctx context.Context, req *v1.BatchCheckPermissionRequest) (*v1.BatchCheckPermissionResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC BatchCheckPermission appelé", "checks", len(req.Checks))
+
+	// Créer la requête pour le service
+	batchReq := &models.BatchCheckPermissionRequest{}
+	for _, check := range req.Checks {
+		batchReq.Checks = append(batchReq.Checks, &models.CheckPermissionRequest{
+			Namespace: check.Namespace,
+			Object:    check.Object,
+			Relation:  check.Relation,
+			Subject:   check.Subject,
+		})
+	}
+
+	// Appeler le service
+	batch, err := s.authService.BatchCheckPermission(ctx, batchReq)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la vérification des permissions", "error", err)
+		return nil, err
+	}
+
+	// Convertir en réponse gRPC; les erreurs sont traduites comme celles de l'appel
+	response := &v1.BatchCheckPermissionResponse{}
+	lang := common.LanguageFromContext(ctx)
+	for _, result := range batch.Results {
+		protoResult := &v1.BatchCheckPermissionResponse_Result{HasPermission: result.HasPermission}
+		if result.Error != nil {
+			protoResult.Error = interceptors.ToLocalizedStatus(result.Error, lang).Proto()
+		}
+		response.Results = append(response.Results, protoResult)
+	}
+
+	return response, nil
+}
+
+// ExpandPermission retourne l'arbre des sujets ayant une relation sur un objet
+func (s *gRPCServer) ExpandPermission(ctx context.Context, req *v1.ExpandPermissionRequest) (*v1.ExpandPermissionResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC ExpandPermission appelé", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation)
+
+	// Créer la requête pour le service
+	expandReq := &models.ExpandPermissionRequest{
+		Namespace: req.Namespace,
+		Object:    req.Object,
+		Relation:  req.Relation,
+		MaxDepth:  int(req.MaxDepth),
+	}
+
+	// Appeler le service
+	tree, err := s.authService.ExpandPermission(ctx, expandReq)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de l'expansion de la permission", "error", err)
+		return nil, err
+	}
+
+	// Convertir en réponse gRPC
+	response := &v1.ExpandPermissionResponse{}
+	if tree != nil {
+		response.Tree = toProtoSubjectTree(tree)
+	}
+	return response, nil
+}
+
+// ListRelations liste les tuples de relation avec filtres optionnels et pagination par jeton
+func (s *gRPCServer) ListRelations(ctx context.Context, req *v1.ListRelationsRequest) (*v1.ListRelationsResponse, error) {
+	s.logger.WithContext(ctx).Info("gRPC ListRelations appelé", "namespace", req.Namespace, "object", req.Object, "relation", req.Relation, "subject", req.Subject)
+
+	// Créer la requête pour le service
+	listReq := &models.ListRelationsRequest{
+		Namespace: req.Namespace,
+		Object:    req.Object,
+		Relation:  req.Relation,
+		Subject:   req.Subject,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+
+	// Appeler le service
+	page, err := s.authService.ListRelations(ctx, listReq)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Erreur lors de la récupération des relations", "error", err)
+		return nil, err
+	}
+
+	// Convertir en réponse gRPC
+	response := &v1.ListRelationsResponse{NextPageToken: page.NextPageToken}
+	for _, relation := range page.Relations {
+		response.Relations = append(response.Relations, &v1.RelationTuple{
+			Namespace: relation.Namespace,
+			Object:    relation.Object,
+			Relation:  relation.Relation,
+			Subject:   relation.Subject,
+		})
+	}
+	return response, nil
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
 
-// isNotFoundError vérifie si une erreur est de type "non trouvé"
-func isNotFoundError(err error) bool {
-	if appErr, ok := err.(*common.AppError); ok {
-		return appErr.Code == common.ErrCodeNotFound ||
-			appErr.Code == common.ErrCodeUserNotFound ||
-			appErr.Code == common.ErrCodeCustomerNotFound
+// toProtoUser convertit un utilisateur en message gRPC
+func toProtoUser(user *models.UserResponse) *v1.User {
+	return &v1.User{
+		UserId:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// toProtoSubjectTree convertit un arbre de sujets en message gRPC
+func toProtoSubjectTree(tree *models.SubjectTree) *v1.SubjectTree {
+	node := &v1.SubjectTree{
+		Type:    tree.Type,
+		Subject: tree.Subject,
+	}
+	for _, child := range tree.Children {
+		node.Children = append(node.Children, toProtoSubjectTree(child))
+	}
+	return node
+}
+
+// toProtoOAuth2Client convertit un client OAuth2 en message gRPC (sans secret)
+func toProtoOAuth2Client(client *models.OAuth2Client) *v1.OAuth2Client {
+	return &v1.OAuth2Client{
+		ClientId:                client.ID,
+		ClientName:              client.Name,
+		RedirectUris:            client.RedirectURIs,
+		GrantTypes:              client.GrantTypes,
+		ResponseTypes:           client.ResponseTypes,
+		Scopes:                  client.Scopes,
+		TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+		CreatedAt:               timestamppb.New(client.CreatedAt),
+		UpdatedAt:               timestamppb.New(client.UpdatedAt),
 	}
-	return false
 }